@@ -0,0 +1,149 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugr-lab/airport-go/auth"
+)
+
+func testRecord(table string) *Record {
+	return &Record{
+		Time:          time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Principal:     "alice",
+		Operation:     auth.OperationDelete,
+		Schema:        "main",
+		Table:         table,
+		Rows:          3,
+		TransactionID: "tx-1",
+		TraceID:       "query-42",
+	}
+}
+
+func TestJSONLinesSinkChain(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesSink(&buf)
+	for _, table := range []string{"a", "b", "c"} {
+		if err := sink.Audit(context.Background(), testRecord(table)); err != nil {
+			t.Fatalf("Audit failed: %v", err)
+		}
+	}
+
+	last, err := VerifyJSONLines(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("VerifyJSONLines failed: %v", err)
+	}
+	if last != sink.LastHash() {
+		t.Errorf("verified last hash = %s, want %s", last, sink.LastHash())
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+	var first map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("first line is not JSON: %v", err)
+	}
+	if first["table"] != "a" || first["transaction_id"] != "tx-1" || first["trace_id"] != "query-42" || first["prev_hash"] != "" {
+		t.Errorf("first line = %s", lines[0])
+	}
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{"modified", []string{lines[0], strings.Replace(lines[1], `"rows":3`, `"rows":0`, 1), lines[2]}, "line 2: record does not match"},
+		{"removed", []string{lines[0], lines[2]}, "line 2: chain broken"},
+		{"reordered", []string{lines[1], lines[0], lines[2]}, "line 1: chain broken"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyJSONLines(strings.NewReader(strings.Join(tt.lines, "")))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("VerifyJSONLines error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenJSONLinesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	sink, err := OpenJSONLinesFile(path)
+	if err != nil {
+		t.Fatalf("OpenJSONLinesFile failed: %v", err)
+	}
+	_ = sink.Audit(context.Background(), testRecord("a"))
+	hash := sink.LastHash()
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	sink, err = OpenJSONLinesFile(path)
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	if sink.LastHash() != hash {
+		t.Errorf("reopened last hash = %s, want %s", sink.LastHash(), hash)
+	}
+	_ = sink.Audit(context.Background(), testRecord("b"))
+	_ = sink.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := VerifyJSONLines(f); err != nil {
+		t.Errorf("VerifyJSONLines of reopened file failed: %v", err)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.jsonl")
+	if err := os.WriteFile(bad, []byte("not json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenJSONLinesFile(bad); err == nil {
+		t.Error("OpenJSONLinesFile of a non-audit file should fail")
+	}
+}
+
+func TestSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewSlogSink(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	rec := testRecord("a")
+	rec.Error = "permission denied"
+	if err := sink.Audit(context.Background(), rec); err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("log output is not JSON: %v", err)
+	}
+	want := map[string]any{
+		"level":          "WARN",
+		"msg":            "audit",
+		"operation":      "delete",
+		"principal":      "alice",
+		"table":          "a",
+		"rows":           float64(3),
+		"transaction_id": "tx-1",
+		"trace_id":       "query-42",
+		"error":          "permission denied",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+	if _, ok := got["function"]; ok {
+		t.Error("empty function should not be logged")
+	}
+}
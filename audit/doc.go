@@ -0,0 +1,35 @@
+// Package audit records who changed and read what through an Airport server.
+//
+// The server passes a Record to its Sink for every DDL action (create and drop
+// of schemas and tables, column changes), every INSERT, UPDATE and DELETE with
+// the number of affected rows, and every DoGet scan with the projected columns
+// and pushed-down filter. Records carry the principal, the transaction ID and
+// the DuckDB trace ID of the request. Denied and failed operations are recorded
+// with their error.
+//
+// Built-in sinks:
+//   - JSONLinesSink writes one JSON object per line, chained by SHA-256 hashes
+//     so that modified, removed or reordered records are detected by VerifyJSONLines
+//   - SlogSink writes records to a slog.Logger
+//
+// # Basic Usage
+//
+//	sink, err := audit.OpenJSONLinesFile("/var/log/airport/audit.jsonl")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer sink.Close()
+//
+//	err = airport.NewServer(grpcServer, airport.ServerConfig{
+//		Catalog:   cat,
+//		AuditSink: sink,
+//	})
+//
+// # Hash Chain
+//
+// Each line of a JSONLinesSink holds the hash of the previous line in
+// "prev_hash" and its own hash in "hash", computed over the previous hash and
+// the record. The first line of a file chains to an empty hash; reopening a
+// file continues its chain. Keep the last hash somewhere the server cannot
+// write to detect truncation of the file.
+package audit
@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// chainedRecord is a line of a JSONLinesSink.
+type chainedRecord struct {
+	Record
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// JSONLinesSink writes records as JSON lines chained by SHA-256 hashes.
+// It is safe for concurrent use.
+type JSONLinesSink struct {
+	mu       sync.Mutex
+	w        io.Writer
+	closer   io.Closer // nil if the writer is not owned by the sink
+	prevHash string
+}
+
+var _ Sink = (*JSONLinesSink)(nil)
+
+// NewJSONLinesSink creates a sink that writes records to w, starting a new hash chain.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{w: w}
+}
+
+// OpenJSONLinesFile opens or creates the file at path for appending records.
+// The hash chain continues from the last record in the file.
+func OpenJSONLinesFile(path string) (*JSONLinesSink, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	line, err := lastLine(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("read audit log %s: %w", path, err)
+	}
+
+	sink := &JSONLinesSink{w: f, closer: f}
+	if len(line) > 0 {
+		var last chainedRecord
+		if err := json.Unmarshal(line, &last); err != nil || last.Hash == "" {
+			f.Close()
+			return nil, fmt.Errorf("audit log %s: last line is not an audit record", path)
+		}
+		sink.prevHash = last.Hash
+	}
+	return sink, nil
+}
+
+// Audit implements Sink.
+func (s *JSONLinesSink) Audit(ctx context.Context, rec *Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash := chainHash(s.prevHash, data)
+	line, err := json.Marshal(chainedRecord{
+		Record:   *rec,
+		PrevHash: s.prevHash,
+		Hash:     hash,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	s.prevHash = hash
+	return nil
+}
+
+// LastHash returns the hash of the last written record.
+func (s *JSONLinesSink) LastHash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prevHash
+}
+
+// Close closes the file opened by OpenJSONLinesFile.
+// Writers passed to NewJSONLinesSink are not closed.
+func (s *JSONLinesSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// VerifyJSONLines checks the hash chain of records written by a JSONLinesSink
+// and returns the hash of the last record. The error names the first line that
+// was modified, removed or inserted.
+func VerifyJSONLines(r io.Reader) (string, error) {
+	br := bufio.NewReader(r)
+	prevHash := ""
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var rec chainedRecord
+			if err := json.Unmarshal(line, &rec); err != nil {
+				return "", fmt.Errorf("line %d: %w", n, err)
+			}
+			if rec.PrevHash != prevHash {
+				return "", fmt.Errorf("line %d: chain broken: previous hash %q, want %q", n, rec.PrevHash, prevHash)
+			}
+			data, err := json.Marshal(&rec.Record)
+			if err != nil {
+				return "", fmt.Errorf("line %d: %w", n, err)
+			}
+			if hash := chainHash(prevHash, data); rec.Hash != hash {
+				return "", fmt.Errorf("line %d: record does not match its hash", n)
+			}
+			prevHash = rec.Hash
+		}
+		if errors.Is(err, io.EOF) {
+			return prevHash, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// chainHash returns the hex SHA-256 hash of prevHash and the record JSON data.
+func chainHash(prevHash string, data []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte{'\n'})
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lastLine returns the last non-empty line of f, reading backwards from its end.
+func lastLine(f *os.File) ([]byte, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	const chunkSize = 4096
+	var buf []byte
+	for end := info.Size(); end > 0; {
+		start := max(end-chunkSize, 0)
+		chunk := make([]byte, end-start)
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return nil, err
+		}
+		buf = append(chunk, buf...)
+		trimmed := bytes.TrimRight(buf, "\r\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+		end = start
+	}
+	return bytes.TrimRight(buf, "\r\n"), nil
+}
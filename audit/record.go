@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/hugr-lab/airport-go/auth"
+)
+
+// Record describes one audited operation.
+type Record struct {
+	// Time is when the operation finished.
+	Time time.Time `json:"time"`
+
+	// Principal is the identity of the caller (empty if unauthenticated).
+	Principal string `json:"principal,omitempty"`
+
+	// Roles are the roles of the caller.
+	Roles []string `json:"roles,omitempty"`
+
+	// Operation is the audited operation, e.g. "create_table", "insert" or "scan".
+	Operation auth.Operation `json:"operation"`
+
+	// Catalog, Schema, Table and Function name the object of the operation.
+	Catalog  string `json:"catalog,omitempty"`
+	Schema   string `json:"schema,omitempty"`
+	Table    string `json:"table,omitempty"`
+	Function string `json:"function,omitempty"`
+
+	// Columns are the columns read by a scan, written by DML or changed by DDL.
+	Columns []string `json:"columns,omitempty"`
+
+	// Filter is the filter pushed down to a scan, as a SQL expression.
+	Filter string `json:"filter,omitempty"`
+
+	// Rows is the number of rows returned by a scan or affected by DML.
+	Rows int64 `json:"rows,omitempty"`
+
+	// Details holds operation specific values, e.g. the new name of a renamed table.
+	Details map[string]string `json:"details,omitempty"`
+
+	// TransactionID is the Airport transaction of the operation (empty outside transactions).
+	TransactionID string `json:"transaction_id"`
+
+	// TraceID is the airport-trace-id of the request (empty if not sent).
+	TraceID string `json:"trace_id"`
+
+	// SessionID is the airport-client-session-id of the request.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Error is the error returned to the client; empty if the operation succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink stores audit records.
+// Implementations MUST be goroutine-safe. Errors are logged by the server;
+// they do not fail the audited operation.
+type Sink interface {
+	// Audit stores rec. Audit must not retain rec after returning.
+	Audit(ctx context.Context, rec *Record) error
+}
+
+// SinkFunc is an adapter to allow ordinary functions to be used as a Sink.
+type SinkFunc func(ctx context.Context, rec *Record) error
+
+// Audit implements Sink.
+func (f SinkFunc) Audit(ctx context.Context, rec *Record) error {
+	return f(ctx, rec)
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogSink writes records to a slog.Logger, one "audit" message per record.
+// Records of failed operations are logged at Warn level, others at Info.
+type SlogSink struct {
+	logger *slog.Logger
+}
+
+var _ Sink = (*SlogSink)(nil)
+
+// NewSlogSink creates a sink that writes records to logger.
+func NewSlogSink(logger *slog.Logger) *SlogSink {
+	return &SlogSink{logger: logger}
+}
+
+// Audit implements Sink.
+func (s *SlogSink) Audit(ctx context.Context, rec *Record) error {
+	attrs := []slog.Attr{
+		slog.Time("time", rec.Time),
+		slog.String("operation", string(rec.Operation)),
+		slog.String("principal", rec.Principal),
+		slog.String("transaction_id", rec.TransactionID),
+		slog.String("trace_id", rec.TraceID),
+	}
+	optional := []struct {
+		key   string
+		value string
+	}{
+		{"session_id", rec.SessionID},
+		{"catalog", rec.Catalog},
+		{"schema", rec.Schema},
+		{"table", rec.Table},
+		{"function", rec.Function},
+		{"filter", rec.Filter},
+		{"error", rec.Error},
+	}
+	for _, a := range optional {
+		if a.value != "" {
+			attrs = append(attrs, slog.String(a.key, a.value))
+		}
+	}
+	if len(rec.Roles) > 0 {
+		attrs = append(attrs, slog.Any("roles", rec.Roles))
+	}
+	if len(rec.Columns) > 0 {
+		attrs = append(attrs, slog.Any("columns", rec.Columns))
+	}
+	if rec.Rows != 0 {
+		attrs = append(attrs, slog.Int64("rows", rec.Rows))
+	}
+	if len(rec.Details) > 0 {
+		attrs = append(attrs, slog.Any("details", rec.Details))
+	}
+
+	level := slog.LevelInfo
+	if rec.Error != "" {
+		level = slog.LevelWarn
+	}
+	s.logger.LogAttrs(ctx, level, "audit", attrs...)
+	return nil
+}
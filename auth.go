@@ -3,6 +3,7 @@ package airport
 import (
 	"context"
 
+	"github.com/hugr-lab/airport-go/audit"
 	"github.com/hugr-lab/airport-go/auth"
 )
 
@@ -53,3 +54,36 @@ func NoAuth() Authenticator {
 func IdentityFromContext(ctx context.Context) string {
 	return auth.IdentityFromContext(ctx)
 }
+
+// Principal is the authenticated caller of a request, with roles, groups,
+// tenant and attributes.
+// This is re-exported from the auth package for convenience.
+type Principal = auth.Principal
+
+// PrincipalAuthenticator is an Authenticator that returns a full Principal.
+// This is re-exported from the auth package for convenience.
+type PrincipalAuthenticator = auth.PrincipalAuthenticator
+
+// PrincipalFromContext retrieves the authenticated principal from context.
+// Returns nil if the request is unauthenticated.
+//
+// Example:
+//
+//	func myScanFunc(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+//	    p := airport.PrincipalFromContext(ctx)
+//	    if !p.HasRole("analyst") {
+//	        return nil, errors.New("analyst role required")
+//	    }
+//	    // Return data for p.Tenant...
+//	}
+func PrincipalFromContext(ctx context.Context) *Principal {
+	return auth.PrincipalFromContext(ctx)
+}
+
+// Authorizer checks operations on schemas, tables, columns and functions.
+// This is re-exported from the auth package for convenience.
+type Authorizer = auth.Authorizer
+
+// AuditSink stores audit records of DDL actions, DML operations and scans.
+// This is re-exported from the audit package for convenience.
+type AuditSink = audit.Sink
@@ -71,6 +71,7 @@ type contextKey int
 
 const (
 	identityKey contextKey = iota
+	principalKey
 )
 
 // IdentityFromContext retrieves the authenticated user identity from context.
@@ -104,17 +105,18 @@ func TokenFromAuthorizationHeader(authHeader string) (string, error) {
 }
 
 // ValidateToken validates a bearer token using the provided Authenticator.
-// Returns context with identity set or error.
+// Returns context with the principal and its identity set, or error.
+// Authenticators are adapted with AsPrincipalAuthenticator.
 func ValidateToken(ctx context.Context, token string, authenticator Authenticator) (context.Context, error) {
 	if token == "" {
 		return ctx, ErrTokenIsEmpty
 	}
 
-	identity, err := authenticator.Authenticate(ctx, token)
-	if err != nil {
+	principal, err := AsPrincipalAuthenticator(authenticator).AuthenticatePrincipal(ctx, token)
+	if err != nil || principal == nil {
 		return ctx, ErrUnauthenticated
 	}
 
-	// Add identity to context
-	return WithIdentity(ctx, identity), nil
+	// Add principal and identity to context
+	return WithPrincipal(ctx, principal), nil
 }
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPermissionDenied is returned by Authorizer implementations to deny an operation.
+// Any non-nil error denies; the server maps denials to gRPC PermissionDenied.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Operation identifies the kind of access checked by an Authorizer.
+// DDL operations are named after the Airport DoAction type that performs them.
+type Operation string
+
+const (
+	// OperationList checks whether a schema, table or function is visible in
+	// list_schemas and ListFlights. Denied objects are omitted, not reported.
+	OperationList Operation = "list"
+
+	// OperationScan checks reads of a table (DoGet, column_statistics).
+	OperationScan Operation = "scan"
+
+	// OperationInsert, OperationUpdate and OperationDelete check DML through DoExchange.
+	OperationInsert Operation = "insert"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+
+	// OperationExecute checks execution of scalar and table functions.
+	OperationExecute Operation = "execute"
+
+	// Schema DDL.
+	OperationCreateSchema Operation = "create_schema"
+	OperationDropSchema   Operation = "drop_schema"
+
+	// Table DDL.
+	OperationCreateTable      Operation = "create_table"
+	OperationDropTable        Operation = "drop_table"
+	OperationRenameTable      Operation = "rename_table"
+	OperationAddColumn        Operation = "add_column"
+	OperationRemoveColumn     Operation = "remove_column"
+	OperationRenameColumn     Operation = "rename_column"
+	OperationChangeColumnType Operation = "change_column_type"
+	OperationSetNotNull       Operation = "set_not_null"
+	OperationDropNotNull      Operation = "drop_not_null"
+	OperationSetDefault       Operation = "set_default"
+	OperationAddField         Operation = "add_field"
+	OperationRenameField      Operation = "rename_field"
+	OperationRemoveField      Operation = "remove_field"
+)
+
+// Resource is the catalog object an operation applies to.
+type Resource struct {
+	// Catalog is the catalog name (empty for the default catalog).
+	Catalog string
+
+	// Schema is the schema name.
+	Schema string
+
+	// Table is the table name. Empty for schema-level operations and functions.
+	Table string
+
+	// Function is the scalar or table function name, for OperationExecute and
+	// OperationList of functions.
+	Function string
+
+	// Columns are the columns involved: the projected and filtered columns of
+	// a scan, the input columns of an insert or update, or the column changed
+	// by DDL.
+	// Nil when the operation does not name columns.
+	Columns []string
+}
+
+// Authorizer decides whether the principal of a request may perform an
+// operation on a catalog object. It complements CatalogAuthorizer, which only
+// controls access to a catalog as a whole.
+//
+// The Flight server calls Authorize before each concrete operation, with the
+// principal in context (PrincipalFromContext). A nil error allows the operation.
+// Implementations MUST be goroutine-safe.
+type Authorizer interface {
+	Authorize(ctx context.Context, op Operation, res Resource) error
+}
+
+// AuthorizerFunc adapts a function to the Authorizer interface.
+type AuthorizerFunc func(ctx context.Context, op Operation, res Resource) error
+
+// Authorize implements Authorizer.
+func (f AuthorizerFunc) Authorize(ctx context.Context, op Operation, res Resource) error {
+	return f(ctx, op, res)
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+)
+
+// Claims are the claims of a validated token, as decoded from its JSON payload.
+// Numeric claims are float64; arrays are []any.
+type Claims map[string]any
+
+// ClaimsAuthenticator is an optional interface that Authenticator implementations
+// can also implement to expose the claims of a token.
+//
+// When an Authenticator implements ClaimsAuthenticator, ValidateToken calls
+// AuthenticateClaims instead of Authenticate and stores the claims in the request
+// context as attributes of the principal (see AsPrincipalAuthenticator), where
+// scan functions and CatalogAuthorizer.AuthorizeCatalog read them with
+// ClaimsFromContext.
+type ClaimsAuthenticator interface {
+	// AuthenticateClaims validates a bearer token and returns the user identity
+	// and the claims of the token.
+	// Returns error if token is invalid or expired.
+	AuthenticateClaims(ctx context.Context, token string) (identity string, claims Claims, err error)
+}
+
+// ClaimsFromContext retrieves the claims of the authenticated token from context.
+// The claims are the attributes of the principal (see Principal.Attributes).
+// Returns (nil, false) if the authenticator does not provide claims.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	p, ok := ctx.Value(principalKey).(*Principal)
+	if !ok || p.Attributes == nil {
+		return nil, false
+	}
+	return Claims(p.Attributes), true
+}
+
+// WithClaims sets the claims of the principal in context, creating a principal
+// from the context identity if none is set.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	p := &Principal{ID: IdentityFromContext(ctx)}
+	if cur, ok := ctx.Value(principalKey).(*Principal); ok {
+		cp := *cur
+		p = &cp
+	}
+	p.Attributes = claims
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// String returns the string claim name, or "" if it is missing or not a string.
+func (c Claims) String(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// Strings returns the claim name as a string slice.
+// A single string claim is returned as a one-element slice; non-string
+// elements of an array claim are skipped.
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// Subject returns the "sub" claim.
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
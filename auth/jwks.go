@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// jwk is a JSON Web Key (RFC 7517) of type RSA, EC (P-256) or oct.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+
+	// oct
+	K string `json:"k"`
+}
+
+// verificationKey is a parsed JWK usable for token verification.
+type verificationKey struct {
+	kid string
+	alg string // signing algorithm the key is used with
+	key any    // *rsa.PublicKey, *ecdsa.PublicKey or []byte
+}
+
+// parseJWKS parses a JWKS document ({"keys": [...]}).
+// Keys that are not signature keys or use unsupported types are skipped.
+func parseJWKS(doc []byte) ([]verificationKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(doc, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := make([]verificationKey, 0, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %d (kid %q): %w", i, k.Kid, err)
+		}
+		if key == nil {
+			continue
+		}
+		keys = append(keys, *key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no supported signature keys")
+	}
+	return keys, nil
+}
+
+// parse converts the JWK to a verification key.
+// Returns nil for unsupported key types and algorithms.
+func (k *jwk) parse() (*verificationKey, error) {
+	vk := &verificationKey{kid: k.Kid}
+	switch k.Kty {
+	case "RSA":
+		vk.alg = "RS256"
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %w", err)
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %w", err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exp.IsInt64() || exp.Int64() < 2 || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("malformed RSA key")
+		}
+		vk.key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		vk.alg = "ES256"
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := decodeSegment(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		// Coordinates are fixed-size big-endian (RFC 7518 section 6.2.1.2).
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("malformed EC key")
+		}
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return nil, fmt.Errorf("point is not on curve P-256")
+		}
+		vk.key = pub
+	case "oct":
+		vk.alg = "HS256"
+		secret, err := decodeSegment(k.K)
+		if err != nil || len(secret) == 0 {
+			return nil, fmt.Errorf("malformed symmetric key")
+		}
+		vk.key = secret
+	default:
+		return nil, nil
+	}
+	if k.Alg != "" && k.Alg != vk.alg {
+		return nil, nil
+	}
+	return vk, nil
+}
+
+// decodeSegment decodes base64url without padding, as used by JWS and JWK.
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
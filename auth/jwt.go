@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned by JWTAuthenticator.
+var (
+	// ErrMalformedToken is returned when a token is not a compact JWS.
+	ErrMalformedToken = errors.New("malformed token")
+
+	// ErrTokenSignature is returned when no key verifies the token signature.
+	ErrTokenSignature = errors.New("invalid token signature")
+
+	// ErrTokenExpired is returned when the exp claim is in the past.
+	ErrTokenExpired = errors.New("token expired")
+
+	// ErrTokenNotYetValid is returned when the nbf claim is in the future.
+	ErrTokenNotYetValid = errors.New("token not yet valid")
+
+	// ErrTokenClaims is returned when the iss, aud or identity claims do not match.
+	ErrTokenClaims = errors.New("invalid token claims")
+)
+
+// JWTConfig configures a JWTAuthenticator.
+type JWTConfig struct {
+	// JWKS is a static JSON Web Key Set document ({"keys": [...]}).
+	// Either JWKS or JWKSFile is required.
+	JWKS []byte
+
+	// JWKSFile is the path of a JWKS document. It is read by NewJWTAuthenticator
+	// and again on every Reload.
+	JWKSFile string
+
+	// Algorithms are the accepted signing algorithms: "RS256", "ES256", "HS256".
+	// Defaults to RS256 and ES256; HS256 must be enabled explicitly.
+	Algorithms []string
+
+	// Issuer is the required "iss" claim. Not checked if empty.
+	Issuer string
+
+	// Audience lists accepted "aud" values; the token must name at least one.
+	// Not checked if empty.
+	Audience []string
+
+	// IdentityClaim is the claim returned as identity. Defaults to "sub".
+	IdentityClaim string
+
+	// RolesClaim, GroupsClaim and TenantClaim are the claims mapped to
+	// Principal.Roles, Principal.Groups and Principal.Tenant.
+	// Default to "roles", "groups" and "tenant".
+	RolesClaim  string
+	GroupsClaim string
+	TenantClaim string
+
+	// Leeway is the clock skew tolerated when checking exp and nbf.
+	Leeway time.Duration
+
+	// RequireExpiry rejects tokens without an "exp" claim.
+	RequireExpiry bool
+}
+
+// JWTAuthenticator validates JWT bearer tokens signed with RS256, ES256 or HS256.
+//
+// Tokens are verified against the keys of a JWKS document, and their exp, nbf,
+// iss and aud claims are checked. The identity is the "sub" claim (see
+// JWTConfig.IdentityClaim). JWTAuthenticator implements PrincipalAuthenticator
+// and ClaimsAuthenticator, so the principal and the claims of the token are
+// available via PrincipalFromContext and ClaimsFromContext in scan functions and
+// in CatalogAuthorizer.AuthorizeCatalog.
+//
+// JWTAuthenticator is goroutine-safe; Reload and SetJWKS may be called while
+// tokens are validated.
+type JWTAuthenticator struct {
+	cfg JWTConfig
+	now func() time.Time
+
+	mu   sync.RWMutex
+	keys []verificationKey
+}
+
+var (
+	_ PrincipalAuthenticator = (*JWTAuthenticator)(nil)
+	_ ClaimsAuthenticator    = (*JWTAuthenticator)(nil)
+)
+
+// NewJWTAuthenticator creates a JWTAuthenticator and loads its key set.
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	if len(cfg.JWKS) == 0 && cfg.JWKSFile == "" {
+		return nil, fmt.Errorf("JWKS or JWKSFile is required")
+	}
+	if len(cfg.Algorithms) == 0 {
+		cfg.Algorithms = []string{"RS256", "ES256"}
+	}
+	for _, alg := range cfg.Algorithms {
+		if alg != "RS256" && alg != "ES256" && alg != "HS256" {
+			return nil, fmt.Errorf("unsupported algorithm %q", alg)
+		}
+	}
+	if cfg.IdentityClaim == "" {
+		cfg.IdentityClaim = "sub"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if cfg.TenantClaim == "" {
+		cfg.TenantClaim = "tenant"
+	}
+
+	a := &JWTAuthenticator{cfg: cfg, now: time.Now}
+	if cfg.JWKSFile != "" {
+		if err := a.Reload(); err != nil {
+			return nil, err
+		}
+	} else if err := a.SetJWKS(cfg.JWKS); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload re-reads JWTConfig.JWKSFile and replaces the key set.
+// On error the previous key set stays in use.
+func (a *JWTAuthenticator) Reload() error {
+	if a.cfg.JWKSFile == "" {
+		return fmt.Errorf("no JWKS file configured")
+	}
+	doc, err := os.ReadFile(a.cfg.JWKSFile)
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	return a.SetJWKS(doc)
+}
+
+// SetJWKS replaces the key set with the keys of a JWKS document.
+// On error the previous key set stays in use.
+func (a *JWTAuthenticator) SetJWKS(doc []byte) error {
+	keys, err := parseJWKS(doc)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = keys
+	return nil
+}
+
+// Authenticate implements Authenticator.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (string, error) {
+	identity, _, err := a.AuthenticateClaims(ctx, token)
+	return identity, err
+}
+
+// AuthenticatePrincipal implements PrincipalAuthenticator.
+// The token claims become the principal attributes.
+func (a *JWTAuthenticator) AuthenticatePrincipal(ctx context.Context, token string) (*Principal, error) {
+	identity, claims, err := a.AuthenticateClaims(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return claimsPrincipal(identity, claims, a.cfg.RolesClaim, a.cfg.GroupsClaim, a.cfg.TenantClaim), nil
+}
+
+// AuthenticateClaims implements ClaimsAuthenticator.
+func (a *JWTAuthenticator) AuthenticateClaims(ctx context.Context, token string) (string, Claims, error) {
+	claims, err := a.verify(token)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := a.checkClaims(claims); err != nil {
+		return "", nil, err
+	}
+
+	identity := claims.String(a.cfg.IdentityClaim)
+	if identity == "" {
+		return "", nil, fmt.Errorf("%w: missing %q", ErrTokenClaims, a.cfg.IdentityClaim)
+	}
+	return identity, claims, nil
+}
+
+// verify checks the token signature and returns its claims.
+func (a *JWTAuthenticator) verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	rawHeader, err := decodeSegment(parts[0])
+	if err != nil || json.Unmarshal(rawHeader, &header) != nil {
+		return nil, fmt.Errorf("%w: header", ErrMalformedToken)
+	}
+	if !slices.Contains(a.cfg.Algorithms, header.Alg) {
+		return nil, fmt.Errorf("%w: algorithm %q not accepted", ErrTokenSignature, header.Alg)
+	}
+	sig, err := decodeSegment(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature", ErrMalformedToken)
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	if !a.verifySignature(header.Alg, header.Kid, signed, sig) {
+		return nil, ErrTokenSignature
+	}
+
+	rawPayload, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: payload", ErrMalformedToken)
+	}
+	var claims Claims
+	if err := json.Unmarshal(rawPayload, &claims); err != nil || claims == nil {
+		return nil, fmt.Errorf("%w: payload", ErrMalformedToken)
+	}
+	return claims, nil
+}
+
+// verifySignature reports whether a key of algorithm alg, and with ID kid if
+// set, verifies sig.
+func (a *JWTAuthenticator) verifySignature(alg, kid string, signed, sig []byte) bool {
+	a.mu.RLock()
+	keys := a.keys
+	a.mu.RUnlock()
+
+	digest := sha256.Sum256(signed)
+	for _, k := range keys {
+		if k.alg != alg || (kid != "" && k.kid != kid) {
+			continue
+		}
+		switch key := k.key.(type) {
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil {
+				return true
+			}
+		case *ecdsa.PublicKey:
+			if len(sig) == 64 {
+				r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+				if ecdsa.Verify(key, digest[:], r, s) {
+					return true
+				}
+			}
+		case []byte:
+			mac := hmac.New(sha256.New, key)
+			mac.Write(signed)
+			if hmac.Equal(mac.Sum(nil), sig) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkClaims validates the registered claims exp, nbf, iss and aud.
+func (a *JWTAuthenticator) checkClaims(claims Claims) error {
+	now := a.now()
+
+	exp, hasExp := numericDate(claims, "exp")
+	switch {
+	case hasExp && now.After(exp.Add(a.cfg.Leeway)):
+		return ErrTokenExpired
+	case !hasExp && a.cfg.RequireExpiry:
+		return fmt.Errorf("%w: missing exp", ErrTokenClaims)
+	}
+	if nbf, ok := numericDate(claims, "nbf"); ok && now.Add(a.cfg.Leeway).Before(nbf) {
+		return ErrTokenNotYetValid
+	}
+
+	if a.cfg.Issuer != "" && claims.String("iss") != a.cfg.Issuer {
+		return fmt.Errorf("%w: issuer", ErrTokenClaims)
+	}
+	if len(a.cfg.Audience) > 0 {
+		aud := claims.Strings("aud")
+		if !slices.ContainsFunc(a.cfg.Audience, func(want string) bool { return slices.Contains(aud, want) }) {
+			return fmt.Errorf("%w: audience", ErrTokenClaims)
+		}
+	}
+	return nil
+}
+
+// numericDate returns the NumericDate claim name as a time.
+func numericDate(claims Claims, name string) (time.Time, bool) {
+	v, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	sec := int64(v)
+	return time.Unix(sec, int64((v-float64(sec))*1e9)), true
+}
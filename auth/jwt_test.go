@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+var b64 = base64.RawURLEncoding
+
+// signJWT creates a compact JWS over claims with the given algorithm and key.
+func signJWT(t *testing.T, alg, kid string, key any, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64.EncodeToString(header) + "." + b64.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatalf("RSA sign failed: %v", err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatalf("ECDSA sign failed: %v", err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	}
+	return signed + "." + b64.EncodeToString(sig)
+}
+
+// testJWKS generates RSA, EC and symmetric keys and their JWKS document.
+func testJWKS(t *testing.T) (*rsa.PrivateKey, *ecdsa.PrivateKey, []byte, []byte) {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	point, err := ecKey.PublicKey.Bytes()
+	if err != nil {
+		t.Fatalf("failed to encode EC key: %v", err)
+	}
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	doc, _ := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64.EncodeToString(rsaKey.N.Bytes()), "e": b64.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64.EncodeToString(point[1:33]), "y": b64.EncodeToString(point[33:])},
+		{"kty": "oct", "kid": "hs-1", "k": b64.EncodeToString(secret)},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"},
+	}})
+	return rsaKey, ecKey, secret, doc
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, ecKey, secret, doc := testJWKS(t)
+	a, err := NewJWTAuthenticator(JWTConfig{
+		JWKS:       doc,
+		Algorithms: []string{"RS256", "ES256", "HS256"},
+		Issuer:     "https://issuer.example",
+		Audience:   []string{"airport"},
+		Leeway:     30 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator failed: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	a.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"sub":   "alice",
+			"iss":   "https://issuer.example",
+			"aud":   []string{"other", "airport"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"roles": []string{"analyst", "admin"},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	for _, tc := range []struct {
+		alg, kid string
+		key      any
+	}{
+		{"RS256", "rsa-1", rsaKey},
+		{"ES256", "ec-1", ecKey},
+		{"HS256", "", secret},
+	} {
+		token := signJWT(t, tc.alg, tc.kid, tc.key, claims(nil))
+		identity, got, err := a.AuthenticateClaims(context.Background(), token)
+		if err != nil {
+			t.Errorf("%s: AuthenticateClaims failed: %v", tc.alg, err)
+			continue
+		}
+		if identity != "alice" || !slices.Equal(got.Strings("roles"), []string{"analyst", "admin"}) {
+			t.Errorf("%s: identity %q, roles %q", tc.alg, identity, got.Strings("roles"))
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"expired", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"exp": now.Add(-time.Minute).Unix()})), ErrTokenExpired},
+		{"expired within leeway", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()})), nil},
+		{"not yet valid", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"nbf": now.Add(time.Minute).Unix()})), ErrTokenNotYetValid},
+		{"wrong issuer", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"iss": "evil"})), ErrTokenClaims},
+		{"wrong audience", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"aud": "other"})), ErrTokenClaims},
+		{"missing subject", signJWT(t, "RS256", "rsa-1", rsaKey, claims(map[string]any{"sub": nil})), ErrTokenClaims},
+		{"wrong key ID", signJWT(t, "RS256", "ec-1", rsaKey, claims(nil)), ErrTokenSignature},
+		{"HMAC with RSA modulus", signJWT(t, "HS256", "rsa-1", rsaKey.N.Bytes(), claims(nil)), ErrTokenSignature},
+		{"none algorithm", signJWT(t, "none", "", []byte{}, claims(nil)), ErrTokenSignature},
+		{"malformed", "not-a-jwt", ErrMalformedToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.Authenticate(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorDefaultAlgorithms(t *testing.T) {
+	_, _, secret, doc := testJWKS(t)
+	a, err := NewJWTAuthenticator(JWTConfig{JWKS: doc})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator failed: %v", err)
+	}
+	token := signJWT(t, "HS256", "hs-1", secret, map[string]any{"sub": "alice"})
+	if _, err := a.Authenticate(context.Background(), token); !errors.Is(err, ErrTokenSignature) {
+		t.Errorf("HS256 without opt-in error = %v, want ErrTokenSignature", err)
+	}
+}
+
+func TestParseJWKSECKey(t *testing.T) {
+	_, ecKey, _, _ := testJWKS(t)
+	point, _ := ecKey.PublicKey.Bytes()
+	x, y := point[1:33], point[33:]
+	offCurve := append([]byte(nil), y...)
+	offCurve[31] ^= 1
+
+	tests := []struct {
+		name    string
+		x, y    []byte
+		wantErr bool
+	}{
+		{"valid", x, y, false},
+		{"off curve", x, offCurve, true},
+		{"short coordinate", x[1:], y, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, _ := json.Marshal(map[string]any{"keys": []map[string]string{
+				{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64.EncodeToString(tt.x), "y": b64.EncodeToString(tt.y)},
+			}})
+			keys, err := parseJWKS(doc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJWKS error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !keys[0].key.(*ecdsa.PublicKey).Equal(&ecKey.PublicKey) {
+				t.Error("parsed key does not match")
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorReload(t *testing.T) {
+	oldKey, _, _, oldDoc := testJWKS(t)
+	newKey, _, _, newDoc := testJWKS(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, oldDoc, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := NewJWTAuthenticator(JWTConfig{JWKSFile: path})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator failed: %v", err)
+	}
+	oldToken := signJWT(t, "RS256", "rsa-1", oldKey, map[string]any{"sub": "alice"})
+	newToken := signJWT(t, "RS256", "rsa-1", newKey, map[string]any{"sub": "alice"})
+	if _, err := a.Authenticate(context.Background(), oldToken); err != nil {
+		t.Fatalf("old key rejected before reload: %v", err)
+	}
+
+	os.WriteFile(path, newDoc, 0o600)
+	if err := a.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if _, err := a.Authenticate(context.Background(), newToken); err != nil {
+		t.Errorf("new key rejected after reload: %v", err)
+	}
+	if _, err := a.Authenticate(context.Background(), oldToken); !errors.Is(err, ErrTokenSignature) {
+		t.Errorf("old key error after reload = %v, want ErrTokenSignature", err)
+	}
+
+	// A broken document keeps the current keys.
+	os.WriteFile(path, []byte("{"), 0o600)
+	if err := a.Reload(); err == nil {
+		t.Error("Reload of invalid JWKS should fail")
+	}
+	if _, err := a.Authenticate(context.Background(), newToken); err != nil {
+		t.Errorf("keys lost after failed reload: %v", err)
+	}
+}
+
+func TestValidateTokenStoresClaims(t *testing.T) {
+	rsaKey, _, _, doc := testJWKS(t)
+	a, _ := NewJWTAuthenticator(JWTConfig{JWKS: doc})
+	token := signJWT(t, "RS256", "rsa-1", rsaKey, map[string]any{"sub": "alice", "tenant": "acme"})
+
+	ctx, err := ValidateToken(context.Background(), token, a)
+	if err != nil {
+		t.Fatalf("ValidateToken failed: %v", err)
+	}
+	if identity := IdentityFromContext(ctx); identity != "alice" {
+		t.Errorf("identity = %q, want alice", identity)
+	}
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.String("tenant") != "acme" {
+		t.Errorf("claims = %v, %v; want tenant acme", claims, ok)
+	}
+
+	// Authenticators without claims leave none in context.
+	ctx, _ = ValidateToken(context.Background(), "token", NoAuth())
+	if _, ok := ClaimsFromContext(ctx); ok {
+		t.Error("NoAuth should not set claims")
+	}
+}
+
+func TestJWTAuthenticatorPrincipal(t *testing.T) {
+	rsaKey, _, _, doc := testJWKS(t)
+	a, _ := NewJWTAuthenticator(JWTConfig{JWKS: doc, RolesClaim: "realm_roles", TenantClaim: "org"})
+	token := signJWT(t, "RS256", "rsa-1", rsaKey, map[string]any{
+		"sub":         "alice",
+		"realm_roles": []string{"admin"},
+		"groups":      []string{"finance"},
+		"org":         "acme",
+	})
+
+	ctx, err := ValidateToken(context.Background(), token, a)
+	if err != nil {
+		t.Fatalf("ValidateToken failed: %v", err)
+	}
+	p := PrincipalFromContext(ctx)
+	if p.ID != "alice" || !p.HasRole("admin") || !p.InGroup("finance") || p.Tenant != "acme" {
+		t.Errorf("principal = %+v", p)
+	}
+	if p.Attributes["org"] != "acme" {
+		t.Errorf("attributes = %v, want token claims", p.Attributes)
+	}
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Principal is the authenticated caller of a request.
+// Authenticators that know more than a bare identity (roles, groups, tenant)
+// return a Principal via PrincipalAuthenticator; scan functions and authorization
+// hooks read it with PrincipalFromContext instead of re-parsing tokens.
+type Principal struct {
+	// ID is the user identity, as returned by IdentityFromContext.
+	ID string
+
+	// Roles are the roles granted to the principal.
+	Roles []string
+
+	// Groups are the groups the principal belongs to.
+	Groups []string
+
+	// Tenant is the tenant the principal acts for. Empty if not multi-tenant.
+	Tenant string
+
+	// Attributes are additional attributes of the principal.
+	// For ClaimsAuthenticator implementations these are the token claims.
+	Attributes map[string]any
+}
+
+// HasRole reports whether the principal has the role.
+func (p *Principal) HasRole(role string) bool {
+	return p != nil && slices.Contains(p.Roles, role)
+}
+
+// InGroup reports whether the principal belongs to the group.
+func (p *Principal) InGroup(group string) bool {
+	return p != nil && slices.Contains(p.Groups, group)
+}
+
+// PrincipalAuthenticator is an Authenticator that returns a full Principal.
+//
+// ValidateToken calls AuthenticatePrincipal instead of Authenticate for
+// implementations of this interface. Use AsPrincipalAuthenticator to adapt an
+// existing Authenticator.
+type PrincipalAuthenticator interface {
+	Authenticator
+
+	// AuthenticatePrincipal validates a bearer token and returns the principal.
+	// Returns error if token is invalid or expired.
+	AuthenticatePrincipal(ctx context.Context, token string) (*Principal, error)
+}
+
+// AsPrincipalAuthenticator adapts an Authenticator to PrincipalAuthenticator.
+//
+// Authenticators that already implement PrincipalAuthenticator are returned as is.
+// For ClaimsAuthenticator implementations the principal takes its roles, groups
+// and tenant from the "roles", "groups" and "tenant" claims, and the claims
+// become its attributes. Other authenticators yield a principal with only an ID.
+func AsPrincipalAuthenticator(a Authenticator) PrincipalAuthenticator {
+	if pa, ok := a.(PrincipalAuthenticator); ok {
+		return pa
+	}
+	return &principalAdapter{Authenticator: a}
+}
+
+// principalAdapter builds a Principal from the result of a plain Authenticator.
+type principalAdapter struct {
+	Authenticator
+}
+
+// AuthenticatePrincipal implements PrincipalAuthenticator.
+func (a *principalAdapter) AuthenticatePrincipal(ctx context.Context, token string) (*Principal, error) {
+	if ca, ok := a.Authenticator.(ClaimsAuthenticator); ok {
+		identity, claims, err := ca.AuthenticateClaims(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return claimsPrincipal(identity, claims, "roles", "groups", "tenant"), nil
+	}
+
+	identity, err := a.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{ID: identity}, nil
+}
+
+// claimsPrincipal builds a Principal from token claims.
+func claimsPrincipal(identity string, claims Claims, rolesClaim, groupsClaim, tenantClaim string) *Principal {
+	return &Principal{
+		ID:         identity,
+		Roles:      claims.Strings(rolesClaim),
+		Groups:     claims.Strings(groupsClaim),
+		Tenant:     claims.String(tenantClaim),
+		Attributes: claims,
+	}
+}
+
+// PrincipalFromContext retrieves the authenticated principal from context.
+// If only an identity was set (WithIdentity), returns a Principal with just that ID.
+// Returns nil for unauthenticated requests.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	if p, ok := ctx.Value(principalKey).(*Principal); ok {
+		return p
+	}
+	if identity := IdentityFromContext(ctx); identity != "" {
+		return &Principal{ID: identity}
+	}
+	return nil
+}
+
+// WithPrincipal adds the authenticated principal, and its identity, to the context.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(WithIdentity(ctx, p.ID), principalKey, p)
+}
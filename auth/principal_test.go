@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// staticPrincipalAuth returns a fixed principal for the token "good".
+type staticPrincipalAuth struct {
+	principal *Principal
+}
+
+func (a *staticPrincipalAuth) Authenticate(ctx context.Context, token string) (string, error) {
+	p, err := a.AuthenticatePrincipal(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return p.ID, nil
+}
+
+func (a *staticPrincipalAuth) AuthenticatePrincipal(ctx context.Context, token string) (*Principal, error) {
+	if token != "good" {
+		return nil, errors.New("invalid token")
+	}
+	return a.principal, nil
+}
+
+// claimsAuth returns fixed claims for any token.
+type claimsAuth struct {
+	claims Claims
+}
+
+func (a *claimsAuth) Authenticate(ctx context.Context, token string) (string, error) {
+	return a.claims.Subject(), nil
+}
+
+func (a *claimsAuth) AuthenticateClaims(ctx context.Context, token string) (string, Claims, error) {
+	return a.claims.Subject(), a.claims, nil
+}
+
+func TestValidateTokenPrincipal(t *testing.T) {
+	want := &Principal{ID: "alice", Roles: []string{"admin"}, Groups: []string{"finance"}, Tenant: "acme"}
+	ctx, err := ValidateToken(context.Background(), "good", &staticPrincipalAuth{principal: want})
+	if err != nil {
+		t.Fatalf("ValidateToken failed: %v", err)
+	}
+	if p := PrincipalFromContext(ctx); p != want {
+		t.Errorf("principal = %+v, want %+v", p, want)
+	}
+	if identity := IdentityFromContext(ctx); identity != "alice" {
+		t.Errorf("identity = %q, want alice", identity)
+	}
+	if p := PrincipalFromContext(ctx); !p.HasRole("admin") || p.HasRole("viewer") || !p.InGroup("finance") {
+		t.Errorf("role and group checks failed for %+v", p)
+	}
+
+	if _, err := ValidateToken(context.Background(), "bad", &staticPrincipalAuth{principal: want}); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("error = %v, want ErrUnauthenticated", err)
+	}
+}
+
+func TestAsPrincipalAuthenticator(t *testing.T) {
+	ctx := context.Background()
+
+	// Plain authenticators yield a principal with only an ID.
+	p, err := AsPrincipalAuthenticator(BearerAuth(func(token string) (string, error) {
+		return "user-" + token, nil
+	})).AuthenticatePrincipal(ctx, "42")
+	if err != nil || p.ID != "user-42" || p.Roles != nil || p.Attributes != nil {
+		t.Errorf("plain adapter = %+v, %v; want ID user-42 only", p, err)
+	}
+
+	// Claims authenticators map the standard claims.
+	claims := Claims{"sub": "bob", "roles": []any{"analyst"}, "groups": "ops", "tenant": "acme"}
+	p, err = AsPrincipalAuthenticator(&claimsAuth{claims: claims}).AuthenticatePrincipal(ctx, "token")
+	if err != nil {
+		t.Fatalf("AuthenticatePrincipal failed: %v", err)
+	}
+	if p.ID != "bob" || !slices.Equal(p.Roles, []string{"analyst"}) || !slices.Equal(p.Groups, []string{"ops"}) || p.Tenant != "acme" {
+		t.Errorf("claims adapter = %+v", p)
+	}
+
+	pa := &staticPrincipalAuth{}
+	if AsPrincipalAuthenticator(pa) != pa {
+		t.Error("PrincipalAuthenticator should be returned as is")
+	}
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	if p := PrincipalFromContext(context.Background()); p != nil {
+		t.Errorf("unauthenticated principal = %+v, want nil", p)
+	}
+
+	// Contexts with only an identity still yield a principal.
+	ctx := WithIdentity(context.Background(), "carol")
+	if p := PrincipalFromContext(ctx); p == nil || p.ID != "carol" {
+		t.Errorf("principal = %+v, want ID carol", p)
+	}
+
+	ctx = WithClaims(ctx, Claims{"tenant": "acme"})
+	if claims, ok := ClaimsFromContext(ctx); !ok || claims.String("tenant") != "acme" {
+		t.Errorf("claims = %v, %v; want tenant acme", claims, ok)
+	}
+	if p := PrincipalFromContext(ctx); p.ID != "carol" {
+		t.Errorf("principal ID = %q after WithClaims, want carol", p.ID)
+	}
+}
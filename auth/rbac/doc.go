@@ -0,0 +1,51 @@
+// Package rbac provides a declarative role-based auth.Authorizer for Airport servers.
+//
+// A Policy is a list of allow and deny rules. Each rule names the principals it
+// applies to (roles, groups, identities), the catalog objects it covers (glob
+// patterns over catalog, schema and table or function names) and the actions it
+// allows or denies: select, insert, update, delete, ddl and execute.
+//
+// An operation is allowed when at least one allow rule matches it and no deny
+// rule does. Schemas, tables and functions are listed in catalog metadata when
+// some action on them is allowed.
+//
+// # Policy File
+//
+// Policies are written in YAML or JSON:
+//
+//	rules:
+//	  - effect: allow
+//	    roles: [admin]
+//	    actions: ["*"]
+//	  - effect: allow
+//	    roles: [analyst]
+//	    schema: sales
+//	    actions: [select, execute]
+//	  - effect: deny
+//	    identities: ["*"]
+//	    table: "secret_*"
+//	    actions: ["*"]
+//
+// Omitted patterns match everything; a rule without roles, groups and
+// identities matches no one. The identity "*" matches every authenticated caller.
+//
+// # Basic Usage
+//
+//	engine, err := rbac.Load("/etc/airport/policy.yaml", rbac.Options{ReloadInterval: 30 * time.Second})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer engine.Close()
+//
+//	err = airport.NewServer(grpcServer, airport.ServerConfig{
+//		Catalog:    cat,
+//		Auth:       jwtAuth,
+//		Authorizer: engine,
+//	})
+//
+// With a ReloadInterval the file is re-read whenever it changes, without
+// restarting the server; Reload re-reads it on demand (e.g. on SIGHUP).
+// A file that fails to parse is logged and the previous policy stays in effect.
+// Replace the file atomically by renaming a complete new file over it: a
+// policy truncated by an in-place write can still parse, without its last rules.
+package rbac
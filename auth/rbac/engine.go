@@ -0,0 +1,215 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hugr-lab/airport-go/auth"
+)
+
+// Options configures a file-backed Engine.
+type Options struct {
+	// ReloadInterval is how often the policy file is checked for changes.
+	// A changed file is reloaded once it has stayed unchanged for one interval,
+	// so that a file still being written is not loaded.
+	// If zero, the file is only re-read by Reload.
+	ReloadInterval time.Duration
+
+	// Logger receives reload events and errors. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// Engine is an auth.Authorizer that evaluates a Policy.
+// All methods are goroutine-safe; the policy can be replaced while requests
+// are authorized.
+type Engine struct {
+	path string
+	opts Options
+
+	mu      sync.RWMutex
+	policy  *Policy
+	modTime time.Time // of the loaded policy file
+	size    int64
+
+	pending os.FileInfo // changed file waiting to stay unchanged for an interval, watchLoop only
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ auth.Authorizer = (*Engine)(nil)
+
+// New creates an Engine for a fixed policy.
+func New(p *Policy) (*Engine, error) {
+	e := &Engine{}
+	if err := e.SetPolicy(p); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Load creates an Engine from a YAML or JSON policy file.
+// If opts.ReloadInterval is set, a background goroutine reloads the file when
+// it changes; Close stops it.
+//
+// Update the file atomically: write the new policy to a temporary file in the
+// same directory and rename it over the policy file. A policy cut short by an
+// in-place write may still be valid, with its last rules missing.
+func Load(path string, opts Options) (*Engine, error) {
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	e := &Engine{path: path, opts: opts}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	if opts.ReloadInterval > 0 {
+		e.stop = make(chan struct{})
+		e.done = make(chan struct{})
+		go e.watchLoop()
+	}
+	return e, nil
+}
+
+// Policy returns the policy in effect.
+func (e *Engine) Policy() *Policy {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.policy
+}
+
+// SetPolicy validates p and puts it in effect.
+func (e *Engine) SetPolicy(p *Policy) error {
+	if p == nil {
+		return fmt.Errorf("policy is nil")
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.policy = p
+	return nil
+}
+
+// Reload re-reads the policy file. On error, including a file that is
+// unparsable or changes while it is read, the previous policy stays in effect.
+func (e *Engine) Reload() error {
+	if e.path == "" {
+		return fmt.Errorf("no policy file configured")
+	}
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return fmt.Errorf("failed to read policy file: %w", err)
+	}
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		return fmt.Errorf("failed to read policy file: %w", err)
+	}
+	if after, err := os.Stat(e.path); err != nil || !sameFile(info, after) {
+		return fmt.Errorf("policy file changed while it was read")
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.policy = p
+	e.modTime = info.ModTime()
+	e.size = info.Size()
+	return nil
+}
+
+// Close stops the reload goroutine. It is a no-op for engines without one.
+func (e *Engine) Close() {
+	if e.stop == nil {
+		return
+	}
+	e.closeOnce.Do(func() {
+		close(e.stop)
+		<-e.done
+	})
+}
+
+// Authorize implements auth.Authorizer.
+// The principal is read with auth.PrincipalFromContext; unauthenticated
+// requests match no rule and are denied.
+func (e *Engine) Authorize(ctx context.Context, op auth.Operation, res auth.Resource) error {
+	p := e.Policy()
+	principal := auth.PrincipalFromContext(ctx)
+
+	if op == auth.OperationList {
+		if p.visible(principal, res) {
+			return nil
+		}
+		return auth.ErrPermissionDenied
+	}
+	if p.allowed(principal, actionOf(op), res) {
+		return nil
+	}
+	return auth.ErrPermissionDenied
+}
+
+// watchLoop reloads the policy file when it changes.
+func (e *Engine) watchLoop() {
+	defer close(e.done)
+	ticker := time.NewTicker(e.opts.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			e.poll()
+		}
+	}
+}
+
+// poll reloads the policy file if it differs from the last one read and has
+// not changed since the previous poll.
+func (e *Engine) poll() {
+	info, changed := e.changed()
+	if !changed {
+		e.pending = nil
+		return
+	}
+	if e.pending == nil || !sameFile(e.pending, info) {
+		// Still being written, or just changed: wait for the next poll
+		e.pending = info
+		return
+	}
+	e.pending = nil
+	if err := e.Reload(); err != nil {
+		e.opts.Logger.Error("Failed to reload policy", "path", e.path, "error", err)
+		// Keep the previous policy and wait for the next change
+		e.mu.Lock()
+		e.modTime, e.size = info.ModTime(), info.Size()
+		e.mu.Unlock()
+		return
+	}
+	e.opts.Logger.Info("Policy reloaded", "path", e.path, "rules", len(e.Policy().Rules))
+}
+
+// changed reports whether the policy file differs from the last one read.
+func (e *Engine) changed() (os.FileInfo, bool) {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return nil, false
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return info, !info.ModTime().Equal(e.modTime) || info.Size() != e.size
+}
+
+// sameFile reports whether a and b have the same modification time and size.
+func sameFile(a, b os.FileInfo) bool {
+	return a.ModTime().Equal(b.ModTime()) && a.Size() == b.Size()
+}
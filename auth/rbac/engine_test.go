@@ -0,0 +1,242 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugr-lab/airport-go/auth"
+)
+
+const testPolicy = `
+rules:
+  - effect: allow
+    roles: [admin]
+    actions: ["*"]
+  - effect: allow
+    roles: [analyst]
+    schema: sales
+    actions: [select, execute]
+  - effect: allow
+    groups: [loaders]
+    schema: sales
+    table: "orders_*"
+    actions: [insert]
+  - effect: deny
+    identities: ["*"]
+    table: "secret_*"
+    actions: ["*"]
+  - effect: deny
+    identities: [mallory]
+    schema: sales
+    actions: ["*"]
+`
+
+func principalContext(id string, roles, groups []string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{ID: id, Roles: roles, Groups: groups})
+}
+
+func TestEngineAuthorize(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	e, _ := New(p)
+
+	admin := principalContext("root", []string{"admin"}, nil)
+	analyst := principalContext("alice", []string{"analyst"}, nil)
+	loader := principalContext("bob", nil, []string{"loaders"})
+	mallory := principalContext("mallory", []string{"analyst"}, nil)
+
+	orders := auth.Resource{Schema: "sales", Table: "orders_2024"}
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		op    auth.Operation
+		res   auth.Resource
+		allow bool
+	}{
+		{"admin drops table", admin, auth.OperationDropTable, orders, true},
+		{"admin reads secret", admin, auth.OperationScan, auth.Resource{Schema: "hr", Table: "secret_pay"}, false},
+		{"analyst selects", analyst, auth.OperationScan, orders, true},
+		{"analyst executes", analyst, auth.OperationExecute, auth.Resource{Schema: "sales", Function: "forecast"}, true},
+		{"analyst inserts", analyst, auth.OperationInsert, orders, false},
+		{"analyst selects other schema", analyst, auth.OperationScan, auth.Resource{Schema: "hr", Table: "people"}, false},
+		{"loader inserts", loader, auth.OperationInsert, orders, true},
+		{"loader inserts other table", loader, auth.OperationInsert, auth.Resource{Schema: "sales", Table: "customers"}, false},
+		{"deny overrides role", mallory, auth.OperationScan, orders, false},
+		{"unauthenticated", context.Background(), auth.OperationScan, orders, false},
+		{"table rule skips schema DDL", loader, auth.OperationDropSchema, auth.Resource{Schema: "sales"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := e.Authorize(tt.ctx, tt.op, tt.res)
+			if tt.allow && err != nil {
+				t.Errorf("denied: %v", err)
+			}
+			if !tt.allow && !errors.Is(err, auth.ErrPermissionDenied) {
+				t.Errorf("error = %v, want ErrPermissionDenied", err)
+			}
+		})
+	}
+}
+
+func TestEngineVisibility(t *testing.T) {
+	p, _ := Parse([]byte(testPolicy))
+	e, _ := New(p)
+
+	analyst := principalContext("alice", []string{"analyst"}, nil)
+	loader := principalContext("bob", nil, []string{"loaders"})
+	mallory := principalContext("mallory", []string{"analyst"}, nil)
+
+	visible := func(ctx context.Context, res auth.Resource) bool {
+		return e.Authorize(ctx, auth.OperationList, res) == nil
+	}
+	if !visible(analyst, auth.Resource{Schema: "sales"}) || visible(analyst, auth.Resource{Schema: "hr"}) {
+		t.Error("analyst should see schema sales only")
+	}
+	if !visible(loader, auth.Resource{Schema: "sales"}) {
+		t.Error("loader should see schema sales through its table rule")
+	}
+	if !visible(loader, auth.Resource{Schema: "sales", Table: "orders_2024"}) || visible(loader, auth.Resource{Schema: "sales", Table: "customers"}) {
+		t.Error("loader should see orders tables only")
+	}
+	if visible(analyst, auth.Resource{Schema: "sales", Table: "secret_plans"}) {
+		t.Error("denied table should be hidden")
+	}
+	if visible(mallory, auth.Resource{Schema: "sales"}) {
+		t.Error("schema denied with all actions should be hidden")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, doc := range map[string]string{
+		"effect":  `rules: [{effect: maybe, actions: [select]}]`,
+		"action":  `rules: [{effect: allow, actions: [truncate]}]`,
+		"actions": `rules: [{effect: allow}]`,
+		"pattern": `rules: [{effect: allow, schema: "[", actions: [select]}]`,
+		"field":   `rules: [{effect: allow, actions: [select], tables: x}]`,
+		"empty":   ``,
+	} {
+		if _, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("%s: Parse should fail", name)
+		}
+	}
+
+	// JSON documents are accepted.
+	p, err := Parse([]byte(`{"rules": [{"effect": "allow", "identities": ["*"], "actions": ["select"]}]}`))
+	if err != nil || len(p.Rules) != 1 {
+		t.Errorf("Parse JSON = %v, %v", p, err)
+	}
+}
+
+func TestEngineReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	write := func(doc string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`rules: [{effect: allow, identities: ["*"], actions: [select]}]`)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	e, err := Load(path, Options{ReloadInterval: 10 * time.Millisecond, Logger: logger})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	defer e.Close()
+
+	ctx := principalContext("alice", nil, nil)
+	res := auth.Resource{Schema: "main", Table: "t"}
+	if err := e.Authorize(ctx, auth.OperationScan, res); err != nil {
+		t.Fatalf("scan denied before reload: %v", err)
+	}
+
+	// The watcher picks up changes without an explicit Reload.
+	write(`rules: [{effect: allow, identities: ["*"], actions: [insert]}]`)
+	deadline := time.Now().Add(5 * time.Second)
+	for e.Authorize(ctx, auth.OperationInsert, res) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("policy change was not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := e.Authorize(ctx, auth.OperationScan, res); err == nil {
+		t.Error("scan allowed after reload")
+	}
+
+	// An invalid file keeps the current policy.
+	write(`rules: [{effect: allow}]`)
+	if err := e.Reload(); err == nil {
+		t.Error("Reload of invalid policy should fail")
+	}
+	if err := e.Authorize(ctx, auth.OperationInsert, res); err != nil {
+		t.Errorf("policy lost after failed reload: %v", err)
+	}
+}
+
+func TestEngineReloadTruncated(t *testing.T) {
+	const full = `rules:
+  - effect: allow
+    identities: ["*"]
+    actions: [select]
+  - effect: deny
+    identities: ["*"]
+    table: "secret_*"
+    actions: ["*"]
+`
+	// A write cut off after the first rule leaves a valid policy without the deny rule.
+	prefix := full[:strings.Index(full, "  - effect: deny")]
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	write := func(doc string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(full)
+	e, err := Load(path, Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	ctx := principalContext("alice", nil, nil)
+	secret := auth.Resource{Schema: "main", Table: "secret_pay"}
+	denied := func(when string) {
+		t.Helper()
+		if err := e.Authorize(ctx, auth.OperationScan, secret); err == nil {
+			t.Errorf("%s: secret table allowed", when)
+		}
+	}
+
+	// A file cut in the middle of a rule fails to parse.
+	write(full[:len(full)-10])
+	if err := e.Reload(); err == nil {
+		t.Error("Reload of truncated policy should fail")
+	}
+	denied("after failed reload")
+
+	// The watcher does not load a file that changes between polls.
+	write(prefix)
+	e.poll()
+	denied("after first poll of partial write")
+	write(full)
+	e.poll()
+	denied("after write completed")
+
+	loaded := e.Policy()
+	e.poll()
+	if e.Policy() == loaded {
+		t.Error("unchanged file was not reloaded")
+	}
+	denied("after reload")
+}
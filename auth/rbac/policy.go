@@ -0,0 +1,216 @@
+package rbac
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hugr-lab/airport-go/auth"
+)
+
+// Effect is the effect of a rule: allow or deny.
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Action is a policy action. Each auth.Operation maps to one action.
+type Action string
+
+const (
+	ActionSelect  Action = "select"  // auth.OperationScan
+	ActionInsert  Action = "insert"  // auth.OperationInsert
+	ActionUpdate  Action = "update"  // auth.OperationUpdate
+	ActionDelete  Action = "delete"  // auth.OperationDelete
+	ActionDDL     Action = "ddl"     // schema and table DDL operations
+	ActionExecute Action = "execute" // auth.OperationExecute
+	ActionAll     Action = "*"       // every action
+)
+
+// actions are the concrete actions, checked to decide visibility.
+var actions = []Action{ActionSelect, ActionInsert, ActionUpdate, ActionDelete, ActionDDL, ActionExecute}
+
+// actionOf maps an operation to its policy action.
+func actionOf(op auth.Operation) Action {
+	switch op {
+	case auth.OperationScan:
+		return ActionSelect
+	case auth.OperationInsert:
+		return ActionInsert
+	case auth.OperationUpdate:
+		return ActionUpdate
+	case auth.OperationDelete:
+		return ActionDelete
+	case auth.OperationExecute:
+		return ActionExecute
+	default:
+		return ActionDDL
+	}
+}
+
+// Policy is a set of allow and deny rules.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule allows or denies actions on matching catalog objects to matching principals.
+type Rule struct {
+	// Effect is allow or deny. Deny rules take precedence over allow rules.
+	Effect Effect `yaml:"effect"`
+
+	// Roles, Groups and Identities select the principals the rule applies to;
+	// a principal matches if it has any of the roles, is in any of the groups or
+	// has any of the identities. The identity "*" matches every authenticated caller.
+	Roles      []string `yaml:"roles,omitempty"`
+	Groups     []string `yaml:"groups,omitempty"`
+	Identities []string `yaml:"identities,omitempty"`
+
+	// Catalog, Schema and Table are glob patterns (path.Match syntax) over the
+	// catalog, schema and table or function name. Empty patterns match everything.
+	// Rules with a Table pattern other than "*" do not match schema-level operations.
+	Catalog string `yaml:"catalog,omitempty"`
+	Schema  string `yaml:"schema,omitempty"`
+	Table   string `yaml:"table,omitempty"`
+
+	// Actions are the actions the rule allows or denies.
+	Actions []Action `yaml:"actions"`
+}
+
+// Parse parses a YAML or JSON policy document and validates it.
+func Parse(data []byte) (*Policy, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var p Policy
+	if err := dec.Decode(&p); err != nil {
+		if errors.Is(err, io.EOF) {
+			// Also guards against reading a file while it is being rewritten
+			return nil, fmt.Errorf("invalid policy: empty document")
+		}
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks rule effects, actions and patterns.
+func (p *Policy) Validate() error {
+	for i, r := range p.Rules {
+		if r.Effect != Allow && r.Effect != Deny {
+			return fmt.Errorf("rule %d: effect must be %q or %q, got %q", i, Allow, Deny, r.Effect)
+		}
+		if len(r.Actions) == 0 {
+			return fmt.Errorf("rule %d: no actions", i)
+		}
+		for _, a := range r.Actions {
+			if a != ActionAll && !slices.Contains(actions, a) {
+				return fmt.Errorf("rule %d: unknown action %q", i, a)
+			}
+		}
+		for _, pattern := range []string{r.Catalog, r.Schema, r.Table} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %d: invalid pattern %q: %w", i, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// allowed reports whether the principal may perform action on res.
+func (p *Policy) allowed(principal *auth.Principal, action Action, res auth.Resource) bool {
+	allowed := false
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if !r.appliesTo(principal) || !r.covers(action) || !r.matches(res) {
+			continue
+		}
+		if r.Effect == Deny {
+			return false
+		}
+		allowed = true
+	}
+	return allowed
+}
+
+// visible reports whether res may be listed in catalog metadata.
+// Tables and functions are visible when some action on them is allowed.
+// Schemas are visible when an allow rule covers any object in them and no
+// deny rule covers the whole schema.
+func (p *Policy) visible(principal *auth.Principal, res auth.Resource) bool {
+	if res.Table != "" || res.Function != "" {
+		return slices.ContainsFunc(actions, func(a Action) bool {
+			return p.allowed(principal, a, res)
+		})
+	}
+
+	visible := false
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if !r.appliesTo(principal) || !match(r.Catalog, res.Catalog) || !match(r.Schema, res.Schema) {
+			continue
+		}
+		if r.Effect == Deny {
+			if r.allTables() && slices.Contains(r.Actions, ActionAll) {
+				return false
+			}
+			continue
+		}
+		visible = true
+	}
+	return visible
+}
+
+// appliesTo reports whether the rule applies to the principal.
+func (r *Rule) appliesTo(p *auth.Principal) bool {
+	if p == nil || p.ID == "" {
+		return false
+	}
+	if slices.Contains(r.Identities, "*") || slices.Contains(r.Identities, p.ID) {
+		return true
+	}
+	return slices.ContainsFunc(r.Roles, p.HasRole) || slices.ContainsFunc(r.Groups, p.InGroup)
+}
+
+// covers reports whether the rule lists action.
+func (r *Rule) covers(action Action) bool {
+	return slices.Contains(r.Actions, ActionAll) || slices.Contains(r.Actions, action)
+}
+
+// matches reports whether the rule patterns match res.
+func (r *Rule) matches(res auth.Resource) bool {
+	if !match(r.Catalog, res.Catalog) || !match(r.Schema, res.Schema) {
+		return false
+	}
+	object := res.Table
+	if object == "" {
+		object = res.Function
+	}
+	if object == "" {
+		// Schema-level operation: only rules covering every table apply
+		return r.allTables()
+	}
+	return match(r.Table, object)
+}
+
+// allTables reports whether the rule covers every table and function of a schema.
+func (r *Rule) allTables() bool {
+	return r.Table == "" || r.Table == "*"
+}
+
+// match reports whether name matches the glob pattern; empty patterns match everything.
+func match(pattern, name string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
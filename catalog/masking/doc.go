@@ -0,0 +1,32 @@
+// Package masking provides per-principal column masking for catalog tables.
+//
+// Wrap decorates a catalog.Table with masking rules. A Rule names a column,
+// the roles it applies to and a Mask that redacts the column values. For each
+// column, the first rule that applies to the caller decides how the column is
+// returned; a rule with a nil Mask reveals it. Masks are applied to the batches
+// returned by Scan and to DML ReturningData; the Arrow schema of the table is
+// unchanged.
+//
+// Built-in masks:
+//   - Null replaces values with null
+//   - Hash replaces strings with their (keyed) SHA-256 hash
+//   - Partial keeps the start and end of strings and hides the rest
+//   - Constant replaces values with a fixed value
+//
+// # Basic Usage
+//
+//	users, err := masking.Wrap(usersTable, []masking.Rule{
+//		{Column: "email", Roles: []string{"admin"}},                          // clear
+//		{Column: "email", Roles: []string{"support"}, Mask: masking.Partial(2, 4, "*")},
+//		{Column: "email", Mask: masking.Hash(hashKey)},                        // everyone else
+//		{Column: "ssn", Roles: []string{"admin"}},
+//		{Column: "ssn", Mask: masking.Null()},
+//	})
+//
+// # Filter Pushdown
+//
+// Filters that reference a column masked for the caller are not pushed down:
+// they are removed from ScanOptions.Filter before the wrapped table is scanned,
+// so rows cannot be selected by their clear values. DuckDB applies such filters
+// to the masked values it receives.
+package masking
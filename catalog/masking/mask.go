@@ -0,0 +1,113 @@
+package masking
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/arrow/scalar"
+)
+
+// Mask redacts the values of a column.
+// Implementations MUST be goroutine-safe.
+type Mask interface {
+	// Apply returns a masked copy of arr with the same length and data type.
+	// Apply must accept empty arrays; Wrap uses them to check that the mask
+	// supports the column type.
+	Apply(mem memory.Allocator, arr arrow.Array) (arrow.Array, error)
+}
+
+// MaskFunc is an adapter to allow ordinary functions to be used as a Mask.
+type MaskFunc func(mem memory.Allocator, arr arrow.Array) (arrow.Array, error)
+
+// Apply implements Mask.
+func (f MaskFunc) Apply(mem memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+	return f(mem, arr)
+}
+
+// Null returns a mask that replaces every value with null.
+func Null() Mask {
+	return MaskFunc(func(mem memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+		return array.MakeArrayOfNull(mem, arr.DataType(), arr.Len()), nil
+	})
+}
+
+// Hash returns a mask that replaces string values with the hex encoded SHA-256
+// hash of the value, or its HMAC-SHA256 if key is set. Equal values keep equal
+// hashes, so masked columns can still be joined and grouped; use a key to
+// prevent guessing values by hashing candidates. Null values stay null.
+func Hash(key []byte) Mask {
+	newHash := sha256.New
+	if len(key) > 0 {
+		newHash = func() hash.Hash { return hmac.New(sha256.New, key) }
+	}
+	return mapStrings(func(s string) string {
+		h := newHash()
+		h.Write([]byte(s))
+		return hex.EncodeToString(h.Sum(nil))
+	})
+}
+
+// Partial returns a mask that keeps the first prefix and the last suffix
+// characters of string values and replaces every other character with fill,
+// e.g. Partial(1, 4, "*") masks "555-0123" as "5***0123". Values with no more
+// than prefix+suffix characters are replaced entirely. Null values stay null.
+func Partial(prefix, suffix int, fill string) Mask {
+	return mapStrings(func(s string) string {
+		runes := []rune(s)
+		if len(runes) <= prefix+suffix {
+			return strings.Repeat(fill, len(runes))
+		}
+		return string(runes[:prefix]) + strings.Repeat(fill, len(runes)-prefix-suffix) + string(runes[len(runes)-suffix:])
+	})
+}
+
+// Constant returns a mask that replaces every value, including nulls, with
+// value converted to the column type (e.g. Constant("REDACTED") or Constant(0)).
+func Constant(value any) Mask {
+	return MaskFunc(func(mem memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+		s, err := scalar.MakeScalar(value).CastTo(arr.DataType())
+		if err != nil {
+			return nil, fmt.Errorf("constant %v: %w", value, err)
+		}
+		return scalar.MakeArrayFromScalar(s, arr.Len(), mem)
+	})
+}
+
+// mapStrings returns a mask that applies fn to the non-null values of string columns.
+func mapStrings(fn func(string) string) Mask {
+	return MaskFunc(func(mem memory.Allocator, arr arrow.Array) (arrow.Array, error) {
+		switch a := arr.(type) {
+		case *array.String:
+			b := array.NewStringBuilder(mem)
+			defer b.Release()
+			for i := 0; i < a.Len(); i++ {
+				if a.IsNull(i) {
+					b.AppendNull()
+				} else {
+					b.Append(fn(a.Value(i)))
+				}
+			}
+			return b.NewArray(), nil
+		case *array.LargeString:
+			b := array.NewLargeStringBuilder(mem)
+			defer b.Release()
+			for i := 0; i < a.Len(); i++ {
+				if a.IsNull(i) {
+					b.AppendNull()
+				} else {
+					b.Append(fn(a.Value(i)))
+				}
+			}
+			return b.NewArray(), nil
+		default:
+			return nil, fmt.Errorf("mask requires a string column, got %s", arr.DataType())
+		}
+	})
+}
@@ -0,0 +1,324 @@
+package masking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/filter"
+	"github.com/hugr-lab/airport-go/internal/dml"
+)
+
+// Rule masks a column for the callers it applies to.
+type Rule struct {
+	// Column is the name of the masked column.
+	Column string
+
+	// Roles are the roles the rule applies to. A rule without roles applies
+	// to every caller, including unauthenticated ones.
+	Roles []string
+
+	// Mask redacts the column. A nil Mask reveals the column.
+	Mask Mask
+}
+
+// appliesTo reports whether the rule applies to the principal.
+func (r *Rule) appliesTo(p *auth.Principal) bool {
+	return len(r.Roles) == 0 || slices.ContainsFunc(r.Roles, p.HasRole)
+}
+
+// Table wraps a catalog.Table and masks columns of the rows it returns.
+//
+// Table implements catalog.InsertableTable, catalog.UpdatableBatchTable and
+// catalog.DeletableBatchTable; DML on a wrapped table without the matching
+// capability fails with catalog.ErrUnimplemented. Column statistics and
+// partitions of the wrapped table are not exposed, since statistics would
+// reveal masked values.
+type Table struct {
+	table catalog.Table
+	rules []Rule
+	alloc memory.Allocator
+}
+
+var (
+	_ catalog.InsertableTable     = (*Table)(nil)
+	_ catalog.UpdatableBatchTable = (*Table)(nil)
+	_ catalog.DeletableBatchTable = (*Table)(nil)
+)
+
+// Wrap returns t with rules applied to its scans and DML returning data.
+// For each column, the first rule that applies to the caller decides how the
+// column is returned; columns without an applicable rule are returned as is.
+// Returns an error if t has no fixed schema, a rule names an unknown column or
+// a mask does not support the column type.
+func Wrap(t catalog.Table, rules []Rule) (*Table, error) {
+	schema := t.ArrowSchema(nil)
+	if schema == nil {
+		return nil, fmt.Errorf("table %q has no fixed schema", t.Name())
+	}
+	alloc := memory.DefaultAllocator
+	for i, r := range rules {
+		indices := schema.FieldIndices(r.Column)
+		if len(indices) == 0 {
+			return nil, fmt.Errorf("rule %d: column %q not found in table %q", i, r.Column, t.Name())
+		}
+		if r.Mask == nil {
+			continue
+		}
+		empty := array.MakeArrayOfNull(alloc, schema.Field(indices[0]).Type, 0)
+		masked, err := r.Mask.Apply(alloc, empty)
+		empty.Release()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: column %q: %w", i, r.Column, err)
+		}
+		masked.Release()
+	}
+	return &Table{table: t, rules: rules, alloc: alloc}, nil
+}
+
+// Unwrap returns the wrapped table.
+func (t *Table) Unwrap() catalog.Table {
+	return t.table
+}
+
+// Name implements catalog.Table.
+func (t *Table) Name() string {
+	return t.table.Name()
+}
+
+// Comment implements catalog.Table.
+func (t *Table) Comment() string {
+	return t.table.Comment()
+}
+
+// ArrowSchema implements catalog.Table.
+// Masked columns keep their type, so the schema is the schema of the wrapped table.
+func (t *Table) ArrowSchema(columns []string) *arrow.Schema {
+	return t.table.ArrowSchema(columns)
+}
+
+// Scan implements catalog.Table.
+// Filters that reference a column masked for the caller are removed from
+// opts.Filter, so the wrapped table cannot select rows by the clear values;
+// DuckDB applies them to the masked values instead.
+func (t *Table) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	masks := t.masks(ctx)
+	if len(masks) == 0 {
+		return t.table.Scan(ctx, opts)
+	}
+
+	var scanOpts catalog.ScanOptions
+	if opts != nil {
+		scanOpts = *opts
+	}
+	data, stripped, err := stripFilters(scanOpts.Filter, masks)
+	if err != nil {
+		return nil, err
+	}
+	if stripped {
+		// Rows the filter would have removed are now returned; leave the
+		// limit to DuckDB, which re-applies the filter.
+		scanOpts.Filter = data
+		scanOpts.Limit = 0
+	}
+
+	rdr, err := t.table.Scan(ctx, &scanOpts)
+	if err != nil {
+		return nil, err
+	}
+	return t.maskReader(rdr, masks), nil
+}
+
+// Insert implements catalog.InsertableTable.
+func (t *Table) Insert(ctx context.Context, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	insertable, ok := t.table.(catalog.InsertableTable)
+	if !ok {
+		return nil, fmt.Errorf("table %q does not support INSERT: %w", t.Name(), catalog.ErrUnimplemented)
+	}
+	result, err := insertable.Insert(ctx, rows, opts)
+	return t.maskResult(ctx, result), err
+}
+
+// Update implements catalog.UpdatableBatchTable.
+func (t *Table) Update(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	result, err := dml.UpdateBatch(ctx, t.table, rows, opts)
+	return t.maskResult(ctx, result), err
+}
+
+// Delete implements catalog.DeletableBatchTable.
+func (t *Table) Delete(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	result, err := dml.DeleteBatch(ctx, t.table, rows, opts)
+	return t.maskResult(ctx, result), err
+}
+
+// masks returns the masks of the columns masked for the caller.
+func (t *Table) masks(ctx context.Context) map[string]Mask {
+	principal := auth.PrincipalFromContext(ctx)
+	masks := make(map[string]Mask)
+	decided := make(map[string]bool)
+	for i := range t.rules {
+		r := &t.rules[i]
+		if decided[r.Column] || !r.appliesTo(principal) {
+			continue
+		}
+		decided[r.Column] = true
+		if r.Mask != nil {
+			masks[r.Column] = r.Mask
+		}
+	}
+	return masks
+}
+
+// maskResult masks the returning data of a DML result.
+func (t *Table) maskResult(ctx context.Context, result *catalog.DMLResult) *catalog.DMLResult {
+	if result == nil || result.ReturningData == nil {
+		return result
+	}
+	if masks := t.masks(ctx); len(masks) > 0 {
+		result.ReturningData = t.maskReader(result.ReturningData, masks)
+	}
+	return result
+}
+
+// maskReader returns a reader masking the batches of rdr. It takes ownership of rdr.
+func (t *Table) maskReader(rdr array.RecordReader, masks map[string]Mask) array.RecordReader {
+	r := &maskedReader{src: rdr, masks: masks, alloc: t.alloc}
+	r.refCount.Add(1)
+	return r
+}
+
+// maskedReader applies column masks to each batch of a source reader.
+type maskedReader struct {
+	refCount atomic.Int64
+
+	src   array.RecordReader
+	masks map[string]Mask
+	alloc memory.Allocator
+	out   arrow.RecordBatch
+	err   error
+}
+
+func (r *maskedReader) Retain() {
+	r.refCount.Add(1)
+}
+
+func (r *maskedReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		if r.out != nil {
+			r.out.Release()
+			r.out = nil
+		}
+		r.src.Release()
+	}
+}
+
+func (r *maskedReader) Schema() *arrow.Schema {
+	return r.src.Schema()
+}
+
+func (r *maskedReader) RecordBatch() arrow.RecordBatch {
+	return r.out
+}
+
+// Deprecated: Use [maskedReader.RecordBatch] instead.
+func (r *maskedReader) Record() arrow.RecordBatch {
+	return r.out
+}
+
+func (r *maskedReader) Err() error {
+	return r.err
+}
+
+func (r *maskedReader) Next() bool {
+	if r.out != nil {
+		r.out.Release()
+		r.out = nil
+	}
+	if r.err != nil || !r.src.Next() {
+		if r.err == nil {
+			r.err = r.src.Err()
+		}
+		return false
+	}
+	r.out, r.err = maskBatch(r.alloc, r.src.RecordBatch(), r.masks)
+	return r.err == nil
+}
+
+// maskBatch returns rec with the masked columns replaced.
+func maskBatch(alloc memory.Allocator, rec arrow.RecordBatch, masks map[string]Mask) (arrow.RecordBatch, error) {
+	cols := make([]arrow.Array, rec.NumCols())
+	defer func() {
+		for _, c := range cols {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+	for i, field := range rec.Schema().Fields() {
+		col := rec.Column(i)
+		mask, ok := masks[field.Name]
+		if !ok {
+			col.Retain()
+			cols[i] = col
+			continue
+		}
+		masked, err := mask.Apply(alloc, col)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mask column %q: %w", field.Name, err)
+		}
+		cols[i] = masked
+	}
+	return array.NewRecordBatch(rec.Schema(), cols, rec.NumRows()), nil
+}
+
+// stripFilters removes the top-level filters referencing masked columns from
+// a serialized filter pushdown document and reports whether any was removed.
+// Filters with unresolvable column references are removed as well.
+// The returned document is nil if no filter is left.
+func stripFilters(data []byte, masks map[string]Mask) ([]byte, bool, error) {
+	if len(data) == 0 {
+		return data, false, nil
+	}
+	doc, err := filter.ParseDocument(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	kept := make([]json.RawMessage, 0, len(doc.Filters))
+	for i, f := range doc.Filters {
+		if !referencesMasked(doc, i, masks) {
+			kept = append(kept, f)
+		}
+	}
+	switch len(kept) {
+	case len(doc.Filters):
+		return data, false, nil
+	case 0:
+		return nil, true, nil
+	}
+	doc.Filters = kept
+	data, err = doc.Marshal()
+	return data, true, err
+}
+
+// referencesMasked reports whether filter i of doc references a masked column.
+func referencesMasked(doc *filter.Document, i int, masks map[string]Mask) bool {
+	columns, err := doc.Columns(i)
+	if err != nil {
+		return true
+	}
+	for _, c := range columns {
+		if _, masked := masks[c]; masked {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package masking
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/catalog"
+	catalogmemory "github.com/hugr-lab/airport-go/catalog/memory"
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+var usersSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "email", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "phone", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "salary", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+}, nil)
+
+var testRules = []Rule{
+	{Column: "email", Roles: []string{"admin"}},
+	{Column: "email", Roles: []string{"support"}, Mask: Partial(1, 4, "*")},
+	{Column: "email", Mask: Hash(nil)},
+	{Column: "phone", Roles: []string{"admin", "support"}},
+	{Column: "phone", Mask: Null()},
+	{Column: "salary", Mask: Constant(0)},
+}
+
+func roleContext(roles ...string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{ID: "user", Roles: roles})
+}
+
+// newUsersTable creates an in-memory users table with two rows and wraps it with testRules.
+func newUsersTable(t *testing.T) (*Table, *recordingTable) {
+	t.Helper()
+	ctx := context.Background()
+
+	cat := catalogmemory.NewCatalog("test")
+	sc, err := cat.CreateSchema(ctx, "main", catalog.CreateSchemaOptions{})
+	if err != nil {
+		t.Fatalf("CreateSchema failed: %v", err)
+	}
+	tbl, err := sc.(*catalogmemory.Schema).CreateTable(ctx, "users", usersSchema, catalog.CreateTableOptions{})
+	if err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	rows := usersReader(t, []int64{1, 2}, []string{"ann@example.com", "bob@example.com"}, []string{"555-0100", "555-0199"})
+	defer rows.Release()
+	if _, err := tbl.(catalog.InsertableTable).Insert(ctx, rows, nil); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	rec := &recordingTable{Table: tbl}
+	masked, err := Wrap(rec, testRules)
+	if err != nil {
+		t.Fatalf("Wrap failed: %v", err)
+	}
+	return masked, rec
+}
+
+func usersReader(t *testing.T, ids []int64, emails, phones []string) array.RecordReader {
+	t.Helper()
+	b := array.NewRecordBuilder(memory.DefaultAllocator, usersSchema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues(emails, nil)
+	b.Field(2).(*array.StringBuilder).AppendValues(phones, nil)
+	for range ids {
+		b.Field(3).(*array.Int64Builder).Append(100)
+	}
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+	rdr, err := array.NewRecordReader(usersSchema, []arrow.RecordBatch{rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rdr
+}
+
+// recordingTable records the options of its scans.
+type recordingTable struct {
+	catalog.Table
+	opts *catalog.ScanOptions
+}
+
+func (r *recordingTable) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	r.opts = opts
+	return r.Table.Scan(ctx, opts)
+}
+
+func (r *recordingTable) Insert(ctx context.Context, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	return r.Table.(catalog.InsertableTable).Insert(ctx, rows, opts)
+}
+
+// readColumn returns the values of a column of all batches as strings, "<nil>" for nulls.
+func readColumn(t *testing.T, rdr array.RecordReader, column string) []string {
+	t.Helper()
+	defer rdr.Release()
+
+	var values []string
+	for rdr.Next() {
+		rec := rdr.RecordBatch()
+		col := rec.Column(rec.Schema().FieldIndices(column)[0])
+		for i := 0; i < col.Len(); i++ {
+			if col.IsNull(i) {
+				values = append(values, "<nil>")
+			} else {
+				values = append(values, col.ValueStr(i))
+			}
+		}
+	}
+	if err := rdr.Err(); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return values
+}
+
+func TestScanMasks(t *testing.T) {
+	tbl, _ := newUsersTable(t)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		column string
+		want   []string
+	}{
+		{"admin email", roleContext("admin"), "email", []string{"ann@example.com", "bob@example.com"}},
+		{"support email", roleContext("support"), "email", []string{"a**********.com", "b**********.com"}},
+		{"support phone", roleContext("support"), "phone", []string{"555-0100", "555-0199"}},
+		{"analyst phone", roleContext("analyst"), "phone", []string{"<nil>", "<nil>"}},
+		{"unauthenticated phone", context.Background(), "phone", []string{"<nil>", "<nil>"}},
+		{"admin salary", roleContext("admin"), "salary", []string{"0", "0"}},
+		{"ids unmasked", roleContext("analyst"), "id", []string{"1", "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, err := tbl.Scan(tt.ctx, &catalog.ScanOptions{})
+			if err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+			if !rdr.Schema().Equal(tbl.ArrowSchema(nil)) {
+				t.Errorf("schema = %v, want table schema", rdr.Schema())
+			}
+			if got := readColumn(t, rdr, tt.column); !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.column, got, tt.want)
+			}
+		})
+	}
+
+	// Hashes are stable and hide the value
+	rdr, _ := tbl.Scan(roleContext("analyst"), nil)
+	hashes := readColumn(t, rdr, "email")
+	if len(hashes[0]) != 64 || hashes[0] == hashes[1] {
+		t.Errorf("email hashes = %v", hashes)
+	}
+	rdr, _ = tbl.Scan(roleContext("analyst"), nil)
+	if again := readColumn(t, rdr, "email"); !slices.Equal(again, hashes) {
+		t.Errorf("email hashes changed: %v, then %v", hashes, again)
+	}
+}
+
+func TestScanStripsMaskedFilters(t *testing.T) {
+	tbl, rec := newUsersTable(t)
+
+	// email = 'ann@example.com' AND id > 0, as sent by DuckDB
+	pushed := []byte(`{"filters": [
+		{"expression_class": "BOUND_COMPARISON", "type": "COMPARE_EQUAL",
+		 "left": {"expression_class": "BOUND_COLUMN_REF", "type": "BOUND_COLUMN_REF", "return_type": {"id": "VARCHAR"}, "binding": {"table_index": 0, "column_index": 0}},
+		 "right": {"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "value": {"type": {"id": "VARCHAR"}, "is_null": false, "value": "ann@example.com"}}},
+		{"expression_class": "BOUND_COMPARISON", "type": "COMPARE_GREATERTHAN",
+		 "left": {"expression_class": "BOUND_COLUMN_REF", "type": "BOUND_COLUMN_REF", "return_type": {"id": "BIGINT"}, "binding": {"table_index": 0, "column_index": 1}},
+		 "right": {"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "value": {"type": {"id": "BIGINT"}, "is_null": false, "value": 0}}}],
+		"column_binding_names_by_index": ["email", "id"], "extra": {"kept": true}}`)
+
+	tests := []struct {
+		ctx  context.Context
+		want string
+	}{
+		{roleContext("admin"), "(email = 'ann@example.com') AND (id > 0)"},
+		{roleContext("analyst"), "id > 0"},
+	}
+	for _, tt := range tests {
+		rdr, err := tbl.Scan(tt.ctx, &catalog.ScanOptions{Filter: pushed, Limit: 1})
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		rdr.Release()
+
+		fp, err := filter.Parse(rec.opts.Filter)
+		if err != nil {
+			t.Fatalf("pushed filter does not parse: %v", err)
+		}
+		if got := filter.NewDuckDBEncoder(nil).EncodeFilters(fp); got != tt.want {
+			t.Errorf("pushed filter = %q, want %q", got, tt.want)
+		}
+		if !bytes.Contains(rec.opts.Filter, []byte(`"extra":`)) {
+			t.Errorf("pushed filter %s dropped unknown fields", rec.opts.Filter)
+		}
+	}
+	if rec.opts.Limit != 0 {
+		t.Errorf("limit = %d with a stripped filter, want 0", rec.opts.Limit)
+	}
+}
+
+func TestReturningDataMasked(t *testing.T) {
+	tbl, _ := newUsersTable(t)
+
+	rows := usersReader(t, []int64{3}, []string{"cy@example.com"}, []string{"555-0142"})
+	defer rows.Release()
+	result, err := tbl.Insert(roleContext("analyst"), rows, &catalog.DMLOptions{Returning: true})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if got := readColumn(t, result.ReturningData, "phone"); !slices.Equal(got, []string{"<nil>"}) {
+		t.Errorf("returned phone = %v, want [<nil>]", got)
+	}
+}
+
+func TestWrapErrors(t *testing.T) {
+	tbl, _ := newUsersTable(t)
+	tests := map[string]Rule{
+		"unknown column":   {Column: "missing", Mask: Null()},
+		"hash of integer":  {Column: "salary", Mask: Hash(nil)},
+		"constant of type": {Column: "salary", Mask: Constant("x")},
+	}
+	for name, r := range tests {
+		if _, err := Wrap(tbl.Unwrap(), []Rule{r}); err == nil {
+			t.Errorf("%s: Wrap should fail", name)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// Catalog is an in-memory catalog.DynamicCatalog.
+// Every DDL operation on the catalog, its schemas or its tables increments the
+// catalog version so that DuckDB refreshes its cached metadata.
+// All methods are goroutine-safe.
+type Catalog struct {
+	name    string
+	alloc   memory.Allocator
+	version atomic.Uint64
+
+	mu      sync.RWMutex
+	schemas map[string]*Schema
+}
+
+// NewCatalog creates an empty in-memory catalog with the given name.
+// The name is used for routing in multi-catalog servers and may be empty.
+func NewCatalog(name string) *Catalog {
+	return NewCatalogWithAllocator(name, memory.DefaultAllocator)
+}
+
+// NewCatalogWithAllocator creates an empty in-memory catalog that uses alloc
+// for all Arrow buffers it creates.
+func NewCatalogWithAllocator(name string, alloc memory.Allocator) *Catalog {
+	if alloc == nil {
+		alloc = memory.DefaultAllocator
+	}
+	c := &Catalog{
+		name:    name,
+		alloc:   alloc,
+		schemas: make(map[string]*Schema),
+	}
+	c.version.Store(1)
+	return c
+}
+
+// Name implements catalog.NamedCatalog.
+func (c *Catalog) Name() string {
+	return c.name
+}
+
+// CatalogVersion implements catalog.VersionedCatalog.
+// The version is never fixed, so DuckDB re-checks it for every transaction.
+func (c *Catalog) CatalogVersion(ctx context.Context) (catalog.CatalogVersion, error) {
+	return catalog.CatalogVersion{Version: c.version.Load()}, nil
+}
+
+// Schemas implements catalog.Catalog.
+func (c *Catalog) Schemas(ctx context.Context) ([]catalog.Schema, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]catalog.Schema, 0, len(c.schemas))
+	for _, s := range c.schemas {
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// Schema implements catalog.Catalog.
+func (c *Catalog) Schema(ctx context.Context, name string) (catalog.Schema, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	s, ok := c.schemas[name]
+	if !ok {
+		return nil, nil // Not found, not an error
+	}
+	return s, nil
+}
+
+// CreateSchema implements catalog.DynamicCatalog.
+func (c *Catalog) CreateSchema(ctx context.Context, name string, opts catalog.CreateSchemaOptions) (catalog.Schema, error) {
+	if name == "" {
+		return nil, fmt.Errorf("schema name cannot be empty")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.schemas[name]; exists {
+		return nil, fmt.Errorf("schema %q: %w", name, catalog.ErrAlreadyExists)
+	}
+
+	s := newSchema(c, name, opts.Comment)
+	c.schemas[name] = s
+	c.bumpVersion()
+	return s, nil
+}
+
+// DropSchema implements catalog.DynamicCatalog.
+func (c *Catalog) DropSchema(ctx context.Context, name string, opts catalog.DropSchemaOptions) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, exists := c.schemas[name]
+	if !exists {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("schema %q: %w", name, catalog.ErrNotFound)
+	}
+
+	if s.tableCount() > 0 {
+		return fmt.Errorf("schema %q: %w", name, catalog.ErrSchemaNotEmpty)
+	}
+
+	delete(c.schemas, name)
+	c.bumpVersion()
+	return nil
+}
+
+// bumpVersion increments the catalog version after a metadata change.
+func (c *Catalog) bumpVersion() {
+	c.version.Add(1)
+}
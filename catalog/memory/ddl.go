@@ -0,0 +1,385 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// AddColumn implements catalog.DynamicTable.
+// Existing rows get NULL in the new column, so a NOT NULL column can only be
+// added to an empty table.
+func (t *Table) AddColumn(ctx context.Context, columnSchema *arrow.Schema, opts catalog.AddColumnOptions) error {
+	if columnSchema == nil || columnSchema.NumFields() != 1 {
+		return fmt.Errorf("column schema must contain exactly one field")
+	}
+	newCol := columnSchema.Field(0)
+	if newCol.Name == RowIDColumn {
+		return fmt.Errorf("column %q is reserved for row identifiers", RowIDColumn)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.columnIndexLocked(newCol.Name) >= 0 {
+		if opts.IfColumnNotExists {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", newCol.Name, catalog.ErrAlreadyExists)
+	}
+
+	data, err := t.compactLocked()
+	if err != nil {
+		return err
+	}
+	if !newCol.Nullable && data.NumRows() > 0 {
+		return fmt.Errorf("column %q: cannot add NOT NULL column to a table with rows", newCol.Name)
+	}
+
+	cols := make([]arrow.Array, 0, data.NumCols()+1)
+	cols = append(cols, data.Columns()...)
+	nulls := array.MakeArrayOfNull(t.alloc, newCol.Type, int(data.NumRows()))
+	defer nulls.Release()
+	cols = append(cols, nulls)
+
+	fields := append(t.schema.Fields(), newCol)
+	t.replaceDataLocked(t.newSchemaLocked(fields), cols, data.NumRows())
+	t.catalog.bumpVersion()
+	return nil
+}
+
+// RemoveColumn implements catalog.DynamicTable.
+func (t *Table) RemoveColumn(ctx context.Context, name string, opts catalog.RemoveColumnOptions) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	colIdx := t.columnIndexLocked(name)
+	if colIdx < 0 {
+		if opts.IfColumnExists {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", name, catalog.ErrNotFound)
+	}
+
+	data, err := t.compactLocked()
+	if err != nil {
+		return err
+	}
+
+	fields := make([]arrow.Field, 0, t.schema.NumFields()-1)
+	cols := make([]arrow.Array, 0, t.schema.NumFields()-1)
+	for i, f := range t.schema.Fields() {
+		if i == colIdx {
+			continue
+		}
+		fields = append(fields, f)
+		cols = append(cols, data.Column(i))
+	}
+
+	delete(t.defaults, name)
+	t.replaceDataLocked(t.newSchemaLocked(fields), cols, data.NumRows())
+	t.catalog.bumpVersion()
+	return nil
+}
+
+// RenameColumn implements catalog.DynamicTable.
+func (t *Table) RenameColumn(ctx context.Context, oldName, newName string, opts catalog.RenameColumnOptions) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	colIdx := t.columnIndexLocked(oldName)
+	if colIdx < 0 {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", oldName, catalog.ErrNotFound)
+	}
+	if newName == RowIDColumn || t.columnIndexLocked(newName) >= 0 {
+		return fmt.Errorf("column %q: %w", newName, catalog.ErrAlreadyExists)
+	}
+
+	if expr, ok := t.defaults[oldName]; ok {
+		delete(t.defaults, oldName)
+		t.defaults[newName] = expr
+	}
+	return t.updateFieldLocked(colIdx, func(f arrow.Field) arrow.Field {
+		f.Name = newName
+		return f
+	})
+}
+
+// ChangeColumnType implements catalog.DynamicTable.
+// Existing values are cast to the new type with Arrow cast rules; the SQL
+// conversion expression is not evaluated.
+func (t *Table) ChangeColumnType(ctx context.Context, columnSchema *arrow.Schema, expression string, opts catalog.ChangeColumnTypeOptions) error {
+	if columnSchema == nil || columnSchema.NumFields() != 1 {
+		return fmt.Errorf("column schema must contain exactly one field")
+	}
+	newField := columnSchema.Field(0)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	colIdx := t.columnIndexLocked(newField.Name)
+	if colIdx < 0 {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", newField.Name, catalog.ErrNotFound)
+	}
+
+	data, err := t.compactLocked()
+	if err != nil {
+		return err
+	}
+	converted, err := castColumn(compute.WithAllocator(ctx, t.alloc), newField, data.Column(colIdx))
+	if err != nil {
+		return err
+	}
+	defer converted.Release()
+	if !newField.Nullable && converted.NullN() > 0 {
+		return fmt.Errorf("column %q: NULL value violates NOT NULL constraint", newField.Name)
+	}
+
+	fields := t.schema.Fields()
+	fields[colIdx] = newField
+	cols := append([]arrow.Array(nil), data.Columns()...)
+	cols[colIdx] = converted
+
+	t.replaceDataLocked(t.newSchemaLocked(fields), cols, data.NumRows())
+	t.catalog.bumpVersion()
+	return nil
+}
+
+// SetNotNull implements catalog.DynamicTable.
+// Fails if the column already contains NULL values.
+func (t *Table) SetNotNull(ctx context.Context, columnName string, opts catalog.SetNotNullOptions) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	colIdx := t.columnIndexLocked(columnName)
+	if colIdx < 0 {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", columnName, catalog.ErrNotFound)
+	}
+
+	for _, b := range t.batches {
+		if b.Column(colIdx).NullN() > 0 {
+			return fmt.Errorf("column %q contains NULL values", columnName)
+		}
+	}
+
+	return t.updateFieldLocked(colIdx, func(f arrow.Field) arrow.Field {
+		f.Nullable = false
+		return f
+	})
+}
+
+// DropNotNull implements catalog.DynamicTable.
+func (t *Table) DropNotNull(ctx context.Context, columnName string, opts catalog.DropNotNullOptions) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	colIdx := t.columnIndexLocked(columnName)
+	if colIdx < 0 {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", columnName, catalog.ErrNotFound)
+	}
+
+	return t.updateFieldLocked(colIdx, func(f arrow.Field) arrow.Field {
+		f.Nullable = true
+		return f
+	})
+}
+
+// SetDefault implements catalog.DynamicTable.
+// The expression is recorded (see DefaultExpression) but not evaluated.
+func (t *Table) SetDefault(ctx context.Context, columnName, expression string, opts catalog.SetDefaultOptions) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.columnIndexLocked(columnName) < 0 {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", columnName, catalog.ErrNotFound)
+	}
+
+	if expression == "" {
+		delete(t.defaults, columnName)
+	} else {
+		t.defaults[columnName] = expression
+	}
+	t.catalog.bumpVersion()
+	return nil
+}
+
+// AddField implements catalog.DynamicTable.
+// columnSchema holds one field named after the top-level struct column; its type
+// describes the path to the new field, one struct level per path element.
+// Existing levels are descended into and the first missing field is added.
+func (t *Table) AddField(ctx context.Context, columnSchema *arrow.Schema, opts catalog.AddFieldOptions) error {
+	if columnSchema == nil || columnSchema.NumFields() != 1 {
+		return fmt.Errorf("column schema must contain exactly one field")
+	}
+	spec := columnSchema.Field(0)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	colIdx := t.columnIndexLocked(spec.Name)
+	if colIdx < 0 {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("column %q: %w", spec.Name, catalog.ErrNotFound)
+	}
+
+	path, newField, err := resolveAddFieldPath(t.schema.Field(colIdx), spec)
+	if errors.Is(err, catalog.ErrAlreadyExists) && opts.IfFieldNotExists {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = t.editStructLocked(colIdx, path, func(fields []arrow.Field, children []arrow.Array, length int) ([]arrow.Field, []arrow.Array, error) {
+		for _, c := range children {
+			c.Retain()
+		}
+		return append(fields, newField), append(children, array.MakeArrayOfNull(t.alloc, newField.Type, length)), nil
+	})
+	if errors.Is(err, catalog.ErrNotFound) && opts.IgnoreNotFound {
+		return nil
+	}
+	return err
+}
+
+// RenameField implements catalog.DynamicTable.
+// columnPath starts with the top-level column name and ends with the field to rename.
+func (t *Table) RenameField(ctx context.Context, columnPath []string, newName string, opts catalog.RenameFieldOptions) error {
+	err := t.editField(columnPath, func(fields []arrow.Field, children []arrow.Array, idx int) ([]arrow.Field, []arrow.Array, error) {
+		for i, f := range fields {
+			if f.Name == newName && i != idx {
+				return nil, nil, fmt.Errorf("field %q: %w", newName, catalog.ErrAlreadyExists)
+			}
+		}
+		for _, c := range children {
+			c.Retain()
+		}
+		fields[idx].Name = newName
+		return fields, children, nil
+	})
+	if errors.Is(err, catalog.ErrNotFound) && opts.IgnoreNotFound {
+		return nil
+	}
+	return err
+}
+
+// RemoveField implements catalog.DynamicTable.
+// columnPath starts with the top-level column name and ends with the field to remove.
+// The last remaining field of a struct cannot be removed.
+func (t *Table) RemoveField(ctx context.Context, columnPath []string, opts catalog.RemoveFieldOptions) error {
+	err := t.editField(columnPath, func(fields []arrow.Field, children []arrow.Array, idx int) ([]arrow.Field, []arrow.Array, error) {
+		if len(fields) == 1 {
+			return nil, nil, fmt.Errorf("cannot remove the only field %q of a struct", fields[idx].Name)
+		}
+		newFields := make([]arrow.Field, 0, len(fields)-1)
+		newChildren := make([]arrow.Array, 0, len(children)-1)
+		for i := range fields {
+			if i == idx {
+				continue
+			}
+			children[i].Retain()
+			newFields = append(newFields, fields[i])
+			newChildren = append(newChildren, children[i])
+		}
+		return newFields, newChildren, nil
+	})
+	if errors.Is(err, catalog.ErrNotFound) && opts.IgnoreNotFound {
+		return nil
+	}
+	return err
+}
+
+// editField locks the table and applies edit to the struct that directly contains
+// the field addressed by columnPath. idx is the position of that field in the struct.
+func (t *Table) editField(columnPath []string, edit func(fields []arrow.Field, children []arrow.Array, idx int) ([]arrow.Field, []arrow.Array, error)) error {
+	if len(columnPath) < 2 {
+		return fmt.Errorf("field path %v: %w", columnPath, catalog.ErrNotFound)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	colIdx := t.columnIndexLocked(columnPath[0])
+	if colIdx < 0 {
+		return fmt.Errorf("column %q: %w", columnPath[0], catalog.ErrNotFound)
+	}
+
+	parent, name := columnPath[1:len(columnPath)-1], columnPath[len(columnPath)-1]
+	return t.editStructLocked(colIdx, parent, func(fields []arrow.Field, children []arrow.Array, _ int) ([]arrow.Field, []arrow.Array, error) {
+		for i, f := range fields {
+			if f.Name == name {
+				return edit(fields, children, i)
+			}
+		}
+		return nil, nil, fmt.Errorf("field %q: %w", name, catalog.ErrNotFound)
+	})
+}
+
+// editStructLocked rewrites the struct reached by following path inside column colIdx
+// and stores the resulting schema and data. Caller must hold the write lock.
+func (t *Table) editStructLocked(colIdx int, path []string, edit structEdit) error {
+	data, err := t.compactLocked()
+	if err != nil {
+		return err
+	}
+
+	field, col, err := editStruct(t.schema.Field(colIdx), data.Column(colIdx), path, edit)
+	if err != nil {
+		return err
+	}
+	defer col.Release()
+
+	fields := t.schema.Fields()
+	fields[colIdx] = field
+	cols := append([]arrow.Array(nil), data.Columns()...)
+	cols[colIdx] = col
+
+	t.replaceDataLocked(t.newSchemaLocked(fields), cols, data.NumRows())
+	t.catalog.bumpVersion()
+	return nil
+}
+
+// updateFieldLocked replaces the definition of column colIdx without touching its values.
+// Caller must hold the write lock.
+func (t *Table) updateFieldLocked(colIdx int, update func(arrow.Field) arrow.Field) error {
+	data, err := t.compactLocked()
+	if err != nil {
+		return err
+	}
+
+	fields := t.schema.Fields()
+	fields[colIdx] = update(fields[colIdx])
+
+	t.replaceDataLocked(t.newSchemaLocked(fields), data.Columns(), data.NumRows())
+	t.catalog.bumpVersion()
+	return nil
+}
+
+// newSchemaLocked builds a schema from fields that keeps the table schema metadata.
+func (t *Table) newSchemaLocked(fields []arrow.Field) *arrow.Schema {
+	meta := t.schema.Metadata()
+	return arrow.NewSchema(fields, &meta)
+}
@@ -0,0 +1,40 @@
+// Package memory provides an in-memory implementation of the dynamic catalog interfaces.
+//
+// The catalog, schema and table types keep all metadata and data in process memory
+// and support the full set of DDL and DML operations exposed by the Airport protocol:
+//   - Catalog implements catalog.DynamicCatalog, catalog.NamedCatalog and catalog.VersionedCatalog
+//   - Schema implements catalog.DynamicSchema
+//   - Table implements catalog.DynamicTable, catalog.InsertableTable,
+//     catalog.UpdatableBatchTable, catalog.DeletableBatchTable and catalog.StatisticsTable
+//
+// It is intended as a reference implementation and as a test double for servers
+// that do not need persistence.
+//
+// # Basic Usage
+//
+//	cat := memory.NewCatalog("demo")
+//	sc, _ := cat.CreateSchema(ctx, "main", catalog.CreateSchemaOptions{})
+//	_, _ = sc.(*memory.Schema).CreateTable(ctx, "users", usersSchema, catalog.CreateTableOptions{})
+//
+//	err := airport.NewServer(grpcServer, airport.ServerConfig{Catalog: cat})
+//
+// # Row Identifiers
+//
+// Every table exposes a leading "rowid" pseudo-column (Int64, marked with the
+// "is_rowid" metadata key) that DuckDB uses to address rows in UPDATE and DELETE.
+// Row IDs are assigned on insert, never reused, and remain stable across updates,
+// deletes and schema changes.
+//
+// # Nested Fields
+//
+// AddField expects a single-field schema that names the top-level struct column
+// and describes the path to the new field: every struct level that already exists
+// is descended into and the first missing field is added. RenameField and
+// RemoveField address the field by its full path, starting with the column name.
+//
+// # Limitations
+//
+// Scans return all rows; filters, limits and time points in ScanOptions are ignored
+// because DuckDB re-applies them client-side. Unique and check constraints are not
+// enforced, and default expressions set with SetDefault are recorded but not evaluated.
+package memory
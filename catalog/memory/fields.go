@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// structEdit rewrites the fields and child arrays of a struct.
+// children are borrowed; every returned array must carry its own reference.
+// length is the length every returned child array must have.
+type structEdit func(fields []arrow.Field, children []arrow.Array, length int) ([]arrow.Field, []arrow.Array, error)
+
+// editStruct follows path through nested struct fields of field/arr and applies
+// edit to the struct at the end of the path. Returns the rewritten field and a new
+// array reference owned by the caller.
+//
+// Child arrays are taken unsliced from the parent's ArrayData so that the parent's
+// offset and validity bitmap can be reused as-is.
+func editStruct(field arrow.Field, arr arrow.Array, path []string, edit structEdit) (arrow.Field, arrow.Array, error) {
+	st, ok := field.Type.(*arrow.StructType)
+	if !ok {
+		return field, nil, fmt.Errorf("field %q is not a struct: %w", field.Name, catalog.ErrNotFound)
+	}
+
+	data := arr.Data()
+	children := make([]arrow.Array, len(data.Children()))
+	for i, cd := range data.Children() {
+		children[i] = array.MakeFromData(cd)
+	}
+	defer releaseArrays(children)
+
+	var (
+		fields      []arrow.Field
+		newChildren []arrow.Array
+		err         error
+	)
+	if len(path) == 0 {
+		fields, newChildren, err = edit(st.Fields(), children, data.Offset()+data.Len())
+	} else {
+		idx, found := st.FieldIdx(path[0])
+		if !found {
+			return field, nil, fmt.Errorf("field %q: %w", path[0], catalog.ErrNotFound)
+		}
+		var child arrow.Array
+		fields = st.Fields()
+		fields[idx], child, err = editStruct(fields[idx], children[idx], path[1:], edit)
+		if err == nil {
+			newChildren = make([]arrow.Array, len(children))
+			for i, c := range children {
+				if i == idx {
+					newChildren[i] = child
+					continue
+				}
+				c.Retain()
+				newChildren[i] = c
+			}
+		}
+	}
+	if err != nil {
+		return field, nil, err
+	}
+	defer releaseArrays(newChildren)
+
+	childData := make([]arrow.ArrayData, len(newChildren))
+	for i, c := range newChildren {
+		childData[i] = c.Data()
+	}
+
+	field.Type = arrow.StructOf(fields...)
+	nd := array.NewData(field.Type, data.Len(), data.Buffers(), childData, data.NullN(), data.Offset())
+	defer nd.Release()
+	return field, array.MakeFromData(nd), nil
+}
+
+// resolveAddFieldPath matches an AddField specification against the existing column.
+// Returns the path of existing struct levels below the column and the field to add
+// at the end of that path.
+func resolveAddFieldPath(column, spec arrow.Field) ([]string, arrow.Field, error) {
+	var path []string
+	existing := column
+	for {
+		existingType, ok := existing.Type.(*arrow.StructType)
+		if !ok {
+			return nil, arrow.Field{}, fmt.Errorf("field %q is not a struct", existing.Name)
+		}
+		specType, ok := spec.Type.(*arrow.StructType)
+		if !ok || specType.NumFields() != 1 {
+			return nil, arrow.Field{}, fmt.Errorf("field %q must be a struct with exactly one field describing the path", spec.Name)
+		}
+
+		next := specType.Field(0)
+		idx, found := existingType.FieldIdx(next.Name)
+		if !found {
+			return path, next, nil
+		}
+
+		child := existingType.Field(idx)
+		if _, ok := child.Type.(*arrow.StructType); !ok {
+			return nil, arrow.Field{}, fmt.Errorf("field %q: %w", next.Name, catalog.ErrAlreadyExists)
+		}
+		if _, ok := next.Type.(*arrow.StructType); !ok {
+			return nil, arrow.Field{}, fmt.Errorf("field %q: %w", next.Name, catalog.ErrAlreadyExists)
+		}
+
+		path = append(path, next.Name)
+		existing, spec = child, next
+	}
+}
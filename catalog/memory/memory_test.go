@@ -0,0 +1,539 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// Compile-time interface checks.
+var (
+	_ catalog.DynamicCatalog      = (*Catalog)(nil)
+	_ catalog.NamedCatalog        = (*Catalog)(nil)
+	_ catalog.VersionedCatalog    = (*Catalog)(nil)
+	_ catalog.DynamicSchema       = (*Schema)(nil)
+	_ catalog.DynamicTable        = (*Table)(nil)
+	_ catalog.InsertableTable     = (*Table)(nil)
+	_ catalog.UpdatableBatchTable = (*Table)(nil)
+	_ catalog.DeletableBatchTable = (*Table)(nil)
+	_ catalog.StatisticsTable     = (*Table)(nil)
+)
+
+var usersSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
+	{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+// newTestTable creates a catalog with schema "main" and an empty users table.
+func newTestTable(t *testing.T, alloc memory.Allocator) (*Catalog, *Table) {
+	t.Helper()
+	ctx := context.Background()
+
+	cat := NewCatalogWithAllocator("test", alloc)
+	sc, err := cat.CreateSchema(ctx, "main", catalog.CreateSchemaOptions{})
+	if err != nil {
+		t.Fatalf("CreateSchema failed: %v", err)
+	}
+	tbl, err := sc.(*Schema).CreateTable(ctx, "users", usersSchema, catalog.CreateTableOptions{})
+	if err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	return cat, tbl.(*Table)
+}
+
+// insertUsers inserts (id, name) rows into tbl.
+func insertUsers(t *testing.T, tbl *Table, alloc memory.Allocator, ids []int64, names []string) {
+	t.Helper()
+
+	b := array.NewRecordBuilder(alloc, usersSchema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues(names, nil)
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+
+	reader, err := array.NewRecordReader(usersSchema, []arrow.RecordBatch{rec})
+	if err != nil {
+		t.Fatalf("NewRecordReader failed: %v", err)
+	}
+	defer reader.Release()
+
+	result, err := tbl.Insert(context.Background(), reader, nil)
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if result.AffectedRows != int64(len(ids)) {
+		t.Fatalf("expected %d inserted rows, got %d", len(ids), result.AffectedRows)
+	}
+}
+
+// scanRows reads the table and returns rowid -> name.
+func scanRows(t *testing.T, tbl *Table) map[int64]string {
+	t.Helper()
+
+	reader, err := tbl.Scan(context.Background(), &catalog.ScanOptions{})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	defer reader.Release()
+
+	if !reader.Schema().Equal(tbl.ArrowSchema(nil)) {
+		t.Fatalf("scan schema %v does not match table schema %v", reader.Schema(), tbl.ArrowSchema(nil))
+	}
+
+	nameIdx := reader.Schema().FieldIndices("name")[0]
+	rows := make(map[int64]string)
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		ids := rec.Column(0).(*array.Int64)
+		names := rec.Column(nameIdx).(*array.String)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			rows[ids.Value(i)] = names.Value(i)
+		}
+	}
+	return rows
+}
+
+// rowIDBatch builds a batch with a rowid column and optional name values.
+func rowIDBatch(alloc memory.Allocator, ids []int64, names []string) arrow.RecordBatch {
+	fields := []arrow.Field{rowIDField()}
+	if names != nil {
+		fields = append(fields, arrow.Field{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true})
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	b := array.NewRecordBuilder(alloc, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+	if names != nil {
+		b.Field(1).(*array.StringBuilder).AppendValues(names, nil)
+	}
+	return b.NewRecordBatch()
+}
+
+// TestCatalogSchemaLifecycle tests creating and dropping schemas and catalog version bumps.
+func TestCatalogSchemaLifecycle(t *testing.T) {
+	ctx := context.Background()
+	cat := NewCatalog("demo")
+
+	if cat.Name() != "demo" {
+		t.Errorf("expected name 'demo', got %q", cat.Name())
+	}
+	v1, _ := cat.CatalogVersion(ctx)
+
+	sc, err := cat.CreateSchema(ctx, "app", catalog.CreateSchemaOptions{Comment: "application"})
+	if err != nil {
+		t.Fatalf("CreateSchema failed: %v", err)
+	}
+	if sc.Comment() != "application" {
+		t.Errorf("expected comment 'application', got %q", sc.Comment())
+	}
+	if _, err := cat.CreateSchema(ctx, "app", catalog.CreateSchemaOptions{}); !errors.Is(err, catalog.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+
+	v2, _ := cat.CatalogVersion(ctx)
+	if v2.Version <= v1.Version {
+		t.Errorf("expected version to increase after CreateSchema, got %d -> %d", v1.Version, v2.Version)
+	}
+
+	if _, err := sc.(*Schema).CreateTable(ctx, "t", usersSchema, catalog.CreateTableOptions{}); err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	if err := cat.DropSchema(ctx, "app", catalog.DropSchemaOptions{}); !errors.Is(err, catalog.ErrSchemaNotEmpty) {
+		t.Errorf("expected ErrSchemaNotEmpty, got %v", err)
+	}
+	if err := sc.(*Schema).DropTable(ctx, "t", catalog.DropTableOptions{}); err != nil {
+		t.Fatalf("DropTable failed: %v", err)
+	}
+	if err := cat.DropSchema(ctx, "app", catalog.DropSchemaOptions{}); err != nil {
+		t.Fatalf("DropSchema failed: %v", err)
+	}
+	if err := cat.DropSchema(ctx, "app", catalog.DropSchemaOptions{}); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := cat.DropSchema(ctx, "app", catalog.DropSchemaOptions{IgnoreNotFound: true}); err != nil {
+		t.Errorf("expected nil with IgnoreNotFound, got %v", err)
+	}
+}
+
+// TestSchemaTableLifecycle tests creating, renaming and conflict handling of tables.
+func TestSchemaTableLifecycle(t *testing.T) {
+	ctx := context.Background()
+	cat := NewCatalog("")
+	sc, _ := cat.CreateSchema(ctx, "main", catalog.CreateSchemaOptions{})
+	s := sc.(*Schema)
+
+	tbl, err := s.CreateTable(ctx, "users", usersSchema, catalog.CreateTableOptions{Comment: "people"})
+	if err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	schema := tbl.ArrowSchema(nil)
+	if catalog.FindRowIDColumn(schema) != 0 {
+		t.Errorf("expected rowid column at index 0, schema: %v", schema)
+	}
+	if schema.NumFields() != 3 {
+		t.Errorf("expected 3 fields, got %d", schema.NumFields())
+	}
+
+	if _, err := s.CreateTable(ctx, "users", usersSchema, catalog.CreateTableOptions{}); !errors.Is(err, catalog.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+	same, err := s.CreateTable(ctx, "users", usersSchema, catalog.CreateTableOptions{OnConflict: catalog.OnConflictIgnore})
+	if err != nil || same != tbl {
+		t.Errorf("expected existing table with OnConflictIgnore, got %v, %v", same, err)
+	}
+
+	if err := s.RenameTable(ctx, "users", "people", catalog.RenameTableOptions{}); err != nil {
+		t.Fatalf("RenameTable failed: %v", err)
+	}
+	renamed, _ := s.Table(ctx, "people")
+	if renamed == nil || renamed.Name() != "people" {
+		t.Fatalf("expected renamed table 'people', got %v", renamed)
+	}
+	if old, _ := s.Table(ctx, "users"); old != nil {
+		t.Error("expected old table name to be gone")
+	}
+
+	reserved := arrow.NewSchema([]arrow.Field{{Name: "rowid", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	if _, err := s.CreateTable(ctx, "bad", reserved, catalog.CreateTableOptions{}); err == nil {
+		t.Error("expected error for schema with rowid column")
+	}
+}
+
+// TestTableDML tests insert, update and delete with stable row identifiers.
+func TestTableDML(t *testing.T) {
+	alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer alloc.AssertSize(t, 0)
+
+	ctx := context.Background()
+	cat, tbl := newTestTable(t, alloc)
+	defer func() {
+		sc, _ := cat.Schema(ctx, "main")
+		_ = sc.(*Schema).DropTable(ctx, "users", catalog.DropTableOptions{})
+	}()
+
+	insertUsers(t, tbl, alloc, []int64{1, 2}, []string{"Alice", "Bob"})
+	insertUsers(t, tbl, alloc, []int64{3}, []string{"Charlie"})
+
+	rows := scanRows(t, tbl)
+	if len(rows) != 3 || rows[1] != "Alice" || rows[2] != "Bob" || rows[3] != "Charlie" {
+		t.Fatalf("unexpected rows after insert: %v", rows)
+	}
+
+	// Update Bob via rowid and request RETURNING data.
+	upd := rowIDBatch(alloc, []int64{2, 99}, []string{"Bobby", "ghost"})
+	res, err := tbl.Update(ctx, upd, &catalog.DMLOptions{Returning: true, ReturningColumns: []string{"id", "name"}})
+	upd.Release()
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if res.AffectedRows != 1 {
+		t.Errorf("expected 1 updated row, got %d", res.AffectedRows)
+	}
+	var returned int64
+	for res.ReturningData.Next() {
+		rec := res.ReturningData.RecordBatch()
+		returned += rec.NumRows()
+		if rec.NumRows() == 1 && rec.Column(1).(*array.String).Value(0) != "Bobby" {
+			t.Errorf("expected RETURNING name 'Bobby', got %q", rec.Column(1).(*array.String).Value(0))
+		}
+	}
+	res.ReturningData.Release()
+	if returned != 1 {
+		t.Errorf("expected 1 RETURNING row, got %d", returned)
+	}
+
+	rows = scanRows(t, tbl)
+	if rows[2] != "Bobby" || rows[1] != "Alice" {
+		t.Errorf("unexpected rows after update: %v", rows)
+	}
+
+	// Delete Alice; rowids of remaining rows must not change.
+	del := rowIDBatch(alloc, []int64{1}, nil)
+	res, err = tbl.Delete(ctx, del, nil)
+	del.Release()
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if res.AffectedRows != 1 {
+		t.Errorf("expected 1 deleted row, got %d", res.AffectedRows)
+	}
+
+	rows = scanRows(t, tbl)
+	if len(rows) != 2 || rows[2] != "Bobby" || rows[3] != "Charlie" {
+		t.Errorf("unexpected rows after delete: %v", rows)
+	}
+
+	// New rows never reuse deleted rowids.
+	insertUsers(t, tbl, alloc, []int64{4}, []string{"Dana"})
+	rows = scanRows(t, tbl)
+	if rows[4] != "Dana" {
+		t.Errorf("expected new row with rowid 4, got %v", rows)
+	}
+}
+
+// TestTableDMLErrors tests that invalid DML is rejected without modifying data.
+func TestTableDMLErrors(t *testing.T) {
+	alloc := memory.NewGoAllocator()
+	ctx := context.Background()
+	_, tbl := newTestTable(t, alloc)
+
+	// NOT NULL violation leaves the table unchanged.
+	b := array.NewRecordBuilder(alloc, usersSchema)
+	b.Field(0).(*array.Int64Builder).AppendNull()
+	b.Field(1).(*array.StringBuilder).Append("nobody")
+	rec := b.NewRecordBatch()
+	b.Release()
+	reader, _ := array.NewRecordReader(usersSchema, []arrow.RecordBatch{rec})
+	if _, err := tbl.Insert(ctx, reader, nil); err == nil {
+		t.Error("expected NOT NULL violation")
+	}
+	reader.Release()
+	rec.Release()
+	if tbl.NumRows() != 0 {
+		t.Errorf("expected no rows after failed insert, got %d", tbl.NumRows())
+	}
+
+	// Null rowid.
+	schema := arrow.NewSchema([]arrow.Field{rowIDField()}, nil)
+	nb := array.NewRecordBuilder(alloc, schema)
+	nb.Field(0).(*array.Int64Builder).AppendNull()
+	nullRec := nb.NewRecordBatch()
+	nb.Release()
+	defer nullRec.Release()
+	if _, err := tbl.Delete(ctx, nullRec, nil); !errors.Is(err, catalog.ErrNullRowID) {
+		t.Errorf("expected ErrNullRowID, got %v", err)
+	}
+}
+
+// TestTableColumnDDL tests column-level ALTER TABLE operations on a populated table.
+func TestTableColumnDDL(t *testing.T) {
+	alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer alloc.AssertSize(t, 0)
+
+	ctx := context.Background()
+	cat, tbl := newTestTable(t, alloc)
+	defer func() {
+		sc, _ := cat.Schema(ctx, "main")
+		_ = sc.(*Schema).DropTable(ctx, "users", catalog.DropTableOptions{})
+	}()
+	insertUsers(t, tbl, alloc, []int64{1, 2}, []string{"Alice", "Bob"})
+
+	age := arrow.NewSchema([]arrow.Field{{Name: "age", Type: arrow.PrimitiveTypes.Int32, Nullable: true}}, nil)
+	if err := tbl.AddColumn(ctx, age, catalog.AddColumnOptions{}); err != nil {
+		t.Fatalf("AddColumn failed: %v", err)
+	}
+	if err := tbl.AddColumn(ctx, age, catalog.AddColumnOptions{}); !errors.Is(err, catalog.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+	if err := tbl.AddColumn(ctx, age, catalog.AddColumnOptions{IfColumnNotExists: true}); err != nil {
+		t.Errorf("expected nil with IfColumnNotExists, got %v", err)
+	}
+
+	ageBig := arrow.NewSchema([]arrow.Field{{Name: "age", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+	if err := tbl.ChangeColumnType(ctx, ageBig, "", catalog.ChangeColumnTypeOptions{}); err != nil {
+		t.Fatalf("ChangeColumnType failed: %v", err)
+	}
+	if f, _ := tbl.ArrowSchema(nil).FieldsByName("age"); !arrow.TypeEqual(f[0].Type, arrow.PrimitiveTypes.Int64) {
+		t.Errorf("expected age to be int64, got %v", f[0].Type)
+	}
+
+	if err := tbl.RenameColumn(ctx, "name", "full_name", catalog.RenameColumnOptions{}); err != nil {
+		t.Fatalf("RenameColumn failed: %v", err)
+	}
+	if err := tbl.RenameColumn(ctx, "missing", "x", catalog.RenameColumnOptions{}); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := tbl.RemoveColumn(ctx, "age", catalog.RemoveColumnOptions{}); err != nil {
+		t.Fatalf("RemoveColumn failed: %v", err)
+	}
+
+	if err := tbl.SetNotNull(ctx, "full_name", catalog.SetNotNullOptions{}); err != nil {
+		t.Fatalf("SetNotNull failed: %v", err)
+	}
+	if f, _ := tbl.ArrowSchema(nil).FieldsByName("full_name"); f[0].Nullable {
+		t.Error("expected full_name to be NOT NULL")
+	}
+	if err := tbl.DropNotNull(ctx, "full_name", catalog.DropNotNullOptions{}); err != nil {
+		t.Fatalf("DropNotNull failed: %v", err)
+	}
+	if err := tbl.SetDefault(ctx, "full_name", "'anonymous'", catalog.SetDefaultOptions{}); err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+	if expr, ok := tbl.DefaultExpression("full_name"); !ok || expr != "'anonymous'" {
+		t.Errorf("expected recorded default, got %q, %v", expr, ok)
+	}
+
+	reader, err := tbl.Scan(ctx, nil)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	defer reader.Release()
+	want := []string{"rowid", "id", "full_name"}
+	got := reader.Schema()
+	if got.NumFields() != len(want) {
+		t.Fatalf("expected fields %v, got %v", want, got)
+	}
+	for i, name := range want {
+		if got.Field(i).Name != name {
+			t.Errorf("field %d: expected %q, got %q", i, name, got.Field(i).Name)
+		}
+	}
+	var n int64
+	for reader.Next() {
+		n += reader.RecordBatch().NumRows()
+	}
+	if n != 2 {
+		t.Errorf("expected 2 rows after DDL, got %d", n)
+	}
+}
+
+// TestTableNestedFieldDDL tests adding, renaming and removing nested struct fields.
+func TestTableNestedFieldDDL(t *testing.T) {
+	alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer alloc.AssertSize(t, 0)
+
+	ctx := context.Background()
+	cat := NewCatalogWithAllocator("", alloc)
+	sc, _ := cat.CreateSchema(ctx, "main", catalog.CreateSchemaOptions{})
+	s := sc.(*Schema)
+
+	addrType := arrow.StructOf(
+		arrow.Field{Name: "city", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "geo", Type: arrow.StructOf(
+			arrow.Field{Name: "lat", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		), Nullable: true},
+	)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "addr", Type: addrType, Nullable: true},
+	}, nil)
+	created, err := s.CreateTable(ctx, "places", schema, catalog.CreateTableOptions{})
+	if err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	tbl := created.(*Table)
+	defer func() { _ = s.DropTable(ctx, "places", catalog.DropTableOptions{}) }()
+
+	b := array.NewRecordBuilder(alloc, schema)
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	sb := b.Field(1).(*array.StructBuilder)
+	for _, city := range []string{"Paris", "Rome"} {
+		sb.Append(true)
+		sb.FieldBuilder(0).(*array.StringBuilder).Append(city)
+		geo := sb.FieldBuilder(1).(*array.StructBuilder)
+		geo.Append(true)
+		geo.FieldBuilder(0).(*array.Float64Builder).Append(48.8)
+	}
+	rec := b.NewRecordBatch()
+	b.Release()
+	reader, _ := array.NewRecordReader(schema, []arrow.RecordBatch{rec})
+	if _, err := tbl.Insert(ctx, reader, nil); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	reader.Release()
+	rec.Release()
+
+	// ALTER TABLE places ADD COLUMN addr.geo.lon DOUBLE
+	addLon := arrow.NewSchema([]arrow.Field{{Name: "addr", Type: arrow.StructOf(
+		arrow.Field{Name: "geo", Type: arrow.StructOf(
+			arrow.Field{Name: "lon", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		)},
+	)}}, nil)
+	if err := tbl.AddField(ctx, addLon, catalog.AddFieldOptions{}); err != nil {
+		t.Fatalf("AddField failed: %v", err)
+	}
+	if err := tbl.AddField(ctx, addLon, catalog.AddFieldOptions{}); !errors.Is(err, catalog.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+	if err := tbl.AddField(ctx, addLon, catalog.AddFieldOptions{IfFieldNotExists: true}); err != nil {
+		t.Errorf("expected nil with IfFieldNotExists, got %v", err)
+	}
+
+	if err := tbl.RenameField(ctx, []string{"addr", "city"}, "town", catalog.RenameFieldOptions{}); err != nil {
+		t.Fatalf("RenameField failed: %v", err)
+	}
+	if err := tbl.RemoveField(ctx, []string{"addr", "geo", "lat"}, catalog.RemoveFieldOptions{}); err != nil {
+		t.Fatalf("RemoveField failed: %v", err)
+	}
+	if err := tbl.RemoveField(ctx, []string{"addr", "geo", "lon"}, catalog.RemoveFieldOptions{}); err == nil {
+		t.Error("expected error removing the only field of a struct")
+	}
+	if err := tbl.RemoveField(ctx, []string{"addr", "missing"}, catalog.RemoveFieldOptions{}); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := tbl.RemoveField(ctx, []string{"addr", "missing"}, catalog.RemoveFieldOptions{IgnoreNotFound: true}); err != nil {
+		t.Errorf("expected nil with IgnoreNotFound, got %v", err)
+	}
+
+	wantType := arrow.StructOf(
+		arrow.Field{Name: "town", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "geo", Type: arrow.StructOf(
+			arrow.Field{Name: "lon", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		), Nullable: true},
+	)
+	f, _ := tbl.ArrowSchema(nil).FieldsByName("addr")
+	if !arrow.TypeEqual(f[0].Type, wantType) {
+		t.Fatalf("expected addr type %v, got %v", wantType, f[0].Type)
+	}
+
+	scan, err := tbl.Scan(ctx, nil)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	defer scan.Release()
+	if !scan.Next() {
+		t.Fatal("expected a batch")
+	}
+	addr := scan.RecordBatch().Column(2).(*array.Struct)
+	town := addr.Field(0).(*array.String)
+	if town.Value(0) != "Paris" || town.Value(1) != "Rome" {
+		t.Errorf("expected towns [Paris Rome], got [%s %s]", town.Value(0), town.Value(1))
+	}
+	lon := addr.Field(1).(*array.Struct).Field(0)
+	if lon.NullN() != 2 {
+		t.Errorf("expected new lon field to be null, got %d nulls", lon.NullN())
+	}
+}
+
+// TestTableColumnStatistics tests column statistics computed from stored data.
+func TestTableColumnStatistics(t *testing.T) {
+	alloc := memory.NewGoAllocator()
+	ctx := context.Background()
+	_, tbl := newTestTable(t, alloc)
+	insertUsers(t, tbl, alloc, []int64{5, 1, 9}, []string{"zoë", "amy", "amy"})
+
+	stats, err := tbl.ColumnStatistics(ctx, "id", "BIGINT")
+	if err != nil {
+		t.Fatalf("ColumnStatistics failed: %v", err)
+	}
+	if stats.Min != int64(1) || stats.Max != int64(9) {
+		t.Errorf("expected min 1 max 9, got %v %v", stats.Min, stats.Max)
+	}
+	if *stats.HasNull || !*stats.HasNotNull || *stats.DistinctCount != 3 {
+		t.Errorf("unexpected null/distinct stats: %+v", stats)
+	}
+
+	stats, err = tbl.ColumnStatistics(ctx, "name", "VARCHAR")
+	if err != nil {
+		t.Fatalf("ColumnStatistics failed: %v", err)
+	}
+	if stats.Min != "amy" || stats.Max != "zoë" {
+		t.Errorf("expected min 'amy' max 'zoë', got %v %v", stats.Min, stats.Max)
+	}
+	if *stats.DistinctCount != 2 || *stats.MaxStringLength != 3 || !*stats.ContainsUnicode {
+		t.Errorf("unexpected string stats: distinct=%d maxLen=%d unicode=%v",
+			*stats.DistinctCount, *stats.MaxStringLength, *stats.ContainsUnicode)
+	}
+
+	if _, err := tbl.ColumnStatistics(ctx, "missing", "VARCHAR"); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
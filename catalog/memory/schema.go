@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/apache/arrow-go/v18/arrow"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// Schema is an in-memory catalog.DynamicSchema.
+// All methods are goroutine-safe.
+type Schema struct {
+	catalog *Catalog
+	name    string
+	comment string
+
+	mu     sync.RWMutex
+	tables map[string]*Table
+}
+
+// newSchema creates an empty schema owned by cat.
+func newSchema(cat *Catalog, name, comment string) *Schema {
+	return &Schema{
+		catalog: cat,
+		name:    name,
+		comment: comment,
+		tables:  make(map[string]*Table),
+	}
+}
+
+// Name implements catalog.Schema.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// Comment implements catalog.Schema.
+func (s *Schema) Comment() string {
+	return s.comment
+}
+
+// Tables implements catalog.Schema.
+func (s *Schema) Tables(ctx context.Context) ([]catalog.Table, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]catalog.Table, 0, len(s.tables))
+	for _, t := range s.tables {
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// Table implements catalog.Schema.
+func (s *Schema) Table(ctx context.Context, name string) (catalog.Table, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.tables[name]
+	if !ok {
+		return nil, nil // Not found, not an error
+	}
+	return t, nil
+}
+
+// ScalarFunctions implements catalog.Schema.
+// In-memory schemas do not host functions.
+func (s *Schema) ScalarFunctions(ctx context.Context) ([]catalog.ScalarFunction, error) {
+	return []catalog.ScalarFunction{}, nil
+}
+
+// TableFunctions implements catalog.Schema.
+// In-memory schemas do not host functions.
+func (s *Schema) TableFunctions(ctx context.Context) ([]catalog.TableFunction, error) {
+	return []catalog.TableFunction{}, nil
+}
+
+// TableFunctionsInOut implements catalog.Schema.
+// In-memory schemas do not host functions.
+func (s *Schema) TableFunctionsInOut(ctx context.Context) ([]catalog.TableFunctionInOut, error) {
+	return []catalog.TableFunctionInOut{}, nil
+}
+
+// CreateTable implements catalog.DynamicSchema.
+// The schema must not contain a rowid column; the table adds its own.
+// Columns listed in opts.NotNullConstraints are marked non-nullable.
+func (s *Schema) CreateTable(ctx context.Context, name string, schema *arrow.Schema, opts catalog.CreateTableOptions) (catalog.Table, error) {
+	if name == "" {
+		return nil, fmt.Errorf("table name cannot be empty")
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("table %q: schema is required", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.tables[name]; exists {
+		switch opts.OnConflict {
+		case catalog.OnConflictIgnore:
+			return existing, nil
+		case catalog.OnConflictReplace:
+			// Fall through to create new table
+		default: // OnConflictError
+			return nil, fmt.Errorf("table %q: %w", name, catalog.ErrAlreadyExists)
+		}
+	}
+
+	t, err := newTable(s.catalog, name, opts.Comment, schema, opts.NotNullConstraints)
+	if err != nil {
+		return nil, err
+	}
+	if old, exists := s.tables[name]; exists {
+		old.release()
+	}
+	s.tables[name] = t
+	s.catalog.bumpVersion()
+	return t, nil
+}
+
+// DropTable implements catalog.DynamicSchema.
+func (s *Schema) DropTable(ctx context.Context, name string, opts catalog.DropTableOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, exists := s.tables[name]
+	if !exists {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("table %q: %w", name, catalog.ErrNotFound)
+	}
+
+	delete(s.tables, name)
+	t.release()
+	s.catalog.bumpVersion()
+	return nil
+}
+
+// RenameTable implements catalog.DynamicSchema.
+func (s *Schema) RenameTable(ctx context.Context, oldName, newName string, opts catalog.RenameTableOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, exists := s.tables[oldName]
+	if !exists {
+		if opts.IgnoreNotFound {
+			return nil
+		}
+		return fmt.Errorf("table %q: %w", oldName, catalog.ErrNotFound)
+	}
+	if _, exists := s.tables[newName]; exists {
+		return fmt.Errorf("table %q: %w", newName, catalog.ErrAlreadyExists)
+	}
+
+	t.setName(newName)
+	delete(s.tables, oldName)
+	s.tables[newName] = t
+	s.catalog.bumpVersion()
+	return nil
+}
+
+// tableCount returns the number of tables in the schema.
+func (s *Schema) tableCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.tables)
+}
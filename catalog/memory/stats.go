@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// ColumnStatistics implements catalog.StatisticsTable.
+// Statistics are computed exactly from the stored data on every call.
+// Min and Max are reported for numeric, string, boolean, date and timestamp columns.
+func (t *Table) ColumnStatistics(ctx context.Context, columnName string, columnType string) (*catalog.ColumnStats, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	indices := t.schema.FieldIndices(columnName)
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("column %q: %w", columnName, catalog.ErrNotFound)
+	}
+	colIdx := indices[0]
+
+	var (
+		total, nulls int
+		distinct     = make(map[string]struct{})
+		acc          statsAccumulator
+	)
+	for _, b := range t.batches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		col := b.Column(colIdx)
+		total += col.Len()
+		nulls += col.NullN()
+		for i := 0; i < col.Len(); i++ {
+			if col.IsValid(i) {
+				distinct[col.ValueStr(i)] = struct{}{}
+			}
+		}
+		acc.add(col)
+	}
+
+	hasNull := nulls > 0
+	hasNotNull := nulls < total
+	distinctCount := uint64(len(distinct))
+	stats := &catalog.ColumnStats{
+		HasNull:       &hasNull,
+		HasNotNull:    &hasNotNull,
+		DistinctCount: &distinctCount,
+		Min:           acc.min,
+		Max:           acc.max,
+	}
+	if acc.isString {
+		stats.MaxStringLength = &acc.maxLen
+		stats.ContainsUnicode = &acc.unicode
+	}
+	return stats, nil
+}
+
+// statsAccumulator tracks min/max and string statistics across batches.
+type statsAccumulator struct {
+	min, max any
+	isString bool
+	maxLen   uint64
+	unicode  bool
+}
+
+// add folds the values of col into the accumulator.
+func (s *statsAccumulator) add(col arrow.Array) {
+	switch a := col.(type) {
+	case *array.Int8:
+		s.min, s.max = foldMinMax[int8](a, s.min, s.max)
+	case *array.Int16:
+		s.min, s.max = foldMinMax[int16](a, s.min, s.max)
+	case *array.Int32:
+		s.min, s.max = foldMinMax[int32](a, s.min, s.max)
+	case *array.Int64:
+		s.min, s.max = foldMinMax[int64](a, s.min, s.max)
+	case *array.Uint8:
+		s.min, s.max = foldMinMax[uint8](a, s.min, s.max)
+	case *array.Uint16:
+		s.min, s.max = foldMinMax[uint16](a, s.min, s.max)
+	case *array.Uint32:
+		s.min, s.max = foldMinMax[uint32](a, s.min, s.max)
+	case *array.Uint64:
+		s.min, s.max = foldMinMax[uint64](a, s.min, s.max)
+	case *array.Float32:
+		s.min, s.max = foldMinMax[float32](a, s.min, s.max)
+	case *array.Float64:
+		s.min, s.max = foldMinMax[float64](a, s.min, s.max)
+	case *array.Date32:
+		s.min, s.max = foldMinMax[arrow.Date32](a, s.min, s.max)
+	case *array.Timestamp:
+		s.min, s.max = foldMinMax[arrow.Timestamp](a, s.min, s.max)
+	case *array.String:
+		s.isString = true
+		s.min, s.max = foldMinMax[string](a, s.min, s.max)
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				continue
+			}
+			v := a.Value(i)
+			s.maxLen = max(s.maxLen, uint64(utf8.RuneCountInString(v)))
+			if !s.unicode {
+				for j := 0; j < len(v); j++ {
+					if v[j] >= utf8.RuneSelf {
+						s.unicode = true
+						break
+					}
+				}
+			}
+		}
+	case *array.Boolean:
+		for i := 0; i < a.Len(); i++ {
+			if a.IsNull(i) {
+				continue
+			}
+			v := a.Value(i)
+			if s.min == nil || (!v && s.min.(bool)) {
+				s.min = v
+			}
+			if s.max == nil || (v && !s.max.(bool)) {
+				s.max = v
+			}
+		}
+	}
+}
+
+// valueArray is an Arrow array with typed value access.
+type valueArray[T cmp.Ordered] interface {
+	arrow.Array
+	Value(i int) T
+}
+
+// foldMinMax folds the non-null values of a into the current min and max.
+// curMin and curMax are nil until the first value has been seen.
+func foldMinMax[T cmp.Ordered](a valueArray[T], curMin, curMax any) (any, any) {
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) {
+			continue
+		}
+		v := a.Value(i)
+		if curMin == nil || v < curMin.(T) {
+			curMin = v
+		}
+		if curMax == nil || v > curMax.(T) {
+			curMax = v
+		}
+	}
+	return curMin, curMax
+}
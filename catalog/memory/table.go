@@ -0,0 +1,597 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// RowIDColumn is the name of the rowid pseudo-column every in-memory table exposes.
+const RowIDColumn = "rowid"
+
+// Table is an in-memory table supporting DDL, DML and column statistics.
+// Data is stored as Arrow record batches that always include the rowid column
+// at index 0. All methods are goroutine-safe.
+type Table struct {
+	catalog *Catalog
+	alloc   memory.Allocator
+
+	mu        sync.RWMutex
+	name      string
+	comment   string
+	schema    *arrow.Schema
+	batches   []arrow.RecordBatch
+	nextRowID int64
+	defaults  map[string]string
+}
+
+// newTable creates an empty table with the rowid column prepended to schema.
+// notNull lists indices (relative to schema) of columns that cannot be null.
+func newTable(cat *Catalog, name, comment string, schema *arrow.Schema, notNull []uint64) (*Table, error) {
+	if catalog.FindRowIDColumn(schema) >= 0 {
+		return nil, fmt.Errorf("table %q: column %q is reserved for row identifiers", name, RowIDColumn)
+	}
+
+	fields := make([]arrow.Field, 0, schema.NumFields()+1)
+	fields = append(fields, rowIDField())
+	fields = append(fields, schema.Fields()...)
+	for _, idx := range notNull {
+		if idx >= uint64(schema.NumFields()) {
+			return nil, fmt.Errorf("table %q: NOT NULL constraint on unknown column index %d", name, idx)
+		}
+		fields[idx+1].Nullable = false
+	}
+
+	meta := schema.Metadata()
+	return &Table{
+		catalog:   cat,
+		alloc:     cat.alloc,
+		name:      name,
+		comment:   comment,
+		schema:    arrow.NewSchema(fields, &meta),
+		nextRowID: 1,
+		defaults:  make(map[string]string),
+	}, nil
+}
+
+// rowIDField returns the field definition of the rowid pseudo-column.
+func rowIDField() arrow.Field {
+	return arrow.Field{
+		Name:     RowIDColumn,
+		Type:     arrow.PrimitiveTypes.Int64,
+		Nullable: false,
+		Metadata: arrow.NewMetadata([]string{"is_rowid"}, []string{"true"}),
+	}
+}
+
+// Name implements catalog.Table.
+func (t *Table) Name() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.name
+}
+
+// Comment implements catalog.Table.
+func (t *Table) Comment() string {
+	return t.comment
+}
+
+// ArrowSchema implements catalog.Table.
+// The returned schema includes the rowid pseudo-column.
+func (t *Table) ArrowSchema(columns []string) *arrow.Schema {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return catalog.ProjectSchema(t.schema, columns)
+}
+
+// NumRows returns the number of rows currently stored in the table.
+func (t *Table) NumRows() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var n int64
+	for _, b := range t.batches {
+		n += b.NumRows()
+	}
+	return n
+}
+
+// DefaultExpression returns the default value expression recorded for a column by SetDefault.
+func (t *Table) DefaultExpression(column string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	expr, ok := t.defaults[column]
+	return expr, ok
+}
+
+// Scan implements catalog.Table.
+// Returns all rows with the full table schema; options are not applied.
+func (t *Table) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return array.NewRecordReader(t.schema, t.batches)
+}
+
+// Insert implements catalog.InsertableTable.
+// Input columns are matched to table columns by name; missing columns are filled
+// with nulls and differing types are cast to the column type.
+// Rows are only stored if every input batch is accepted.
+func (t *Table) Insert(ctx context.Context, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nextRowID := t.nextRowID
+	var inserted []arrow.RecordBatch
+	release := func() {
+		for _, b := range inserted {
+			b.Release()
+		}
+	}
+
+	var affected int64
+	for rows.Next() {
+		rec, err := t.conformLocked(ctx, rows.RecordBatch(), nextRowID)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		nextRowID += rec.NumRows()
+		affected += rec.NumRows()
+		inserted = append(inserted, rec)
+	}
+	if err := rows.Err(); err != nil {
+		release()
+		return nil, err
+	}
+
+	t.batches = append(t.batches, inserted...)
+	t.nextRowID = nextRowID
+
+	result := &catalog.DMLResult{AffectedRows: affected}
+	if opts != nil && opts.Returning {
+		for _, b := range inserted {
+			b.Retain()
+		}
+		result.ReturningData = t.returningReader(inserted, opts.ReturningColumns)
+	}
+	return result, nil
+}
+
+// Update implements catalog.UpdatableBatchTable.
+// Only the columns present in rows are modified; rowids that do not exist are skipped.
+func (t *Table) Update(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	rowIDIdx := catalog.FindRowIDColumn(rows.Schema())
+	if rowIDIdx < 0 {
+		return nil, fmt.Errorf("update requires a %s column", RowIDColumn)
+	}
+	ids, err := rowIDValues(rows.Column(rowIDIdx))
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	data, err := t.compactLocked()
+	if err != nil {
+		return nil, err
+	}
+	positions, err := rowPositions(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take indices over the concatenation [current values, new values]:
+	// untouched rows keep their own position, updated rows point at their new value.
+	n := data.NumRows()
+	indices := make([]int64, n)
+	for i := range indices {
+		indices[i] = int64(i)
+	}
+	updated := make([]int64, 0, len(ids))
+	for j, id := range ids {
+		p, ok := positions[id]
+		if !ok {
+			continue
+		}
+		if indices[p] == int64(p) {
+			updated = append(updated, int64(p))
+		}
+		indices[p] = n + int64(j)
+	}
+
+	cols := make([]arrow.Array, data.NumCols())
+	for i := range cols {
+		cols[i] = data.Column(i)
+		cols[i].Retain()
+	}
+	defer releaseArrays(cols)
+
+	if len(updated) > 0 {
+		indexArr := t.int64Array(indices)
+		defer indexArr.Release()
+
+		cctx := compute.WithAllocator(ctx, t.alloc)
+		for i := 0; i < int(rows.NumCols()); i++ {
+			if i == rowIDIdx {
+				continue
+			}
+			name := rows.Schema().Field(i).Name
+			colIdx := t.columnIndexLocked(name)
+			if colIdx < 0 {
+				return nil, fmt.Errorf("column %q: %w", name, catalog.ErrNotFound)
+			}
+			merged, err := t.mergeColumn(cctx, colIdx, cols[colIdx], rows.Column(i), indexArr)
+			if err != nil {
+				return nil, err
+			}
+			cols[colIdx].Release()
+			cols[colIdx] = merged
+		}
+		t.replaceDataLocked(t.schema, cols, n)
+	}
+
+	result := &catalog.DMLResult{AffectedRows: int64(len(updated))}
+	if opts != nil && opts.Returning {
+		returning, err := t.takeRowsLocked(ctx, updated)
+		if err != nil {
+			return nil, err
+		}
+		result.ReturningData = t.returningReader([]arrow.RecordBatch{returning}, opts.ReturningColumns)
+	}
+	return result, nil
+}
+
+// Delete implements catalog.DeletableBatchTable.
+// Rowids that do not exist are skipped.
+func (t *Table) Delete(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	rowIDIdx := catalog.FindRowIDColumn(rows.Schema())
+	if rowIDIdx < 0 {
+		return nil, fmt.Errorf("delete requires a %s column", RowIDColumn)
+	}
+	ids, err := rowIDValues(rows.Column(rowIDIdx))
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	data, err := t.compactLocked()
+	if err != nil {
+		return nil, err
+	}
+	positions, err := rowPositions(data)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if p, ok := positions[id]; ok {
+			deleted[p] = true
+		}
+	}
+
+	result := &catalog.DMLResult{AffectedRows: int64(len(deleted))}
+	if len(deleted) == 0 {
+		if opts != nil && opts.Returning {
+			result.ReturningData = t.returningReader(nil, opts.ReturningColumns)
+		}
+		return result, nil
+	}
+
+	kept := make([]int64, 0, int(data.NumRows())-len(deleted))
+	removed := make([]int64, 0, len(deleted))
+	for i := 0; i < int(data.NumRows()); i++ {
+		if deleted[i] {
+			removed = append(removed, int64(i))
+		} else {
+			kept = append(kept, int64(i))
+		}
+	}
+
+	var returning arrow.RecordBatch
+	if opts != nil && opts.Returning {
+		returning, err = t.takeRowsLocked(ctx, removed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	remaining, err := t.takeRowsLocked(ctx, kept)
+	if err != nil {
+		if returning != nil {
+			returning.Release()
+		}
+		return nil, err
+	}
+	t.releaseBatchesLocked()
+	t.batches = []arrow.RecordBatch{remaining}
+
+	if returning != nil {
+		result.ReturningData = t.returningReader([]arrow.RecordBatch{returning}, opts.ReturningColumns)
+	}
+	return result, nil
+}
+
+// setName updates the table name after a rename.
+func (t *Table) setName(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.name = name
+}
+
+// release frees all stored data. Called when the table is dropped or replaced.
+func (t *Table) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.releaseBatchesLocked()
+}
+
+// releaseBatchesLocked releases and clears stored batches. Caller must hold t.mu.
+func (t *Table) releaseBatchesLocked() {
+	for _, b := range t.batches {
+		b.Release()
+	}
+	t.batches = nil
+}
+
+// columnIndexLocked returns the schema index of a user column, or -1.
+// The rowid column is never returned. Caller must hold t.mu.
+func (t *Table) columnIndexLocked(name string) int {
+	for i := 1; i < t.schema.NumFields(); i++ {
+		if t.schema.Field(i).Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// compactLocked merges stored batches into a single batch and returns it.
+// The returned batch is owned by the table. Caller must hold the write lock.
+func (t *Table) compactLocked() (arrow.RecordBatch, error) {
+	if len(t.batches) == 1 {
+		return t.batches[0], nil
+	}
+
+	var n int64
+	for _, b := range t.batches {
+		n += b.NumRows()
+	}
+
+	cols := make([]arrow.Array, t.schema.NumFields())
+	defer releaseArrays(cols)
+	for i, f := range t.schema.Fields() {
+		if len(t.batches) == 0 {
+			cols[i] = array.MakeArrayOfNull(t.alloc, f.Type, 0)
+			continue
+		}
+		parts := make([]arrow.Array, len(t.batches))
+		for j, b := range t.batches {
+			parts[j] = b.Column(i)
+		}
+		col, err := array.Concatenate(parts, t.alloc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compact column %q: %w", f.Name, err)
+		}
+		cols[i] = col
+	}
+
+	t.replaceDataLocked(t.schema, cols, n)
+	return t.batches[0], nil
+}
+
+// replaceDataLocked replaces the table schema and data with a single batch built from cols.
+// The caller keeps ownership of cols. Caller must hold the write lock.
+func (t *Table) replaceDataLocked(schema *arrow.Schema, cols []arrow.Array, n int64) {
+	rec := array.NewRecordBatch(schema, cols, n)
+	t.releaseBatchesLocked()
+	t.schema = schema
+	t.batches = []arrow.RecordBatch{rec}
+}
+
+// conformLocked converts an input batch to the table layout, assigning rowids from firstRowID.
+func (t *Table) conformLocked(ctx context.Context, batch arrow.RecordBatch, firstRowID int64) (arrow.RecordBatch, error) {
+	n := batch.NumRows()
+	inputSchema := batch.Schema()
+	rowIDIdx := catalog.FindRowIDColumn(inputSchema)
+	for i := 0; i < inputSchema.NumFields(); i++ {
+		name := inputSchema.Field(i).Name
+		if i == rowIDIdx {
+			continue
+		}
+		if t.columnIndexLocked(name) < 0 {
+			return nil, fmt.Errorf("column %q: %w", name, catalog.ErrNotFound)
+		}
+	}
+
+	cols := make([]arrow.Array, t.schema.NumFields())
+	defer releaseArrays(cols)
+
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = firstRowID + int64(i)
+	}
+	cols[0] = t.int64Array(ids)
+
+	cctx := compute.WithAllocator(ctx, t.alloc)
+	for i := 1; i < t.schema.NumFields(); i++ {
+		f := t.schema.Field(i)
+		indices := inputSchema.FieldIndices(f.Name)
+		if len(indices) == 0 {
+			cols[i] = array.MakeArrayOfNull(t.alloc, f.Type, int(n))
+		} else {
+			col, err := castColumn(cctx, f, batch.Column(indices[0]))
+			if err != nil {
+				return nil, err
+			}
+			cols[i] = col
+		}
+		if !f.Nullable && cols[i].NullN() > 0 {
+			return nil, fmt.Errorf("column %q: NULL value violates NOT NULL constraint", f.Name)
+		}
+	}
+
+	return array.NewRecordBatch(t.schema, cols, n), nil
+}
+
+// mergeColumn returns the column at colIdx with values replaced according to indices,
+// which address the concatenation of current and replacement values.
+func (t *Table) mergeColumn(ctx context.Context, colIdx int, current, values arrow.Array, indices arrow.Array) (arrow.Array, error) {
+	f := t.schema.Field(colIdx)
+	values, err := castColumn(ctx, f, values)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+
+	combined, err := array.Concatenate([]arrow.Array{current, values}, t.alloc)
+	if err != nil {
+		return nil, fmt.Errorf("column %q: %w", f.Name, err)
+	}
+	defer combined.Release()
+
+	merged, err := compute.TakeArray(ctx, combined, indices)
+	if err != nil {
+		return nil, fmt.Errorf("column %q: %w", f.Name, err)
+	}
+	if !f.Nullable && merged.NullN() > 0 {
+		merged.Release()
+		return nil, fmt.Errorf("column %q: NULL value violates NOT NULL constraint", f.Name)
+	}
+	return merged, nil
+}
+
+// takeRowsLocked returns a new batch holding the rows at the given positions
+// of the (compacted) table data. Caller must hold the write lock.
+func (t *Table) takeRowsLocked(ctx context.Context, positions []int64) (arrow.RecordBatch, error) {
+	data, err := t.compactLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	indices := t.int64Array(positions)
+	defer indices.Release()
+
+	cctx := compute.WithAllocator(ctx, t.alloc)
+	cols := make([]arrow.Array, data.NumCols())
+	defer releaseArrays(cols)
+	for i := range cols {
+		col, err := compute.TakeArray(cctx, data.Column(i), indices)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", t.schema.Field(i).Name, err)
+		}
+		cols[i] = col
+	}
+	return array.NewRecordBatch(t.schema, cols, int64(len(positions))), nil
+}
+
+// returningReader builds the RETURNING reader for DML results, projecting
+// batches to columns (all non-rowid columns when empty).
+// Takes ownership of batches.
+func (t *Table) returningReader(batches []arrow.RecordBatch, columns []string) array.RecordReader {
+	if len(columns) == 0 {
+		columns = make([]string, 0, t.schema.NumFields()-1)
+		for _, f := range t.schema.Fields()[1:] {
+			columns = append(columns, f.Name)
+		}
+	}
+	schema := catalog.ProjectSchema(t.schema, columns)
+
+	projected := make([]arrow.RecordBatch, 0, len(batches))
+	for _, b := range batches {
+		cols := make([]arrow.Array, schema.NumFields())
+		for i, f := range schema.Fields() {
+			cols[i] = b.Column(b.Schema().FieldIndices(f.Name)[0])
+		}
+		projected = append(projected, array.NewRecordBatch(schema, cols, b.NumRows()))
+		b.Release()
+	}
+
+	reader, _ := array.NewRecordReader(schema, projected)
+	for _, b := range projected {
+		b.Release()
+	}
+	return reader
+}
+
+// int64Array builds an Int64 array from values.
+func (t *Table) int64Array(values []int64) arrow.Array {
+	b := array.NewInt64Builder(t.alloc)
+	defer b.Release()
+	b.AppendValues(values, nil)
+	return b.NewArray()
+}
+
+// castColumn returns arr converted to the type of field f.
+// The result is a new reference owned by the caller.
+func castColumn(ctx context.Context, f arrow.Field, arr arrow.Array) (arrow.Array, error) {
+	if arrow.TypeEqual(arr.DataType(), f.Type) {
+		arr.Retain()
+		return arr, nil
+	}
+	out, err := compute.CastToType(ctx, arr, f.Type)
+	if err != nil {
+		return nil, fmt.Errorf("column %q: cannot convert %s to %s: %w", f.Name, arr.DataType(), f.Type, err)
+	}
+	return out, nil
+}
+
+// rowPositions maps rowid values to row positions in data.
+func rowPositions(data arrow.RecordBatch) (map[int64]int, error) {
+	ids, err := rowIDValues(data.Column(0))
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[int64]int, len(ids))
+	for i, id := range ids {
+		positions[id] = i
+	}
+	return positions, nil
+}
+
+// rowIDValues extracts rowid values from an integer array.
+// Returns catalog.ErrNullRowID if any value is null.
+func rowIDValues(arr arrow.Array) ([]int64, error) {
+	if arr.NullN() > 0 {
+		return nil, catalog.ErrNullRowID
+	}
+	ids := make([]int64, arr.Len())
+	switch a := arr.(type) {
+	case *array.Int64:
+		copy(ids, a.Int64Values())
+	case *array.Int32:
+		for i := range ids {
+			ids[i] = int64(a.Value(i))
+		}
+	case *array.Uint64:
+		for i := range ids {
+			ids[i] = int64(a.Value(i))
+		}
+	default:
+		return nil, fmt.Errorf("%s column must be Int64, Int32, or Uint64, got %s", RowIDColumn, arr.DataType())
+	}
+	return ids, nil
+}
+
+// releaseArrays releases all non-nil arrays in arrs.
+func releaseArrays(arrs []arrow.Array) {
+	for _, a := range arrs {
+		if a != nil {
+			a.Release()
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/metadata"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// fileExt is the extension of the files exposed as tables.
+const fileExt = ".parquet"
+
+// Catalog is a read-only catalog.Catalog over a directory tree of Parquet files.
+// Every subdirectory of the root directory is a schema; see the package
+// documentation for how tables are discovered inside a schema directory.
+// The directory tree is listed on every lookup, so files added or removed
+// while the server runs are picked up; parsed file footers are cached.
+// All methods are goroutine-safe.
+type Catalog struct {
+	name  string
+	root  string
+	alloc memory.Allocator
+
+	mu      sync.Mutex
+	footers map[string]*footer
+}
+
+// NewCatalog creates a catalog that serves the Parquet files below root.
+// The name is used for routing in multi-catalog servers and may be empty.
+// Returns an error if root is not a directory.
+func NewCatalog(name, root string) (*Catalog, error) {
+	return NewCatalogWithAllocator(name, root, memory.DefaultAllocator)
+}
+
+// NewCatalogWithAllocator creates a catalog that serves the Parquet files below
+// root and uses alloc for all Arrow buffers it creates.
+func NewCatalogWithAllocator(name, root string, alloc memory.Allocator) (*Catalog, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("parquet catalog root: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("parquet catalog root %q is not a directory", root)
+	}
+	if alloc == nil {
+		alloc = memory.DefaultAllocator
+	}
+	return &Catalog{
+		name:    name,
+		root:    root,
+		alloc:   alloc,
+		footers: make(map[string]*footer),
+	}, nil
+}
+
+// Name implements catalog.NamedCatalog.
+func (c *Catalog) Name() string {
+	return c.name
+}
+
+// Schemas implements catalog.Catalog.
+// Returns one schema per subdirectory of the root directory.
+func (c *Catalog) Schemas(ctx context.Context) ([]catalog.Schema, error) {
+	entries, err := os.ReadDir(c.root)
+	if err != nil {
+		return nil, fmt.Errorf("list schemas: %w", err)
+	}
+
+	result := make([]catalog.Schema, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() && !hidden(e.Name()) {
+			result = append(result, newSchema(c, e.Name()))
+		}
+	}
+	return result, nil
+}
+
+// Schema implements catalog.Catalog.
+func (c *Catalog) Schema(ctx context.Context, name string) (catalog.Schema, error) {
+	if !validName(name) {
+		return nil, nil // Not found, not an error
+	}
+	info, err := os.Stat(filepath.Join(c.root, name))
+	if err != nil || !info.IsDir() {
+		return nil, nil // Not found, not an error
+	}
+	return newSchema(c, name), nil
+}
+
+// footer is the parsed metadata of a Parquet file.
+type footer struct {
+	size    int64
+	modTime time.Time
+
+	meta   *metadata.FileMetaData
+	schema *arrow.Schema
+	// fields maps the top-level schema fields to Parquet leaf columns.
+	fields []pqarrow.SchemaField
+}
+
+// footer returns the parsed footer of the file at path.
+// Footers are cached until the size or modification time of the file changes.
+func (c *Catalog) footer(path string) (*footer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	f, ok := c.footers[path]
+	c.mu.Unlock()
+	if ok && f.size == info.Size() && f.modTime.Equal(info.ModTime()) {
+		return f, nil
+	}
+
+	rdr, err := file.OpenParquetFile(path, false)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, c.alloc)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := fr.Schema()
+	if err != nil {
+		return nil, err
+	}
+
+	f = &footer{
+		size:    info.Size(),
+		modTime: info.ModTime(),
+		meta:    rdr.MetaData(),
+		schema:  schema,
+		fields:  fr.Manifest.Fields,
+	}
+
+	c.mu.Lock()
+	c.footers[path] = f
+	c.mu.Unlock()
+	return f, nil
+}
+
+// hidden reports whether a directory entry is skipped during discovery.
+// Names starting with "." or "_" are metadata of other tools (e.g. _SUCCESS).
+func hidden(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// validName reports whether name can be used as a schema or table name.
+func validName(name string) bool {
+	return name != "" && !hidden(name) && !strings.ContainsAny(name, `/\`) && name != ".."
+}
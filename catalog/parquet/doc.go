@@ -0,0 +1,59 @@
+// Package parquet provides a read-only catalog over a directory tree of Parquet files.
+//
+// The catalog maps the directory layout to Airport objects:
+//   - every subdirectory of the root directory is a schema
+//   - every "*.parquet" file in a schema directory is a table named after the file
+//   - every subdirectory of a schema directory is a table made of all Parquet files
+//     below it; "key=value" directories on the way (hive partitioning) become
+//     extra columns of the table
+//
+// Files and directories starting with "." or "_" are ignored. Catalog implements
+// catalog.Catalog and catalog.NamedCatalog, Schema implements catalog.Schema and
+// Table implements catalog.PartitionedTable and catalog.StatisticsTable.
+// Data is read through the arrow-go pqarrow package.
+//
+// # Basic Usage
+//
+//	cat, err := parquet.NewCatalog("lake", "/data/lake")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	err = airport.NewServer(grpcServer, airport.ServerConfig{Catalog: cat})
+//
+// With the layout
+//
+//	/data/lake/sales/orders.parquet
+//	/data/lake/sales/events/year=2024/month=1/part-0.parquet
+//	/data/lake/sales/events/year=2024/month=2/part-0.parquet
+//
+// DuckDB sees the tables sales.orders and sales.events; events has the columns of
+// its files followed by the BIGINT columns year and month.
+//
+// # Scans
+//
+// Only the columns listed in ScanOptions.Columns are read from the files; the other
+// columns of the returned records are null. The parsed ScanOptions.Filter is checked
+// against the hive partition values and the min/max and null count statistics of
+// each row group, and files and row groups that cannot hold matching rows are
+// skipped. Comparisons of a column with a constant, BETWEEN, IN, IS NULL and
+// IS NOT NULL, combined with AND and OR, are used for pruning; other expressions
+// keep the data. Rows of the remaining row groups are returned unfiltered, because
+// DuckDB re-applies the filter.
+//
+// Every data file is a separate partition, so DuckDB may fetch the files of a
+// table over parallel streams.
+//
+// # Partition Columns
+//
+// Partition values are URL-unescaped. A partition column is BIGINT if all of its
+// values are integers and VARCHAR otherwise; "__HIVE_DEFAULT_PARTITION__" is NULL.
+// All files of a table must have the same partition keys, in the same order, and
+// the same file columns.
+//
+// # Limitations
+//
+// The catalog is read-only and does not support time travel. Pruning uses only
+// top-level primitive columns. Table lookups read the footers of new or changed
+// files, which may be slow for directories with many files.
+package parquet
@@ -0,0 +1,474 @@
+package parquet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// Compile-time interface checks.
+var (
+	_ catalog.Catalog          = (*Catalog)(nil)
+	_ catalog.NamedCatalog     = (*Catalog)(nil)
+	_ catalog.Schema           = (*Schema)(nil)
+	_ catalog.PartitionedTable = (*Table)(nil)
+	_ catalog.StatisticsTable  = (*Table)(nil)
+)
+
+var usersSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
+	{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+// writeUsers writes (id, "user-<id>") rows for ids [from, to) to a Parquet file
+// with rowGroupSize rows per row group.
+func writeUsers(t *testing.T, path string, from, to int64, rowGroupSize int64) {
+	t.Helper()
+
+	b := array.NewRecordBuilder(memory.DefaultAllocator, usersSchema)
+	defer b.Release()
+	for id := from; id < to; id++ {
+		b.Field(0).(*array.Int64Builder).Append(id)
+		b.Field(1).(*array.StringBuilder).Append(fmt.Sprintf("user-%d", id))
+	}
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+	tbl := array.NewTableFromRecords(usersSchema, []arrow.RecordBatch{rec})
+	defer tbl.Release()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := pqarrow.WriteTable(tbl, f, rowGroupSize, nil, pqarrow.DefaultWriterProps()); err != nil {
+		t.Fatalf("WriteTable failed: %v", err)
+	}
+}
+
+// newTestCatalog creates the layout
+//
+//	sales/orders.parquet                              ids 0..39, 4 row groups
+//	sales/events/year=2024/month=1/part-0.parquet     ids 0..9
+//	sales/events/year=2024/month=2/part-0.parquet     ids 10..19
+//	sales/_tmp/ignored.parquet
+//	empty/
+func newTestCatalog(t *testing.T, alloc memory.Allocator) *Catalog {
+	t.Helper()
+
+	root := t.TempDir()
+	writeUsers(t, filepath.Join(root, "sales", "orders.parquet"), 0, 40, 10)
+	writeUsers(t, filepath.Join(root, "sales", "events", "year=2024", "month=1", "part-0.parquet"), 0, 10, 10)
+	writeUsers(t, filepath.Join(root, "sales", "events", "year=2024", "month=2", "part-0.parquet"), 10, 20, 10)
+	writeUsers(t, filepath.Join(root, "sales", "_tmp", "ignored.parquet"), 0, 1, 10)
+	if err := os.MkdirAll(filepath.Join(root, "empty"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cat, err := NewCatalogWithAllocator("lake", root, alloc)
+	if err != nil {
+		t.Fatalf("NewCatalog failed: %v", err)
+	}
+	return cat
+}
+
+// getTable looks up a table of the test catalog.
+func getTable(t *testing.T, cat *Catalog, schema, name string) *Table {
+	t.Helper()
+	ctx := context.Background()
+
+	sc, err := cat.Schema(ctx, schema)
+	if err != nil || sc == nil {
+		t.Fatalf("Schema(%q) = %v, %v", schema, sc, err)
+	}
+	tbl, err := sc.Table(ctx, name)
+	if err != nil || tbl == nil {
+		t.Fatalf("Table(%q) = %v, %v", name, tbl, err)
+	}
+	return tbl.(*Table)
+}
+
+// scanIDs scans tbl and returns the non-null values of the id column.
+func scanIDs(t *testing.T, tbl *Table, opts *catalog.ScanOptions) []int64 {
+	t.Helper()
+
+	reader, err := tbl.Scan(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	defer reader.Release()
+
+	if !reader.Schema().Equal(tbl.ArrowSchema(nil)) {
+		t.Fatalf("reader schema %v, want %v", reader.Schema(), tbl.ArrowSchema(nil))
+	}
+	var ids []int64
+	for reader.Next() {
+		col := reader.RecordBatch().Column(0).(*array.Int64)
+		for i := 0; i < col.Len(); i++ {
+			if col.IsValid(i) {
+				ids = append(ids, col.Value(i))
+			}
+		}
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("reader error: %v", err)
+	}
+	return ids
+}
+
+// columnRef returns the JSON of a BIGINT or VARCHAR column reference.
+func columnRef(index int, typ string) string {
+	return fmt.Sprintf(`{"expression_class": "BOUND_COLUMN_REF", "type": "BOUND_COLUMN_REF", "alias": "",
+		"return_type": {"id": %q}, "binding": {"table_index": 0, "column_index": %d}, "depth": 0}`, typ, index)
+}
+
+// constant returns the JSON of a constant of the given type.
+func constant(typ string, value any) string {
+	data := fmt.Sprint(value)
+	if s, ok := value.(string); ok {
+		data = fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf(`{"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "alias": "",
+		"value": {"type": {"id": %q}, "is_null": false, "value": %s}}`, typ, data)
+}
+
+// comparison returns the JSON of a binary comparison.
+func comparison(typ, left, right string) string {
+	return fmt.Sprintf(`{"expression_class": "BOUND_COMPARISON", "type": %q, "alias": "", "left": %s, "right": %s}`,
+		typ, left, right)
+}
+
+// conjunction returns the JSON of an AND or OR conjunction.
+func conjunction(typ string, children ...string) string {
+	return fmt.Sprintf(`{"expression_class": "BOUND_CONJUNCTION", "type": %q, "alias": "", "children": [%s]}`,
+		typ, strings.Join(children, ", "))
+}
+
+// operator returns the JSON of an operator expression such as IS NULL or IN.
+func operator(typ string, children ...string) string {
+	return fmt.Sprintf(`{"expression_class": "BOUND_OPERATOR", "type": %q, "alias": "",
+		"return_type": {"id": "BOOLEAN"}, "children": [%s]}`, typ, strings.Join(children, ", "))
+}
+
+// filterJSON returns a filter pushdown document over the given columns.
+func filterJSON(columns []string, filters ...string) []byte {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = fmt.Sprintf("%q", c)
+	}
+	return []byte(fmt.Sprintf(`{"filters": [%s], "column_binding_names_by_index": [%s]}`,
+		strings.Join(filters, ", "), strings.Join(names, ", ")))
+}
+
+func TestCatalogDiscovery(t *testing.T) {
+	ctx := context.Background()
+	cat := newTestCatalog(t, memory.DefaultAllocator)
+
+	if _, err := NewCatalog("x", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("NewCatalog should fail for a missing root")
+	}
+
+	schemas, err := cat.Schemas(ctx)
+	if err != nil {
+		t.Fatalf("Schemas failed: %v", err)
+	}
+	var names []string
+	for _, s := range schemas {
+		names = append(names, s.Name())
+	}
+	if got := strings.Join(names, ","); got != "empty,sales" {
+		t.Errorf("schemas = %s, want empty,sales", got)
+	}
+
+	for _, name := range []string{"missing", "..", "_tmp", "sales/events"} {
+		if sc, err := cat.Schema(ctx, name); err != nil || sc != nil {
+			t.Errorf("Schema(%q) = %v, %v; want nil, nil", name, sc, err)
+		}
+	}
+
+	sc, _ := cat.Schema(ctx, "sales")
+	tables, err := sc.Tables(ctx)
+	if err != nil {
+		t.Fatalf("Tables failed: %v", err)
+	}
+	names = nil
+	for _, tbl := range tables {
+		names = append(names, tbl.Name())
+	}
+	if got := strings.Join(names, ","); got != "events,orders" {
+		t.Errorf("tables = %s, want events,orders", got)
+	}
+	if tbl, err := sc.Table(ctx, "_tmp"); err != nil || tbl != nil {
+		t.Errorf("Table(_tmp) = %v, %v; want nil, nil", tbl, err)
+	}
+
+	events := getTable(t, cat, "sales", "events")
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "year", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "month", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	}, nil)
+	if !sameColumns(events.ArrowSchema(nil), want) {
+		t.Errorf("events schema = %v, want %v", events.ArrowSchema(nil), want)
+	}
+	if n := events.NumRows(); n != 20 {
+		t.Errorf("events NumRows = %d, want 20", n)
+	}
+}
+
+func TestCatalogPartitionTypes(t *testing.T) {
+	root := t.TempDir()
+	writeUsers(t, filepath.Join(root, "s", "t", "region=eu%20west", "part-0.parquet"), 0, 5, 10)
+	writeUsers(t, filepath.Join(root, "s", "t", "region=__HIVE_DEFAULT_PARTITION__", "part-0.parquet"), 5, 10, 10)
+	writeUsers(t, filepath.Join(root, "s", "bad", "a=1", "part-0.parquet"), 0, 5, 10)
+	writeUsers(t, filepath.Join(root, "s", "bad", "b=1", "part-0.parquet"), 0, 5, 10)
+
+	cat, err := NewCatalog("", root)
+	if err != nil {
+		t.Fatalf("NewCatalog failed: %v", err)
+	}
+	tbl := getTable(t, cat, "s", "t")
+
+	field, _ := tbl.ArrowSchema(nil).FieldsByName("region")
+	if len(field) != 1 || !arrow.TypeEqual(field[0].Type, arrow.BinaryTypes.String) {
+		t.Fatalf("region field = %v, want VARCHAR", field)
+	}
+
+	reader, err := tbl.Scan(context.Background(), &catalog.ScanOptions{Columns: []string{"region"}})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	defer reader.Release()
+	var values []string
+	for reader.Next() {
+		col := reader.RecordBatch().Column(2)
+		for i := 0; i < col.Len(); i++ {
+			values = append(values, col.ValueStr(i))
+		}
+	}
+	if got := strings.Join(values, ","); got != "(null),(null),(null),(null),(null),eu west,eu west,eu west,eu west,eu west" {
+		t.Errorf("region values = %s", got)
+	}
+
+	sc, _ := cat.Schema(context.Background(), "s")
+	if _, err := sc.Table(context.Background(), "bad"); err == nil {
+		t.Error("Table(bad) should fail for mismatched partition keys")
+	}
+}
+
+func TestScanProjection(t *testing.T) {
+	alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer alloc.AssertSize(t, 0)
+
+	cat := newTestCatalog(t, alloc)
+	orders := getTable(t, cat, "sales", "orders")
+
+	if got := scanIDs(t, orders, &catalog.ScanOptions{}); len(got) != 40 {
+		t.Errorf("full scan returned %d ids, want 40", len(got))
+	}
+
+	// Only name is read; id is null.
+	reader, err := orders.Scan(context.Background(), &catalog.ScanOptions{Columns: []string{"name"}, BatchSize: 8})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	var rows int64
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		if rec.NumRows() > 8 {
+			t.Errorf("batch of %d rows, want at most 8", rec.NumRows())
+		}
+		if rec.Column(0).NullN() != int(rec.NumRows()) {
+			t.Errorf("id column should be null when not projected")
+		}
+		if got, want := rec.Column(1).ValueStr(0), fmt.Sprintf("user-%d", rows); got != want {
+			t.Errorf("name = %s, want %s", got, want)
+		}
+		rows += rec.NumRows()
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("reader error: %v", err)
+	}
+	reader.Release()
+	if rows != 40 {
+		t.Errorf("projected scan returned %d rows, want 40", rows)
+	}
+
+	// Only partition columns: rows are counted from the footers.
+	events := getTable(t, cat, "sales", "events")
+	reader, err = events.Scan(context.Background(), &catalog.ScanOptions{Columns: []string{"month"}})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	var months []string
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		months = append(months, fmt.Sprintf("%d:%s", rec.NumRows(), rec.Column(3).ValueStr(0)))
+		if rec.Column(0).NullN() != int(rec.NumRows()) || rec.Column(2).NullN() != int(rec.NumRows()) {
+			t.Errorf("unprojected columns should be null")
+		}
+	}
+	reader.Release()
+	if got := strings.Join(months, ","); got != "10:1,10:2" {
+		t.Errorf("month batches = %s, want 10:1,10:2", got)
+	}
+}
+
+func TestScanRowGroupPruning(t *testing.T) {
+	alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer alloc.AssertSize(t, 0)
+
+	cat := newTestCatalog(t, alloc)
+	orders := getTable(t, cat, "sales", "orders")
+	columns := []string{"id", "name"}
+	id := columnRef(0, "BIGINT")
+	name := columnRef(1, "VARCHAR")
+
+	tests := []struct {
+		name   string
+		filter []byte
+		rows   int
+	}{
+		{"greater", filterJSON(columns, comparison("COMPARE_GREATERTHANOREQUALTO", id, constant("BIGINT", 25))), 20},
+		{"flipped", filterJSON(columns, comparison("COMPARE_GREATERTHAN", constant("BIGINT", 10), id)), 10},
+		{"equal", filterJSON(columns, comparison("COMPARE_EQUAL", id, constant("BIGINT", 15))), 10},
+		{"out of range", filterJSON(columns, comparison("COMPARE_LESSTHAN", id, constant("BIGINT", 0))), 0},
+		{"string", filterJSON(columns, comparison("COMPARE_EQUAL", name, constant("VARCHAR", "user-7"))), 10},
+		{"or", filterJSON(columns, conjunction("CONJUNCTION_OR",
+			comparison("COMPARE_EQUAL", id, constant("BIGINT", 5)),
+			comparison("COMPARE_EQUAL", id, constant("BIGINT", 35)))), 20},
+		{"and", filterJSON(columns, conjunction("CONJUNCTION_AND",
+			comparison("COMPARE_GREATERTHAN", id, constant("BIGINT", 5)),
+			comparison("COMPARE_LESSTHAN", id, constant("BIGINT", 15)))), 20},
+		{"multiple filters", filterJSON(columns,
+			comparison("COMPARE_GREATERTHANOREQUALTO", id, constant("BIGINT", 10)),
+			comparison("COMPARE_LESSTHANOREQUALTO", id, constant("BIGINT", 19))), 10},
+		{"in", filterJSON(columns, operator("COMPARE_IN", id, constant("BIGINT", 3), constant("BIGINT", 33))), 20},
+		{"is null", filterJSON(columns, operator("OPERATOR_IS_NULL", name)), 0},
+		{"is not null", filterJSON(columns, operator("OPERATOR_IS_NOT_NULL", name)), 40},
+		{"distinct from", filterJSON(columns, comparison("COMPARE_DISTINCT_FROM", id, constant("BIGINT", 1000))), 40},
+		{"incomparable", filterJSON(columns, comparison("COMPARE_EQUAL", id, constant("VARCHAR", "x"))), 40},
+		{"unknown column", filterJSON([]string{"other"}, comparison("COMPARE_EQUAL", columnRef(0, "BIGINT"), constant("BIGINT", 1000))), 40},
+		{"invalid", []byte("{"), 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanIDs(t, orders, &catalog.ScanOptions{Filter: tt.filter}); len(got) != tt.rows {
+				t.Errorf("scan returned %d rows, want %d", len(got), tt.rows)
+			}
+		})
+	}
+}
+
+func TestScanLimit(t *testing.T) {
+	cat := newTestCatalog(t, memory.DefaultAllocator)
+	orders := getTable(t, cat, "sales", "orders")
+
+	if got := scanIDs(t, orders, &catalog.ScanOptions{Limit: 15}); len(got) != 20 {
+		t.Errorf("limited scan returned %d rows, want the 20 rows of 2 row groups", len(got))
+	}
+
+	filter := filterJSON([]string{"id"}, comparison("COMPARE_GREATERTHANOREQUALTO", columnRef(0, "BIGINT"), constant("BIGINT", 0)))
+	if got := scanIDs(t, orders, &catalog.ScanOptions{Limit: 15, Filter: filter}); len(got) != 40 {
+		t.Errorf("limited filtered scan returned %d rows, want 40", len(got))
+	}
+}
+
+func TestScanPartitions(t *testing.T) {
+	ctx := context.Background()
+	cat := newTestCatalog(t, memory.DefaultAllocator)
+	events := getTable(t, cat, "sales", "events")
+
+	parts, err := events.Partitions(ctx, &catalog.ScanOptions{})
+	if err != nil {
+		t.Fatalf("Partitions failed: %v", err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("got %d partitions, want 2", len(parts))
+	}
+	var total int
+	for _, p := range parts {
+		ids := scanIDs(t, events, &catalog.ScanOptions{Partition: p})
+		if len(ids) != 10 {
+			t.Errorf("partition %s returned %d rows, want 10", p, len(ids))
+		}
+		total += len(ids)
+	}
+	if total != 20 {
+		t.Errorf("partitions returned %d rows, want 20", total)
+	}
+
+	if _, err := events.Scan(ctx, &catalog.ScanOptions{Partition: []byte("missing.parquet")}); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("Scan of unknown partition: err = %v, want ErrNotFound", err)
+	}
+
+	// Files of other months are pruned by their partition values.
+	columns := []string{"id", "name", "year", "month"}
+	filter := filterJSON(columns, comparison("COMPARE_EQUAL", columnRef(3, "BIGINT"), constant("BIGINT", 2)))
+	parts, err = events.Partitions(ctx, &catalog.ScanOptions{Filter: filter})
+	if err != nil || parts != nil {
+		t.Errorf("Partitions with filter = %v, %v; want nil, nil", parts, err)
+	}
+	ids := scanIDs(t, events, &catalog.ScanOptions{Filter: filter})
+	if len(ids) != 10 || ids[0] != 10 {
+		t.Errorf("month = 2 returned ids %v, want 10..19", ids)
+	}
+
+	filter = filterJSON(columns, operator("OPERATOR_IS_NULL", columnRef(2, "BIGINT")))
+	if ids := scanIDs(t, events, &catalog.ScanOptions{Filter: filter}); len(ids) != 0 {
+		t.Errorf("year IS NULL returned %d rows, want 0", len(ids))
+	}
+}
+
+func TestColumnStatistics(t *testing.T) {
+	ctx := context.Background()
+	cat := newTestCatalog(t, memory.DefaultAllocator)
+	events := getTable(t, cat, "sales", "events")
+
+	stats, err := events.ColumnStatistics(ctx, "id", "BIGINT")
+	if err != nil {
+		t.Fatalf("ColumnStatistics failed: %v", err)
+	}
+	if stats.Min != int64(0) || stats.Max != int64(19) {
+		t.Errorf("id min/max = %v/%v, want 0/19", stats.Min, stats.Max)
+	}
+	if stats.HasNull == nil || *stats.HasNull || stats.HasNotNull == nil || !*stats.HasNotNull {
+		t.Errorf("id null flags = %v/%v, want false/true", stats.HasNull, stats.HasNotNull)
+	}
+
+	stats, err = events.ColumnStatistics(ctx, "name", "VARCHAR")
+	if err != nil {
+		t.Fatalf("ColumnStatistics failed: %v", err)
+	}
+	if stats.Min != "user-0" || stats.Max != "user-9" {
+		t.Errorf("name min/max = %v/%v, want user-0/user-9", stats.Min, stats.Max)
+	}
+
+	stats, err = events.ColumnStatistics(ctx, "month", "BIGINT")
+	if err != nil {
+		t.Fatalf("ColumnStatistics failed: %v", err)
+	}
+	if stats.Min != int64(1) || stats.Max != int64(2) || stats.DistinctCount == nil || *stats.DistinctCount != 2 {
+		t.Errorf("month stats = %+v, want min 1, max 2, 2 distinct", stats)
+	}
+
+	if _, err := events.ColumnStatistics(ctx, "missing", "BIGINT"); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("missing column: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,533 @@
+package parquet
+
+import (
+	"cmp"
+	"math"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/parquet/metadata"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+// valueRange describes the values of a column in a row group or a file.
+type valueRange struct {
+	// min and max bound the non-null values; nil when unknown.
+	// Values are int64, uint64, float64, string, bool or time.Time.
+	min, max any
+	// noNulls and allNull are set when the column is known to hold no nulls,
+	// or only nulls.
+	noNulls, allNull bool
+}
+
+// rangeLookup returns the value range of a column, or false if it is unknown.
+type rangeLookup func(column string) (valueRange, bool)
+
+// pruner decides from value ranges whether a row group or file may hold rows
+// matching a filter. It only answers "no" when no row can match; anything it
+// does not understand keeps the data, because DuckDB re-applies the filter.
+type pruner struct {
+	fp *filter.FilterPushdown
+}
+
+// newPruner parses a ScanOptions.Filter.
+// Returns nil, which keeps all data, if there is no filter or it cannot be parsed.
+func newPruner(data []byte) *pruner {
+	if len(data) == 0 {
+		return nil
+	}
+	fp, err := filter.Parse(data)
+	if err != nil || len(fp.Filters) == 0 {
+		return nil
+	}
+	return &pruner{fp: fp}
+}
+
+// mayMatch reports whether rows with the given column ranges may match every filter.
+func (p *pruner) mayMatch(lookup rangeLookup) bool {
+	if p == nil {
+		return true
+	}
+	for _, f := range p.fp.Filters {
+		if !p.eval(f, lookup) {
+			return false
+		}
+	}
+	return true
+}
+
+// eval reports whether expr may be true for some row.
+func (p *pruner) eval(expr filter.Expression, lookup rangeLookup) bool {
+	switch e := expr.(type) {
+	case *filter.ConjunctionExpression:
+		switch e.Type() {
+		case filter.TypeConjunctionAnd:
+			for _, child := range e.Children {
+				if !p.eval(child, lookup) {
+					return false
+				}
+			}
+			return true
+		case filter.TypeConjunctionOr:
+			for _, child := range e.Children {
+				if p.eval(child, lookup) {
+					return true
+				}
+			}
+			return len(e.Children) == 0
+		}
+
+	case *filter.ComparisonExpression:
+		if e.Type() == filter.TypeCompareIn {
+			if list, ok := e.Right.(*filter.FunctionExpression); ok {
+				return p.evalIn(e.Left, list.Children, lookup)
+			}
+			return true
+		}
+		if r, c, flipped, ok := p.columnConstant(e.Left, e.Right, lookup); ok {
+			op := e.Type()
+			if flipped {
+				op = flipComparison(op)
+			}
+			return evalComparison(op, r, c)
+		}
+
+	case *filter.BetweenExpression:
+		if e.Type() != filter.TypeCompareBetween {
+			return true
+		}
+		r, ok := p.columnRange(e.Input, lookup)
+		if !ok {
+			return true
+		}
+		if r.allNull {
+			return false
+		}
+		lowerOp, upperOp := filter.TypeCompareGreaterThanOrEqual, filter.TypeCompareLessThanOrEqual
+		if !e.LowerInclusive {
+			lowerOp = filter.TypeCompareGreaterThan
+		}
+		if !e.UpperInclusive {
+			upperOp = filter.TypeCompareLessThan
+		}
+		if lower, ok := constantValue(e.Lower); ok && !evalComparison(lowerOp, r, lower) {
+			return false
+		}
+		if upper, ok := constantValue(e.Upper); ok && !evalComparison(upperOp, r, upper) {
+			return false
+		}
+
+	case *filter.OperatorExpression:
+		switch e.Type() {
+		case filter.TypeOperatorIsNull, filter.TypeOperatorIsNotNull:
+			if len(e.Children) != 1 {
+				return true
+			}
+			r, ok := p.columnRange(e.Children[0], lookup)
+			if !ok {
+				return true
+			}
+			if e.Type() == filter.TypeOperatorIsNull {
+				return !r.noNulls
+			}
+			return !r.allNull
+		case filter.TypeCompareIn:
+			if len(e.Children) > 1 {
+				return p.evalIn(e.Children[0], e.Children[1:], lookup)
+			}
+		}
+	}
+	return true
+}
+
+// evalIn reports whether column IN (items) may be true for some row.
+func (p *pruner) evalIn(column filter.Expression, items []filter.Expression, lookup rangeLookup) bool {
+	r, ok := p.columnRange(column, lookup)
+	if !ok {
+		return true
+	}
+	if r.allNull {
+		return false
+	}
+	for _, item := range items {
+		c, ok := constantValue(item)
+		if !ok || evalComparison(filter.TypeCompareEqual, r, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnConstant matches a comparison between a column and a constant.
+// flipped is set when the constant is on the left.
+func (p *pruner) columnConstant(left, right filter.Expression, lookup rangeLookup) (valueRange, any, bool, bool) {
+	if r, ok := p.columnRange(left, lookup); ok {
+		if c, ok := constantValue(right); ok {
+			return r, c, false, true
+		}
+	}
+	if r, ok := p.columnRange(right, lookup); ok {
+		if c, ok := constantValue(left); ok {
+			return r, c, true, true
+		}
+	}
+	return valueRange{}, nil, false, false
+}
+
+// columnRange returns the value range of a column reference expression.
+func (p *pruner) columnRange(expr filter.Expression, lookup rangeLookup) (valueRange, bool) {
+	ref, ok := expr.(*filter.ColumnRefExpression)
+	if !ok {
+		return valueRange{}, false
+	}
+	name, err := p.fp.ColumnName(ref)
+	if err != nil {
+		return valueRange{}, false
+	}
+	return lookup(name)
+}
+
+// evalComparison reports whether "column op c" may be true for a row in r.
+// Comparisons with NULL never hold, so a column of nulls never matches.
+func evalComparison(op filter.ExpressionType, r valueRange, c any) bool {
+	switch op {
+	case filter.TypeCompareEqual, filter.TypeCompareNotEqual,
+		filter.TypeCompareLessThan, filter.TypeCompareLessThanOrEqual,
+		filter.TypeCompareGreaterThan, filter.TypeCompareGreaterThanOrEqual:
+	default:
+		// DISTINCT FROM and other comparisons may hold for nulls.
+		return true
+	}
+	if r.allNull {
+		return false
+	}
+	// test reports whether bound is known and its comparison with c satisfies pred.
+	test := func(bound any, pred func(int) bool) bool {
+		if bound == nil {
+			return false
+		}
+		res, ok := compareValues(bound, c)
+		return ok && pred(res)
+	}
+
+	switch op {
+	case filter.TypeCompareEqual:
+		return !test(r.min, func(n int) bool { return n > 0 }) && !test(r.max, func(n int) bool { return n < 0 })
+	case filter.TypeCompareNotEqual:
+		return !(test(r.min, func(n int) bool { return n == 0 }) && test(r.max, func(n int) bool { return n == 0 }))
+	case filter.TypeCompareLessThan:
+		return !test(r.min, func(n int) bool { return n >= 0 })
+	case filter.TypeCompareLessThanOrEqual:
+		return !test(r.min, func(n int) bool { return n > 0 })
+	case filter.TypeCompareGreaterThan:
+		return !test(r.max, func(n int) bool { return n <= 0 })
+	case filter.TypeCompareGreaterThanOrEqual:
+		return !test(r.max, func(n int) bool { return n < 0 })
+	}
+	return true
+}
+
+// flipComparison returns the comparison with swapped operands.
+func flipComparison(op filter.ExpressionType) filter.ExpressionType {
+	switch op {
+	case filter.TypeCompareLessThan:
+		return filter.TypeCompareGreaterThan
+	case filter.TypeCompareLessThanOrEqual:
+		return filter.TypeCompareGreaterThanOrEqual
+	case filter.TypeCompareGreaterThan:
+		return filter.TypeCompareLessThan
+	case filter.TypeCompareGreaterThanOrEqual:
+		return filter.TypeCompareLessThanOrEqual
+	}
+	return op
+}
+
+// compareValues compares two range values.
+// Integers and floats compare numerically; other values only compare with
+// values of the same type. Returns false if the values are not comparable.
+func compareValues(a, b any) (int, bool) {
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return cmp.Compare(x, y), true
+		case uint64:
+			if x < 0 {
+				return -1, true
+			}
+			return cmp.Compare(uint64(x), y), true
+		case float64:
+			return compareFloat(float64(x), y)
+		}
+	case uint64:
+		switch y := b.(type) {
+		case uint64:
+			return cmp.Compare(x, y), true
+		case int64:
+			if y < 0 {
+				return 1, true
+			}
+			return cmp.Compare(x, uint64(y)), true
+		case float64:
+			return compareFloat(float64(x), y)
+		}
+	case float64:
+		switch y := b.(type) {
+		case float64:
+			return compareFloat(x, y)
+		case int64:
+			return compareFloat(x, float64(y))
+		case uint64:
+			return compareFloat(x, float64(y))
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return cmp.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case y:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y), true
+		}
+	}
+	return 0, false
+}
+
+// compareFloat compares two floats. NaN is not comparable.
+func compareFloat(a, b float64) (int, bool) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, false
+	}
+	return cmp.Compare(a, b), true
+}
+
+// constantValue converts a constant expression to a range value.
+// Returns false for NULL and for types that are not used for pruning.
+func constantValue(expr filter.Expression) (any, bool) {
+	c, ok := expr.(*filter.ConstantExpression)
+	if !ok || c.Value.IsNull {
+		return nil, false
+	}
+	v := c.Value
+	switch v.Type.ID {
+	case filter.TypeIDBoolean, filter.TypeIDTinyInt, filter.TypeIDSmallInt, filter.TypeIDInteger, filter.TypeIDBigInt,
+		filter.TypeIDUTinyInt, filter.TypeIDUSmallInt, filter.TypeIDUInteger, filter.TypeIDUBigInt,
+		filter.TypeIDFloat, filter.TypeIDDouble, filter.TypeIDVarchar, filter.TypeIDChar:
+		switch v.Data.(type) {
+		case bool, int64, uint64, float64, string:
+			return v.Data, true
+		}
+	case filter.TypeIDHugeInt:
+		if h, ok := v.Data.(filter.HugeInt); ok {
+			if h.Upper == 0 && h.Lower <= math.MaxInt64 || h.Upper == -1 && h.Lower > math.MaxInt64 {
+				return int64(h.Lower), true
+			}
+		}
+	case filter.TypeIDDate:
+		if days, ok := v.Data.(int64); ok {
+			return time.Unix(days*86400, 0).UTC(), true
+		}
+	case filter.TypeIDTimestamp, filter.TypeIDTimestampTZ:
+		if n, ok := v.Data.(int64); ok {
+			return time.UnixMicro(n).UTC(), true
+		}
+	case filter.TypeIDTimestampSec:
+		if n, ok := v.Data.(int64); ok {
+			return time.Unix(n, 0).UTC(), true
+		}
+	case filter.TypeIDTimestampMs:
+		if n, ok := v.Data.(int64); ok {
+			return time.UnixMilli(n).UTC(), true
+		}
+	case filter.TypeIDTimestampNs:
+		if n, ok := v.Data.(int64); ok {
+			return time.Unix(0, n).UTC(), true
+		}
+	}
+	return nil, false
+}
+
+// partitionRanges returns the value ranges of the hive partition columns of f.
+func (t *Table) partitionRanges(f *dataFile) rangeLookup {
+	return func(column string) (valueRange, bool) {
+		i := t.partitionField(column)
+		if i < 0 {
+			return valueRange{}, false
+		}
+		v := f.partition[i]
+		if v == nil {
+			return valueRange{allNull: true}, true
+		}
+		return valueRange{min: v, max: v, noNulls: true}, true
+	}
+}
+
+// rowGroupRanges returns the value ranges of the columns of row group rg of f,
+// taken from the Parquet column chunk statistics and the hive partition values.
+func (t *Table) rowGroupRanges(f *dataFile, rg int) rangeLookup {
+	partition := t.partitionRanges(f)
+	return func(column string) (valueRange, bool) {
+		i := t.fileField(column)
+		if i < 0 {
+			return partition(column)
+		}
+		stats, rows, ok := f.columnStatistics(i, rg)
+		if !ok {
+			return valueRange{}, false
+		}
+
+		var r valueRange
+		if stats.HasNullCount() {
+			r.noNulls = stats.NullCount() == 0
+			r.allNull = stats.NullCount() == rows
+		}
+		if lo, hi, ok := statsBounds(t.schema.Field(i).Type, stats); ok {
+			r.min = rangeValue(t.schema.Field(i).Type, lo)
+			// Parquet statistics leave out NaN, which DuckDB orders above
+			// every other value, so the maximum of a float column is unknown.
+			if _, isFloat := r.min.(float64); !isFloat {
+				r.max = rangeValue(t.schema.Field(i).Type, hi)
+			}
+		}
+		return r, true
+	}
+}
+
+// columnStatistics returns the statistics of top-level field i in row group rg,
+// with the number of rows in the row group. Returns false for nested fields and
+// column chunks without statistics.
+func (f *dataFile) columnStatistics(i, rg int) (metadata.TypedStatistics, int64, bool) {
+	field := f.footer.fields[i]
+	if !field.IsLeaf() || field.LevelInfo.RepLevel > 0 {
+		return nil, 0, false
+	}
+	rgMeta := f.footer.meta.RowGroup(rg)
+	chunk, err := rgMeta.ColumnChunk(field.ColIndex)
+	if err != nil {
+		return nil, 0, false
+	}
+	stats, err := chunk.Statistics()
+	if err != nil || stats == nil {
+		return nil, 0, false
+	}
+	return stats, rgMeta.NumRows(), true
+}
+
+// statsBounds returns the minimum and maximum of Parquet statistics as Go values
+// of the Arrow column type, as required by catalog.ColumnStats.
+// Returns false if the statistics have no bounds or the type is not supported.
+func statsBounds(dt arrow.DataType, stats metadata.TypedStatistics) (any, any, bool) {
+	if !stats.HasMinMax() {
+		return nil, nil, false
+	}
+	switch s := stats.(type) {
+	case *metadata.BooleanStatistics:
+		if dt.ID() == arrow.BOOL {
+			return s.Min(), s.Max(), true
+		}
+	case *metadata.Int32Statistics:
+		lo, hi := s.Min(), s.Max()
+		switch dt.ID() {
+		case arrow.INT8:
+			return int8(lo), int8(hi), true
+		case arrow.INT16:
+			return int16(lo), int16(hi), true
+		case arrow.INT32:
+			return lo, hi, true
+		case arrow.UINT8:
+			return uint8(lo), uint8(hi), true
+		case arrow.UINT16:
+			return uint16(lo), uint16(hi), true
+		case arrow.UINT32:
+			return uint32(lo), uint32(hi), true
+		case arrow.DATE32:
+			return arrow.Date32(lo), arrow.Date32(hi), true
+		}
+	case *metadata.Int64Statistics:
+		lo, hi := s.Min(), s.Max()
+		switch dt.ID() {
+		case arrow.INT64:
+			return lo, hi, true
+		case arrow.UINT64:
+			return uint64(lo), uint64(hi), true
+		case arrow.TIMESTAMP:
+			// The file unit may differ from the Arrow unit, e.g. for
+			// second timestamps stored as milliseconds.
+			lt, ok := s.Descr().LogicalType().(*schema.TimestampLogicalType)
+			if !ok {
+				return nil, nil, false
+			}
+			unit := dt.(*arrow.TimestampType).Unit
+			from := timestampUnit(lt.TimeUnit())
+			min, err1 := arrow.TimestampFromTime(arrow.Timestamp(lo).ToTime(from), unit)
+			max, err2 := arrow.TimestampFromTime(arrow.Timestamp(hi).ToTime(from), unit)
+			if err1 == nil && err2 == nil {
+				return min, max, true
+			}
+		}
+	case *metadata.Float32Statistics:
+		if dt.ID() == arrow.FLOAT32 {
+			return s.Min(), s.Max(), true
+		}
+	case *metadata.Float64Statistics:
+		if dt.ID() == arrow.FLOAT64 {
+			return s.Min(), s.Max(), true
+		}
+	case *metadata.ByteArrayStatistics:
+		switch dt.ID() {
+		case arrow.STRING, arrow.LARGE_STRING:
+			return string(s.Min()), string(s.Max()), true
+		}
+	}
+	return nil, nil, false
+}
+
+// rangeValue converts a value returned by statsBounds, or a hive partition value,
+// to a range value.
+func rangeValue(dt arrow.DataType, v any) any {
+	switch x := v.(type) {
+	case int8:
+		return int64(x)
+	case int16:
+		return int64(x)
+	case int32:
+		return int64(x)
+	case uint8:
+		return uint64(x)
+	case uint16:
+		return uint64(x)
+	case uint32:
+		return uint64(x)
+	case float32:
+		return float64(x)
+	case arrow.Date32:
+		return x.ToTime()
+	case arrow.Timestamp:
+		return x.ToTime(dt.(*arrow.TimestampType).Unit)
+	}
+	return v
+}
+
+// timestampUnit converts a Parquet timestamp unit to an Arrow time unit.
+func timestampUnit(u schema.TimeUnitType) arrow.TimeUnit {
+	switch u {
+	case schema.TimeUnitMillis:
+		return arrow.Millisecond
+	case schema.TimeUnitNanos:
+		return arrow.Nanosecond
+	default:
+		return arrow.Microsecond
+	}
+}
@@ -0,0 +1,215 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// defaultBatchSize is the number of rows per record when ScanOptions.BatchSize is unset.
+const defaultBatchSize = 64 * 1024
+
+// scanReader is an array.RecordReader over the row groups selected by a scan.
+// Files are opened one at a time, when the reader reaches them.
+type scanReader struct {
+	refCount atomic.Int64
+
+	ctx       context.Context
+	table     *Table
+	tasks     []scanTask
+	read      []bool
+	batchSize int64
+
+	// Current file.
+	task   scanTask
+	rdr    *file.Reader
+	rr     pqarrow.RecordReader
+	fields []int // record column of each schema field, -1 if not read
+	rows   int64 // rows left when no file column is read
+
+	cur arrow.RecordBatch
+	err error
+}
+
+// newScanReader creates a reader over tasks that reads the schema fields marked in read.
+func newScanReader(ctx context.Context, t *Table, tasks []scanTask, read []bool, batchSize int64) *scanReader {
+	r := &scanReader{
+		ctx:       ctx,
+		table:     t,
+		tasks:     tasks,
+		read:      read,
+		batchSize: batchSize,
+	}
+	r.refCount.Store(1)
+	return r
+}
+
+func (r *scanReader) Retain() {
+	r.refCount.Add(1)
+}
+
+func (r *scanReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		r.closeFile()
+	}
+}
+
+func (r *scanReader) Schema() *arrow.Schema {
+	return r.table.schema
+}
+
+func (r *scanReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+
+	for r.err == nil {
+		if err := r.ctx.Err(); err != nil {
+			r.err = err
+			break
+		}
+
+		switch {
+		case r.rr != nil:
+			if r.rr.Next() {
+				rec := r.rr.RecordBatch()
+				r.cur = r.record(rec, rec.NumRows())
+				return true
+			}
+			if err := r.rr.Err(); err != nil {
+				r.err = fmt.Errorf("read %s: %w", r.task.file.rel, err)
+			}
+			r.closeFile()
+		case r.rows > 0:
+			n := min(r.rows, r.batchSize)
+			r.rows -= n
+			r.cur = r.record(nil, n)
+			return true
+		case len(r.tasks) > 0:
+			r.task, r.tasks = r.tasks[0], r.tasks[1:]
+			if err := r.open(); err != nil {
+				r.err = fmt.Errorf("open %s: %w", r.task.file.rel, err)
+			}
+		default:
+			return false
+		}
+	}
+	r.closeFile()
+	return false
+}
+
+func (r *scanReader) RecordBatch() arrow.RecordBatch {
+	return r.cur
+}
+
+// Deprecated: Use [scanReader.RecordBatch] instead.
+func (r *scanReader) Record() arrow.RecordBatch {
+	return r.cur
+}
+
+func (r *scanReader) Err() error {
+	return r.err
+}
+
+// open starts reading the current task.
+// If no file column is read, only the row count of the selected row groups is needed.
+func (r *scanReader) open() error {
+	f := r.task.file
+	var leaves []int
+	for i := range r.table.numFileFields {
+		if r.read[i] {
+			leaves = appendLeaves(leaves, f.footer.fields[i])
+		}
+	}
+	if len(leaves) == 0 {
+		for _, rg := range r.task.rowGroups {
+			r.rows += f.footer.meta.RowGroup(rg).NumRows()
+		}
+		return nil
+	}
+
+	rdr, err := file.OpenParquetFile(f.path, false, file.WithMetadata(f.footer.meta))
+	if err != nil {
+		return err
+	}
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{BatchSize: r.batchSize}, r.table.catalog.alloc)
+	if err != nil {
+		rdr.Close()
+		return err
+	}
+	rr, err := fr.GetRecordReader(r.ctx, leaves, r.task.rowGroups)
+	if err != nil {
+		rdr.Close()
+		return err
+	}
+
+	r.rdr, r.rr = rdr, rr
+	r.fields = make([]int, r.table.numFileFields)
+	for i := range r.fields {
+		r.fields[i] = -1
+		if r.read[i] {
+			if idx := rr.Schema().FieldIndices(r.table.schema.Field(i).Name); len(idx) > 0 {
+				r.fields[i] = idx[0]
+			}
+		}
+	}
+	return nil
+}
+
+// closeFile releases the current file, if any.
+func (r *scanReader) closeFile() {
+	if r.rr != nil {
+		r.rr.Release()
+		r.rr = nil
+	}
+	if r.rdr != nil {
+		r.rdr.Close()
+		r.rdr = nil
+	}
+}
+
+// record builds a full-schema record of n rows from the file columns in rec
+// and the hive partition values of the current file. Fields that are not read are null.
+func (r *scanReader) record(rec arrow.RecordBatch, n int64) arrow.RecordBatch {
+	alloc := r.table.catalog.alloc
+	schema := r.table.schema
+	cols := make([]arrow.Array, schema.NumFields())
+	for i, field := range schema.Fields() {
+		switch {
+		case i >= r.table.numFileFields && r.read[i]:
+			cols[i] = partitionArray(alloc, field.Type, r.task.file.partition[i-r.table.numFileFields], n)
+		case rec != nil && i < r.table.numFileFields && r.fields[i] >= 0:
+			cols[i] = rec.Column(r.fields[i])
+			cols[i].Retain()
+		default:
+			cols[i] = array.MakeArrayOfNull(alloc, field.Type, int(n))
+		}
+	}
+
+	out := array.NewRecordBatch(schema, cols, n)
+	for _, c := range cols {
+		c.Release()
+	}
+	return out
+}
+
+// appendLeaves appends the Parquet leaf column indices of a schema field.
+func appendLeaves(leaves []int, field pqarrow.SchemaField) []int {
+	if field.IsLeaf() {
+		return append(leaves, field.ColIndex)
+	}
+	for _, child := range field.Children {
+		leaves = appendLeaves(leaves, child)
+	}
+	return leaves
+}
@@ -0,0 +1,129 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// Schema is a directory of Parquet tables.
+// Every "*.parquet" file in the directory is a table named after the file
+// without its extension, and every subdirectory is a table made of all Parquet
+// files below it. A subdirectory takes precedence over a file with the same name.
+type Schema struct {
+	catalog *Catalog
+	name    string
+	dir     string
+}
+
+// newSchema creates the schema for the root subdirectory name.
+func newSchema(cat *Catalog, name string) *Schema {
+	return &Schema{
+		catalog: cat,
+		name:    name,
+		dir:     filepath.Join(cat.root, name),
+	}
+}
+
+// Name implements catalog.Schema.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// Comment implements catalog.Schema.
+func (s *Schema) Comment() string {
+	return ""
+}
+
+// Tables implements catalog.Schema.
+// Directories without Parquet files are skipped.
+func (s *Schema) Tables(ctx context.Context) ([]catalog.Table, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("list tables of schema %q: %w", s.name, err)
+	}
+
+	seen := make(map[string]bool, len(entries))
+	result := make([]catalog.Table, 0, len(entries))
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		name := e.Name()
+		if hidden(name) {
+			continue
+		}
+		if !e.IsDir() {
+			if !strings.EqualFold(filepath.Ext(name), fileExt) {
+				continue
+			}
+			name = name[:len(name)-len(fileExt)]
+			if seen[name] || s.isDir(name) {
+				continue
+			}
+		}
+		seen[name] = true
+
+		t, err := loadTable(s.catalog, name, filepath.Join(s.dir, e.Name()), e.IsDir())
+		if err != nil {
+			return nil, fmt.Errorf("table %q: %w", name, err)
+		}
+		if t != nil {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// Table implements catalog.Schema.
+func (s *Schema) Table(ctx context.Context, name string) (catalog.Table, error) {
+	if !validName(name) {
+		return nil, nil // Not found, not an error
+	}
+
+	path := filepath.Join(s.dir, name)
+	isDir := s.isDir(name)
+	if !isDir {
+		path += fileExt
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return nil, nil // Not found, not an error
+		}
+	}
+
+	t, err := loadTable(s.catalog, name, path, isDir)
+	if err != nil {
+		return nil, fmt.Errorf("table %q: %w", name, err)
+	}
+	if t == nil {
+		return nil, nil // Not found, not an error
+	}
+	return t, nil
+}
+
+// ScalarFunctions implements catalog.Schema.
+// Parquet schemas do not host functions.
+func (s *Schema) ScalarFunctions(ctx context.Context) ([]catalog.ScalarFunction, error) {
+	return []catalog.ScalarFunction{}, nil
+}
+
+// TableFunctions implements catalog.Schema.
+// Parquet schemas do not host functions.
+func (s *Schema) TableFunctions(ctx context.Context) ([]catalog.TableFunction, error) {
+	return []catalog.TableFunction{}, nil
+}
+
+// TableFunctionsInOut implements catalog.Schema.
+// Parquet schemas do not host functions.
+func (s *Schema) TableFunctionsInOut(ctx context.Context) ([]catalog.TableFunctionInOut, error) {
+	return []catalog.TableFunctionInOut{}, nil
+}
+
+// isDir reports whether the schema directory has a subdirectory name.
+func (s *Schema) isDir(name string) bool {
+	info, err := os.Stat(filepath.Join(s.dir, name))
+	return err == nil && info.IsDir()
+}
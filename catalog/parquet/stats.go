@@ -0,0 +1,122 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// ColumnStatistics implements catalog.StatisticsTable.
+// Statistics of file columns are merged from the column chunk statistics in the
+// Parquet footers, without reading data. Min and Max are reported only if every
+// row group has bounds for the column, and null flags only if every row group
+// has a null count. DistinctCount is not reported for file columns.
+// Statistics of hive partition columns are exact.
+func (t *Table) ColumnStatistics(ctx context.Context, columnName string, columnType string) (*catalog.ColumnStats, error) {
+	if i := t.partitionField(columnName); i >= 0 {
+		return t.partitionStatistics(i), nil
+	}
+	i := t.fileField(columnName)
+	if i < 0 {
+		return nil, fmt.Errorf("column %q: %w", columnName, catalog.ErrNotFound)
+	}
+	dt := t.schema.Field(i).Type
+
+	var (
+		minV, maxV, minR, maxR any
+		bounds                 = true
+		counts                 = true
+		nulls, rows            int64
+	)
+	for _, f := range t.files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for rg := range f.footer.meta.NumRowGroups() {
+			stats, n, ok := f.columnStatistics(i, rg)
+			if !ok {
+				return &catalog.ColumnStats{}, nil
+			}
+			rows += n
+			if counts = counts && stats.HasNullCount(); counts {
+				nulls += stats.NullCount()
+			}
+			if !bounds {
+				continue
+			}
+			lo, hi, ok := statsBounds(dt, stats)
+			if !ok {
+				// A row group of nulls has no bounds but does not widen them.
+				bounds = stats.HasNullCount() && stats.NullCount() == n
+				continue
+			}
+			loR, hiR := rangeValue(dt, lo), rangeValue(dt, hi)
+			if c, ok := compareValues(loR, minR); minR == nil || ok && c < 0 {
+				minV, minR = lo, loR
+			}
+			if c, ok := compareValues(hiR, maxR); maxR == nil || ok && c > 0 {
+				maxV, maxR = hi, hiR
+			}
+		}
+	}
+
+	stats := &catalog.ColumnStats{}
+	if bounds {
+		stats.Min, stats.Max = minV, maxV
+	}
+	if counts {
+		hasNull := nulls > 0
+		hasNotNull := nulls < rows
+		stats.HasNull = &hasNull
+		stats.HasNotNull = &hasNotNull
+	}
+	return stats, nil
+}
+
+// partitionStatistics computes exact statistics of hive partition column i.
+func (t *Table) partitionStatistics(i int) *catalog.ColumnStats {
+	var (
+		hasNull, hasNotNull bool
+		minV, maxV          any
+		distinct            = make(map[any]struct{})
+		isString            bool
+		maxLen              uint64
+		unicode             bool
+	)
+	for _, f := range t.files {
+		v := f.partition[i]
+		if v == nil {
+			hasNull = true
+			continue
+		}
+		hasNotNull = true
+		distinct[v] = struct{}{}
+		if c, _ := compareValues(v, minV); minV == nil || c < 0 {
+			minV = v
+		}
+		if c, _ := compareValues(v, maxV); maxV == nil || c > 0 {
+			maxV = v
+		}
+		if s, ok := v.(string); ok {
+			isString = true
+			maxLen = max(maxLen, uint64(utf8.RuneCountInString(s)))
+			unicode = unicode || utf8.RuneCountInString(s) != len(s)
+		}
+	}
+
+	distinctCount := uint64(len(distinct))
+	stats := &catalog.ColumnStats{
+		HasNull:       &hasNull,
+		HasNotNull:    &hasNotNull,
+		DistinctCount: &distinctCount,
+		Min:           minV,
+		Max:           maxV,
+	}
+	if isString {
+		stats.MaxStringLength = &maxLen
+		stats.ContainsUnicode = &unicode
+	}
+	return stats
+}
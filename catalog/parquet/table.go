@@ -0,0 +1,389 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// hiveNull is the partition value Hive and Spark write for NULL.
+const hiveNull = "__HIVE_DEFAULT_PARTITION__"
+
+// Table is a read-only catalog.Table over one Parquet file or a directory of them.
+// It implements catalog.PartitionedTable, with one partition per data file,
+// and catalog.StatisticsTable.
+type Table struct {
+	catalog *Catalog
+	name    string
+	schema  *arrow.Schema
+	// numFileFields is the number of leading schema fields stored in the files;
+	// the remaining fields are hive partition columns.
+	numFileFields int
+	files         []*dataFile
+}
+
+// dataFile is one Parquet file of a table.
+type dataFile struct {
+	path string
+	// rel is the path relative to the table directory, used as partition descriptor.
+	rel    string
+	footer *footer
+	// partition holds the hive partition values (int64, string or nil) of the file.
+	partition []any
+}
+
+// loadTable reads the footers of the files that make up a table.
+// Returns nil if a table directory holds no Parquet files.
+func loadTable(cat *Catalog, name, path string, isDir bool) (*Table, error) {
+	var (
+		files []*dataFile
+		keys  []string
+		raw   [][]string
+	)
+	if !isDir {
+		files = append(files, &dataFile{path: path, rel: filepath.Base(path)})
+		raw = append(raw, nil)
+	} else {
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if p == path {
+				return nil
+			}
+			if hidden(d.Name()) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || !strings.EqualFold(filepath.Ext(p), fileExt) {
+				return nil
+			}
+
+			rel, err := filepath.Rel(path, p)
+			if err != nil {
+				return err
+			}
+			fileKeys, values, err := hivePartition(rel)
+			if err != nil {
+				return err
+			}
+			if len(files) == 0 {
+				keys = fileKeys
+			} else if !slices.Equal(keys, fileKeys) {
+				return fmt.Errorf("%s: hive partition keys %v do not match %v", rel, fileKeys, keys)
+			}
+			files = append(files, &dataFile{path: p, rel: filepath.ToSlash(rel)})
+			raw = append(raw, values)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	for _, f := range files {
+		ft, err := cat.footer(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.rel, err)
+		}
+		if f != files[0] && !sameColumns(ft.schema, files[0].footer.schema) {
+			return nil, fmt.Errorf("%s: schema does not match %s", f.rel, files[0].rel)
+		}
+		f.footer = ft
+	}
+
+	fileSchema := files[0].footer.schema
+	fields := append([]arrow.Field(nil), fileSchema.Fields()...)
+	for i, key := range keys {
+		if fileSchema.HasField(key) {
+			return nil, fmt.Errorf("hive partition key %q conflicts with a file column", key)
+		}
+		column := make([]string, len(raw))
+		for j := range raw {
+			column[j] = raw[j][i]
+		}
+		typ, values := partitionValues(column)
+		fields = append(fields, arrow.Field{Name: key, Type: typ, Nullable: true})
+		for j, f := range files {
+			f.partition = append(f.partition, values[j])
+		}
+	}
+	var md *arrow.Metadata
+	if fileSchema.HasMetadata() {
+		m := fileSchema.Metadata()
+		md = &m
+	}
+
+	return &Table{
+		catalog:       cat,
+		name:          name,
+		schema:        arrow.NewSchema(fields, md),
+		numFileFields: fileSchema.NumFields(),
+		files:         files,
+	}, nil
+}
+
+// Name implements catalog.Table.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Comment implements catalog.Table.
+func (t *Table) Comment() string {
+	return ""
+}
+
+// ArrowSchema implements catalog.Table.
+// The schema holds the file columns followed by the hive partition columns.
+func (t *Table) ArrowSchema(columns []string) *arrow.Schema {
+	return catalog.ProjectSchema(t.schema, columns)
+}
+
+// NumRows returns the number of rows in all files of the table.
+func (t *Table) NumRows() int64 {
+	var n int64
+	for _, f := range t.files {
+		n += f.footer.meta.NumRows
+	}
+	return n
+}
+
+// Scan implements catalog.Table.
+// Only the columns in opts.Columns are read; the other columns of the returned
+// full-schema records are null. Row groups and files that cannot match
+// opts.Filter are skipped, using the Parquet statistics and the hive partition values.
+// The remaining rows are returned unfiltered.
+func (t *Table) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tasks, err := t.plan(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	read := make([]bool, t.schema.NumFields())
+	if len(opts.Columns) == 0 {
+		for i := range read {
+			read[i] = true
+		}
+	}
+	for _, name := range opts.Columns {
+		for _, i := range t.schema.FieldIndices(name) {
+			read[i] = true
+		}
+	}
+
+	batchSize := int64(opts.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return newScanReader(ctx, t, tasks, read, batchSize), nil
+}
+
+// Partitions implements catalog.PartitionedTable.
+// Every data file that may hold matching rows is a partition; tables with a single
+// such file are not partitioned.
+func (t *Table) Partitions(ctx context.Context, opts *catalog.ScanOptions) ([][]byte, error) {
+	tasks, err := t.plan(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) < 2 {
+		return nil, nil
+	}
+
+	parts := make([][]byte, len(tasks))
+	for i, task := range tasks {
+		parts[i] = []byte(task.file.rel)
+	}
+	return parts, nil
+}
+
+// scanTask is a data file and the row groups of it to read.
+type scanTask struct {
+	file      *dataFile
+	rowGroups []int
+}
+
+// plan selects the files and row groups a scan reads.
+// Without a filter, row groups past opts.Limit rows are skipped as well.
+func (t *Table) plan(opts *catalog.ScanOptions) ([]scanTask, error) {
+	p := newPruner(opts.Filter)
+
+	files := t.files
+	if opts.Partition != nil {
+		files = nil
+		for _, f := range t.files {
+			if f.rel == string(opts.Partition) {
+				files = []*dataFile{f}
+				break
+			}
+		}
+		if files == nil {
+			return nil, fmt.Errorf("partition %q: %w", opts.Partition, catalog.ErrNotFound)
+		}
+	}
+
+	limit := opts.Limit
+	if p != nil {
+		limit = 0
+	}
+
+	var (
+		tasks []scanTask
+		rows  int64
+	)
+	for _, f := range files {
+		if !p.mayMatch(t.partitionRanges(f)) {
+			continue
+		}
+		var rowGroups []int
+		for rg := range f.footer.meta.NumRowGroups() {
+			if limit > 0 && rows >= limit {
+				break
+			}
+			if !p.mayMatch(t.rowGroupRanges(f, rg)) {
+				continue
+			}
+			rowGroups = append(rowGroups, rg)
+			rows += f.footer.meta.RowGroup(rg).NumRows()
+		}
+		if len(rowGroups) > 0 {
+			tasks = append(tasks, scanTask{file: f, rowGroups: rowGroups})
+		}
+	}
+	return tasks, nil
+}
+
+// partitionField returns the index of a hive partition column in the partition
+// values of a file, or -1 if name is not a partition column.
+func (t *Table) partitionField(name string) int {
+	for _, i := range t.schema.FieldIndices(name) {
+		if i >= t.numFileFields {
+			return i - t.numFileFields
+		}
+	}
+	return -1
+}
+
+// fileField returns the schema index of a file column, or -1 if name is not a file column.
+func (t *Table) fileField(name string) int {
+	for _, i := range t.schema.FieldIndices(name) {
+		if i < t.numFileFields {
+			return i
+		}
+	}
+	return -1
+}
+
+// hivePartition parses the key=value directories of a file path relative to the
+// table directory. Values are unescaped but not typed.
+func hivePartition(rel string) ([]string, []string, error) {
+	dirs := strings.Split(filepath.ToSlash(filepath.Dir(rel)), "/")
+	var keys, values []string
+	for _, dir := range dirs {
+		key, value, ok := strings.Cut(dir, "=")
+		if !ok || key == "" {
+			continue
+		}
+		key, err := url.PathUnescape(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: hive partition key: %w", rel, err)
+		}
+		value, err = url.PathUnescape(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: hive partition value: %w", rel, err)
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
+
+// partitionValues types the values of a hive partition column.
+// The column is Int64 if every non-null value is an integer, and String otherwise.
+func partitionValues(column []string) (arrow.DataType, []any) {
+	values := make([]any, len(column))
+	isInt := true
+	for i, s := range column {
+		if s == hiveNull {
+			continue
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			isInt = false
+			break
+		}
+		values[i] = n
+	}
+	if isInt {
+		return arrow.PrimitiveTypes.Int64, values
+	}
+
+	for i, s := range column {
+		if s == hiveNull {
+			values[i] = nil
+		} else {
+			values[i] = s
+		}
+	}
+	return arrow.BinaryTypes.String, values
+}
+
+// partitionArray returns an array of n copies of a hive partition value.
+func partitionArray(alloc memory.Allocator, dt arrow.DataType, value any, n int64) arrow.Array {
+	switch v := value.(type) {
+	case int64:
+		b := array.NewInt64Builder(alloc)
+		defer b.Release()
+		b.Reserve(int(n))
+		for range n {
+			b.UnsafeAppend(v)
+		}
+		return b.NewArray()
+	case string:
+		b := array.NewStringBuilder(alloc)
+		defer b.Release()
+		b.Reserve(int(n))
+		b.ReserveData(int(n) * len(v))
+		for range n {
+			b.Append(v)
+		}
+		return b.NewArray()
+	default:
+		return array.MakeArrayOfNull(alloc, dt, int(n))
+	}
+}
+
+// sameColumns reports whether two file schemas have the same column names and types.
+// Schema and field metadata may differ between writers and are ignored.
+func sameColumns(a, b *arrow.Schema) bool {
+	if a.NumFields() != b.NumFields() {
+		return false
+	}
+	for i := range a.NumFields() {
+		fa, fb := a.Field(i), b.Field(i)
+		if fa.Name != fb.Name || !arrow.TypeEqual(fa.Type, fb.Type) {
+			return false
+		}
+	}
+	return true
+}
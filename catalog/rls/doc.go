@@ -0,0 +1,39 @@
+// Package rls provides row-level security for catalog tables.
+//
+// Wrap decorates a catalog.Table with a PredicateProvider that returns, for the
+// calling principal, the predicates a row must match to be visible, such as
+// tenant_id = <tenant claim> or region IN ('eu', 'us'). The predicates apply to
+// every scan and DML operation:
+//   - Scans add the predicates to ScanOptions.Filter, so tables that push the
+//     filter down (such as catalog/sqldb tables) only read visible rows, and
+//     filter the returned batches, so any table is restricted
+//   - Inserts fail if an inserted row does not match the predicates
+//   - Updates and deletes fail if they target a rowid outside the visible rows,
+//     and updates fail if a new value does not match the predicates
+//
+// Rejected operations return auth.ErrPermissionDenied, reported to clients as
+// codes.PermissionDenied.
+//
+// # Basic Usage
+//
+//	tenants := rls.PredicateProviderFunc(func(ctx context.Context, p *auth.Principal, table string) ([]rls.Predicate, error) {
+//		if p.HasRole("admin") {
+//			return nil, nil // unrestricted
+//		}
+//		if p == nil || p.Tenant == "" {
+//			return []rls.Predicate{rls.In("tenant_id")}, nil // no rows
+//		}
+//		return []rls.Predicate{rls.Equal("tenant_id", p.Tenant)}, nil
+//	})
+//
+//	cat.AddSchema("main", "", map[string]catalog.Table{
+//		"orders": rls.Wrap(ordersTable, tenants),
+//	}, nil, nil, nil, nil)
+//
+// # Limitations
+//
+// Predicate columns must be boolean, integer, floating point or string columns
+// of the table. Update and delete checks scan the wrapped table for the targeted
+// rowids, which requires a rowid column. DDL, column statistics and partitioned
+// scans of the wrapped table are not available through the wrapper.
+package rls
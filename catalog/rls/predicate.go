@@ -0,0 +1,211 @@
+package rls
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/apache/arrow-go/v18/arrow"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+// Predicate restricts rows to those where Column equals one of Values.
+// A predicate without values matches no rows.
+type Predicate struct {
+	// Column is the name of the restricted column.
+	Column string
+
+	// Values are the allowed column values. Supported Go types are bool,
+	// integers, floats and strings; they are converted to the column type.
+	Values []any
+}
+
+// Equal returns a predicate for column = value.
+func Equal(column string, value any) Predicate {
+	return Predicate{Column: column, Values: []any{value}}
+}
+
+// In returns a predicate for column IN (values...).
+func In(column string, values ...any) Predicate {
+	return Predicate{Column: column, Values: values}
+}
+
+// PredicateProvider returns the row restrictions of a caller.
+// Implementations MUST be goroutine-safe.
+type PredicateProvider interface {
+	// Predicates returns the predicates restricting the rows of table that the
+	// caller may access. All predicates must hold for a row to be visible.
+	// Returning no predicates leaves the table unrestricted.
+	// principal is nil for unauthenticated requests.
+	Predicates(ctx context.Context, principal *auth.Principal, table string) ([]Predicate, error)
+}
+
+// PredicateProviderFunc is an adapter to allow ordinary functions to be used as a PredicateProvider.
+type PredicateProviderFunc func(ctx context.Context, principal *auth.Principal, table string) ([]Predicate, error)
+
+// Predicates implements PredicateProvider.
+func (f PredicateProviderFunc) Predicates(ctx context.Context, principal *auth.Principal, table string) ([]Predicate, error) {
+	return f(ctx, principal, table)
+}
+
+// JSON structures of the DuckDB filter pushdown format, see filter.Parse.
+
+type logicalType struct {
+	ID filter.LogicalTypeID `json:"id"`
+}
+
+type columnRef struct {
+	ExpressionClass filter.ExpressionClass `json:"expression_class"`
+	Type            filter.ExpressionType  `json:"type"`
+	ReturnType      logicalType            `json:"return_type"`
+	Binding         filter.ColumnBinding   `json:"binding"`
+}
+
+type constant struct {
+	ExpressionClass filter.ExpressionClass `json:"expression_class"`
+	Type            filter.ExpressionType  `json:"type"`
+	Value           constantValue          `json:"value"`
+}
+
+type constantValue struct {
+	Type  logicalType `json:"type"`
+	Value any         `json:"value"`
+}
+
+type comparison struct {
+	ExpressionClass filter.ExpressionClass `json:"expression_class"`
+	Type            filter.ExpressionType  `json:"type"`
+	Left            any                    `json:"left"`
+	Right           any                    `json:"right"`
+}
+
+type operator struct {
+	ExpressionClass filter.ExpressionClass `json:"expression_class"`
+	Type            filter.ExpressionType  `json:"type"`
+	ReturnType      logicalType            `json:"return_type"`
+	Children        []any                  `json:"children"`
+}
+
+// encodeFilters encodes predicates as filter pushdown expressions.
+// The column of predicate i is bound to column binding index base+i.
+func encodeFilters(schema *arrow.Schema, preds []Predicate, base int) ([]json.RawMessage, error) {
+	filters := make([]json.RawMessage, 0, len(preds))
+	for i, p := range preds {
+		expr, err := encodePredicate(schema, p, base+i)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, data)
+	}
+	return filters, nil
+}
+
+// encodePredicate builds column = value or column IN (values...).
+func encodePredicate(schema *arrow.Schema, p Predicate, binding int) (any, error) {
+	indices := schema.FieldIndices(p.Column)
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("predicate column %q not found", p.Column)
+	}
+	typeID, err := typeIDOf(schema.Field(indices[0]).Type)
+	if err != nil {
+		return nil, fmt.Errorf("predicate column %q: %w", p.Column, err)
+	}
+
+	ref := columnRef{
+		ExpressionClass: filter.ClassBoundColumnRef,
+		Type:            filter.TypeBoundColumnRef,
+		ReturnType:      logicalType{ID: typeID},
+		Binding:         filter.ColumnBinding{ColumnIndex: binding},
+	}
+	values := make([]any, 0, len(p.Values))
+	for _, v := range p.Values {
+		data, err := convertValue(typeID, v)
+		if err != nil {
+			return nil, fmt.Errorf("predicate column %q: %w", p.Column, err)
+		}
+		values = append(values, constant{
+			ExpressionClass: filter.ClassBoundConstant,
+			Type:            filter.TypeValueConstant,
+			Value:           constantValue{Type: logicalType{ID: typeID}, Value: data},
+		})
+	}
+
+	if len(values) == 1 {
+		return comparison{
+			ExpressionClass: filter.ClassBoundComparison,
+			Type:            filter.TypeCompareEqual,
+			Left:            ref,
+			Right:           values[0],
+		}, nil
+	}
+	return operator{
+		ExpressionClass: filter.ClassBoundOperator,
+		Type:            filter.TypeCompareIn,
+		ReturnType:      logicalType{ID: filter.TypeIDBoolean},
+		Children:        append([]any{ref}, values...),
+	}, nil
+}
+
+// typeIDOf maps the Arrow types predicates support to DuckDB type IDs.
+func typeIDOf(dt arrow.DataType) (filter.LogicalTypeID, error) {
+	switch dt.ID() {
+	case arrow.BOOL:
+		return filter.TypeIDBoolean, nil
+	case arrow.INT8:
+		return filter.TypeIDTinyInt, nil
+	case arrow.INT16:
+		return filter.TypeIDSmallInt, nil
+	case arrow.INT32:
+		return filter.TypeIDInteger, nil
+	case arrow.INT64:
+		return filter.TypeIDBigInt, nil
+	case arrow.UINT8:
+		return filter.TypeIDUTinyInt, nil
+	case arrow.UINT16:
+		return filter.TypeIDUSmallInt, nil
+	case arrow.UINT32:
+		return filter.TypeIDUInteger, nil
+	case arrow.UINT64:
+		return filter.TypeIDUBigInt, nil
+	case arrow.FLOAT32:
+		return filter.TypeIDFloat, nil
+	case arrow.FLOAT64:
+		return filter.TypeIDDouble, nil
+	case arrow.STRING, arrow.LARGE_STRING, arrow.STRING_VIEW:
+		return filter.TypeIDVarchar, nil
+	default:
+		return "", fmt.Errorf("unsupported column type %s", dt)
+	}
+}
+
+// convertValue converts a predicate value to the JSON value of a DuckDB constant.
+func convertValue(typeID filter.LogicalTypeID, v any) (any, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case typeID == filter.TypeIDBoolean && rv.Kind() == reflect.Bool:
+		return rv.Bool(), nil
+	case typeID == filter.TypeIDVarchar && rv.Kind() == reflect.String:
+		return rv.String(), nil
+	case typeID.IsSigned() && rv.CanInt():
+		return rv.Int(), nil
+	case typeID.IsSigned() && rv.CanUint() && rv.Uint() <= math.MaxInt64:
+		return int64(rv.Uint()), nil
+	case typeID.IsUnsigned() && rv.CanUint():
+		return rv.Uint(), nil
+	case typeID.IsUnsigned() && rv.CanInt() && rv.Int() >= 0:
+		return uint64(rv.Int()), nil
+	case (typeID == filter.TypeIDFloat || typeID == filter.TypeIDDouble) && rv.CanFloat():
+		return rv.Float(), nil
+	case (typeID == filter.TypeIDFloat || typeID == filter.TypeIDDouble) && rv.CanInt():
+		return float64(rv.Int()), nil
+	}
+	return nil, fmt.Errorf("value %v (%T) does not match column type %s", v, v, typeID)
+}
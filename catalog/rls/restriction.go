@@ -0,0 +1,120 @@
+package rls
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+// restriction is the compiled set of predicates of one request.
+type restriction struct {
+	schema *arrow.Schema
+	preds  []Predicate
+
+	// none is set when a predicate has no values and no row is visible.
+	none bool
+
+	// eval selects the rows matching every predicate.
+	eval *filter.Evaluator
+
+	// checks evaluate each predicate on its own, for DML input that may
+	// carry only some of the predicate columns.
+	checks []check
+}
+
+type check struct {
+	column string
+	eval   *filter.Evaluator
+}
+
+// newRestriction compiles preds against the table schema.
+func newRestriction(schema *arrow.Schema, preds []Predicate) (*restriction, error) {
+	r := &restriction{schema: schema, preds: preds}
+	for _, p := range preds {
+		if len(p.Values) == 0 {
+			r.none = true
+			return r, nil
+		}
+	}
+
+	data, err := r.merge(nil)
+	if err != nil {
+		return nil, err
+	}
+	fp, err := filter.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	r.eval = filter.NewEvaluator(fp, nil)
+	for i, p := range preds {
+		ev := filter.NewEvaluator(&filter.FilterPushdown{
+			Filters:        fp.Filters[i : i+1],
+			ColumnBindings: fp.ColumnBindings,
+		}, nil)
+		if ev.Empty() {
+			// The evaluator would select every row
+			return nil, fmt.Errorf("predicate on column %q cannot be evaluated", p.Column)
+		}
+		r.checks = append(r.checks, check{column: p.Column, eval: ev})
+	}
+	return r, nil
+}
+
+// columns returns the predicate columns.
+func (r *restriction) columns() []string {
+	columns := make([]string, len(r.preds))
+	for i, p := range r.preds {
+		columns[i] = p.Column
+	}
+	return columns
+}
+
+// merge adds the predicates to a serialized filter pushdown document.
+// data may be empty; the predicates are AND'ed with its filters.
+func (r *restriction) merge(data []byte) ([]byte, error) {
+	doc, err := filter.ParseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	filters, err := encodeFilters(r.schema, r.preds, len(doc.ColumnBindings))
+	if err != nil {
+		return nil, err
+	}
+	doc.Filters = append(doc.Filters, filters...)
+	doc.ColumnBindings = append(doc.ColumnBindings, r.columns()...)
+	return doc.Marshal()
+}
+
+// checkRows verifies that every row of rec matches the predicates.
+// With partial set, predicates on columns missing from rec are skipped;
+// otherwise missing columns fail the check.
+func (r *restriction) checkRows(ctx context.Context, rec arrow.RecordBatch, partial bool) error {
+	if r.none {
+		return fmt.Errorf("%w: no rows are visible", auth.ErrPermissionDenied)
+	}
+	for _, c := range r.checks {
+		if !rec.Schema().HasField(c.column) {
+			if partial {
+				continue
+			}
+			return fmt.Errorf("%w: column %q is required", auth.ErrPermissionDenied, c.column)
+		}
+		mask, err := c.eval.Evaluate(ctx, rec)
+		if err != nil {
+			return err
+		}
+		ok := mask.NullN() == 0
+		for i := 0; ok && i < mask.Len(); i++ {
+			ok = mask.Value(i)
+		}
+		mask.Release()
+		if !ok {
+			return fmt.Errorf("%w: row outside the visible rows of column %q", auth.ErrPermissionDenied, c.column)
+		}
+	}
+	return nil
+}
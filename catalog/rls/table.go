@@ -0,0 +1,280 @@
+package rls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/filter"
+	"github.com/hugr-lab/airport-go/internal/dml"
+)
+
+// Table wraps a catalog.Table and restricts every scan and DML operation to
+// the rows visible to the caller.
+//
+// Table implements catalog.InsertableTable, catalog.UpdatableBatchTable and
+// catalog.DeletableBatchTable; DML on a wrapped table without the matching
+// capability fails with catalog.ErrUnimplemented. Column statistics and
+// partitions of the wrapped table are not exposed, since they are computed
+// over all rows.
+type Table struct {
+	table    catalog.Table
+	provider PredicateProvider
+}
+
+var (
+	_ catalog.InsertableTable     = (*Table)(nil)
+	_ catalog.UpdatableBatchTable = (*Table)(nil)
+	_ catalog.DeletableBatchTable = (*Table)(nil)
+)
+
+// Wrap returns t restricted by the predicates of provider.
+// t must have a fixed schema (ArrowSchema(nil) must not return nil).
+func Wrap(t catalog.Table, provider PredicateProvider) *Table {
+	return &Table{table: t, provider: provider}
+}
+
+// Unwrap returns the wrapped table.
+func (t *Table) Unwrap() catalog.Table {
+	return t.table
+}
+
+// Name implements catalog.Table.
+func (t *Table) Name() string {
+	return t.table.Name()
+}
+
+// Comment implements catalog.Table.
+func (t *Table) Comment() string {
+	return t.table.Comment()
+}
+
+// ArrowSchema implements catalog.Table.
+func (t *Table) ArrowSchema(columns []string) *arrow.Schema {
+	return t.table.ArrowSchema(columns)
+}
+
+// Scan implements catalog.Table.
+// The predicates are added to opts.Filter, so tables that push filters down
+// (such as SQL-backed tables) apply them at the source, and the returned
+// batches are filtered again. opts.Limit is not passed on, because the wrapped
+// table could stop before it reached the visible rows.
+func (t *Table) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	r, err := t.restrict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return t.table.Scan(ctx, opts)
+	}
+	if r.none {
+		return array.NewRecordReader(r.schema, nil)
+	}
+
+	var scanOpts catalog.ScanOptions
+	if opts != nil {
+		scanOpts = *opts
+	}
+	if scanOpts.Filter, err = r.merge(scanOpts.Filter); err != nil {
+		return nil, err
+	}
+	scanOpts.Limit = 0
+	if len(scanOpts.Columns) > 0 {
+		scanOpts.Columns = appendMissing(slices.Clone(scanOpts.Columns), r.columns())
+	}
+
+	rdr, err := t.table.Scan(ctx, &scanOpts)
+	if err != nil {
+		return nil, err
+	}
+	return filter.FilterRecordReader(ctx, rdr, r.eval), nil
+}
+
+// Insert implements catalog.InsertableTable.
+// Inserted rows must match the predicates; the first row that does not fails
+// the insert with auth.ErrPermissionDenied.
+func (t *Table) Insert(ctx context.Context, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	insertable, ok := t.table.(catalog.InsertableTable)
+	if !ok {
+		return nil, fmt.Errorf("table %q does not support INSERT: %w", t.Name(), catalog.ErrUnimplemented)
+	}
+	r, err := t.restrict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return insertable.Insert(ctx, rows, opts)
+	}
+
+	checked := &checkedReader{RecordReader: rows, ctx: ctx, r: r}
+	result, err := insertable.Insert(ctx, checked, opts)
+	if checked.err != nil {
+		if result != nil && result.ReturningData != nil {
+			result.ReturningData.Release()
+		}
+		return nil, checked.err
+	}
+	return result, err
+}
+
+// Update implements catalog.UpdatableBatchTable.
+// Every targeted rowid must be visible and the new values must match the
+// predicates on the updated columns; otherwise the update fails with
+// auth.ErrPermissionDenied and no row is changed.
+func (t *Table) Update(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	r, err := t.restrict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		if err := r.checkRows(ctx, rows, true); err != nil {
+			return nil, err
+		}
+		if err := t.checkVisible(ctx, r, rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return dml.UpdateBatch(ctx, t.table, rows, opts)
+}
+
+// Delete implements catalog.DeletableBatchTable.
+// Every targeted rowid must be visible; otherwise the delete fails with
+// auth.ErrPermissionDenied and no row is removed.
+func (t *Table) Delete(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	r, err := t.restrict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		if err := t.checkVisible(ctx, r, rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return dml.DeleteBatch(ctx, t.table, rows, opts)
+}
+
+// restrict returns the restriction for the caller, or nil if the table is unrestricted.
+func (t *Table) restrict(ctx context.Context) (*restriction, error) {
+	preds, err := t.provider.Predicates(ctx, auth.PrincipalFromContext(ctx), t.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get row predicates: %w", err)
+	}
+	if len(preds) == 0 {
+		return nil, nil
+	}
+	schema := t.table.ArrowSchema(nil)
+	if schema == nil {
+		return nil, fmt.Errorf("table %q has no fixed schema", t.Name())
+	}
+	return newRestriction(schema, preds)
+}
+
+// checkVisible verifies that every rowid in rows addresses a visible row,
+// by scanning the wrapped table for the visible rows among them.
+func (t *Table) checkVisible(ctx context.Context, r *restriction, rows arrow.RecordBatch) error {
+	rowIDIdx := catalog.FindRowIDColumn(rows.Schema())
+	if rowIDIdx < 0 {
+		return fmt.Errorf("input has no rowid column")
+	}
+	ids, err := dml.RowIDValues(rows.Column(rowIDIdx))
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if r.none {
+		return fmt.Errorf("%w: no rows are visible", auth.ErrPermissionDenied)
+	}
+
+	tableRowIDIdx := catalog.FindRowIDColumn(r.schema)
+	if tableRowIDIdx < 0 {
+		return fmt.Errorf("table %q has no rowid column", t.Name())
+	}
+	rowID := r.schema.Field(tableRowIDIdx).Name
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	lookup, err := newRestriction(r.schema, append(slices.Clone(r.preds), In(rowID, values...)))
+	if err != nil {
+		return err
+	}
+	data, err := lookup.merge(nil)
+	if err != nil {
+		return err
+	}
+	rdr, err := t.table.Scan(ctx, &catalog.ScanOptions{
+		Columns: lookup.columns(),
+		Filter:  data,
+	})
+	if err != nil {
+		return err
+	}
+	visibleRows := filter.FilterRecordReader(ctx, rdr, lookup.eval)
+	defer visibleRows.Release()
+
+	visible := make(map[int64]bool, len(ids))
+	for visibleRows.Next() {
+		rec := visibleRows.RecordBatch()
+		found, err := dml.RowIDValues(rec.Column(catalog.FindRowIDColumn(rec.Schema())))
+		if err != nil {
+			return err
+		}
+		for _, id := range found {
+			visible[id] = true
+		}
+	}
+	if err := visibleRows.Err(); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !visible[id] {
+			return fmt.Errorf("%w: rowid %d is not visible", auth.ErrPermissionDenied, id)
+		}
+	}
+	return nil
+}
+
+// checkedReader checks every batch read from a DML input against a restriction.
+type checkedReader struct {
+	array.RecordReader
+	ctx context.Context
+	r   *restriction
+	err error
+}
+
+func (c *checkedReader) Next() bool {
+	if c.err != nil || !c.RecordReader.Next() {
+		return false
+	}
+	if err := c.r.checkRows(c.ctx, c.RecordReader.RecordBatch(), false); err != nil {
+		c.err = err
+		return false
+	}
+	return true
+}
+
+func (c *checkedReader) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.RecordReader.Err()
+}
+
+// appendMissing appends the names not yet in columns.
+func appendMissing(columns, names []string) []string {
+	for _, name := range names {
+		if !slices.Contains(columns, name) {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,274 @@
+package rls
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/catalog"
+	catalogmemory "github.com/hugr-lab/airport-go/catalog/memory"
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+var ordersSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+	{Name: "tenant", Type: arrow.BinaryTypes.String},
+}, nil)
+
+// tenantProvider restricts callers to their tenant; admins are unrestricted
+// and callers without a tenant see no rows.
+var tenantProvider = PredicateProviderFunc(func(ctx context.Context, p *auth.Principal, table string) ([]Predicate, error) {
+	switch {
+	case p.HasRole("admin"):
+		return nil, nil
+	case p == nil || p.Tenant == "":
+		return []Predicate{In("tenant")}, nil
+	default:
+		return []Predicate{Equal("tenant", p.Tenant)}, nil
+	}
+})
+
+func tenantContext(tenant string, roles ...string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{ID: "user-" + tenant, Tenant: tenant, Roles: roles})
+}
+
+// newOrdersTable creates an in-memory orders table with rows 1-4 owned by
+// tenants a, b, a and b, and wraps it with tenantProvider.
+func newOrdersTable(t *testing.T) (*Table, *catalogmemory.Table) {
+	t.Helper()
+	ctx := context.Background()
+
+	cat := catalogmemory.NewCatalog("test")
+	sc, err := cat.CreateSchema(ctx, "main", catalog.CreateSchemaOptions{})
+	if err != nil {
+		t.Fatalf("CreateSchema failed: %v", err)
+	}
+	tbl, err := sc.(*catalogmemory.Schema).CreateTable(ctx, "orders", ordersSchema, catalog.CreateTableOptions{})
+	if err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	inner := tbl.(*catalogmemory.Table)
+	rows := ordersReader(t, []int32{1, 2, 3, 4}, []string{"a", "b", "a", "b"})
+	defer rows.Release()
+	if _, err := inner.Insert(ctx, rows, nil); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	return Wrap(inner, tenantProvider), inner
+}
+
+func ordersReader(t *testing.T, ids []int32, tenants []string) array.RecordReader {
+	t.Helper()
+	b := array.NewRecordBuilder(memory.DefaultAllocator, ordersSchema)
+	defer b.Release()
+	b.Field(0).(*array.Int32Builder).AppendValues(ids, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues(tenants, nil)
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+	rdr, err := array.NewRecordReader(ordersSchema, []arrow.RecordBatch{rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rdr
+}
+
+// rowIDBatch builds a batch of rowids and, if tenants is set, new tenant values.
+func rowIDBatch(ids []int64, tenants []string) arrow.RecordBatch {
+	fields := []arrow.Field{{Name: "rowid", Type: arrow.PrimitiveTypes.Int64}}
+	if tenants != nil {
+		fields = append(fields, arrow.Field{Name: "tenant", Type: arrow.BinaryTypes.String})
+	}
+	b := array.NewRecordBuilder(memory.DefaultAllocator, arrow.NewSchema(fields, nil))
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+	if tenants != nil {
+		b.Field(1).(*array.StringBuilder).AppendValues(tenants, nil)
+	}
+	return b.NewRecordBatch()
+}
+
+// scanIDs returns the sorted order ids returned by a scan of tbl.
+func scanIDs(t *testing.T, ctx context.Context, tbl catalog.Table, opts *catalog.ScanOptions) []int32 {
+	t.Helper()
+	rdr, err := tbl.Scan(ctx, opts)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	defer rdr.Release()
+
+	var ids []int32
+	for rdr.Next() {
+		rec := rdr.RecordBatch()
+		col := rec.Column(rec.Schema().FieldIndices("id")[0]).(*array.Int32)
+		ids = append(ids, col.Int32Values()...)
+	}
+	if err := rdr.Err(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestScan(t *testing.T) {
+	tbl, _ := newOrdersTable(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		opts *catalog.ScanOptions
+		want []int32
+	}{
+		{"tenant", tenantContext("a"), &catalog.ScanOptions{}, []int32{1, 3}},
+		{"projection and limit", tenantContext("b"), &catalog.ScanOptions{Columns: []string{"id"}, Limit: 1}, []int32{2, 4}},
+		{"admin", tenantContext("", "admin"), nil, []int32{1, 2, 3, 4}},
+		{"no tenant", tenantContext(""), &catalog.ScanOptions{}, nil},
+		{"unauthenticated", context.Background(), &catalog.ScanOptions{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanIDs(t, tt.ctx, tbl, tt.opts); !slices.Equal(got, tt.want) {
+				t.Errorf("ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingTable records the options of its scans.
+type recordingTable struct {
+	catalog.Table
+	opts *catalog.ScanOptions
+}
+
+func (r *recordingTable) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	r.opts = opts
+	return r.Table.Scan(ctx, opts)
+}
+
+func TestScanMergesFilter(t *testing.T) {
+	_, inner := newOrdersTable(t)
+	rec := &recordingTable{Table: inner}
+	tbl := Wrap(rec, PredicateProviderFunc(func(context.Context, *auth.Principal, string) ([]Predicate, error) {
+		return []Predicate{In("tenant", "a", "b"), Equal("id", 3)}, nil
+	}))
+
+	// id > 1, as sent by DuckDB
+	pushed := []byte(`{"filters": [{"expression_class": "BOUND_COMPARISON", "type": "COMPARE_GREATERTHAN",
+		"left": {"expression_class": "BOUND_COLUMN_REF", "type": "BOUND_COLUMN_REF", "return_type": {"id": "INTEGER"}, "binding": {"table_index": 0, "column_index": 0}},
+		"right": {"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "value": {"type": {"id": "INTEGER"}, "is_null": false, "value": 1}}}],
+		"column_binding_names_by_index": ["id"], "extra": {"kept": true}}`)
+
+	tests := []struct {
+		filter []byte
+		want   string
+	}{
+		{nil, "(tenant IN ('a', 'b')) AND (id = 3)"},
+		{pushed, "(id > 1) AND (tenant IN ('a', 'b')) AND (id = 3)"},
+	}
+	for _, tt := range tests {
+		if got := scanIDs(t, tenantContext("a"), tbl, &catalog.ScanOptions{Filter: tt.filter}); !slices.Equal(got, []int32{3}) {
+			t.Errorf("ids = %v, want [3]", got)
+		}
+		fp, err := filter.Parse(rec.opts.Filter)
+		if err != nil {
+			t.Fatalf("merged filter does not parse: %v", err)
+		}
+		if got := filter.NewDuckDBEncoder(nil).EncodeFilters(fp); got != tt.want {
+			t.Errorf("merged filter = %q, want %q", got, tt.want)
+		}
+		if tt.filter != nil && !bytes.Contains(rec.opts.Filter, []byte(`"extra":{"kept":true}`)) {
+			t.Errorf("merged filter %s dropped unknown fields", rec.opts.Filter)
+		}
+	}
+}
+
+func TestInsert(t *testing.T) {
+	tbl, inner := newOrdersTable(t)
+	ctx := tenantContext("a")
+
+	rows := ordersReader(t, []int32{5}, []string{"a"})
+	defer rows.Release()
+	if _, err := tbl.Insert(ctx, rows, nil); err != nil {
+		t.Fatalf("Insert of own row failed: %v", err)
+	}
+
+	rows = ordersReader(t, []int32{6, 7}, []string{"a", "b"})
+	defer rows.Release()
+	if _, err := tbl.Insert(ctx, rows, nil); !errors.Is(err, auth.ErrPermissionDenied) {
+		t.Errorf("Insert of foreign row error = %v, want ErrPermissionDenied", err)
+	}
+	if n := inner.NumRows(); n != 5 {
+		t.Errorf("table has %d rows, want 5", n)
+	}
+}
+
+func TestUpdateDelete(t *testing.T) {
+	tbl, inner := newOrdersTable(t)
+	ctx := tenantContext("a")
+
+	// Rows 1 and 3 belong to tenant a, rows 2 and 4 to tenant b.
+	denied := []struct {
+		name string
+		call func() error
+	}{
+		{"update foreign row", func() error {
+			rows := rowIDBatch([]int64{1, 2}, []string{"a", "a"})
+			defer rows.Release()
+			_, err := tbl.Update(ctx, rows, nil)
+			return err
+		}},
+		{"move row to other tenant", func() error {
+			rows := rowIDBatch([]int64{1}, []string{"b"})
+			defer rows.Release()
+			_, err := tbl.Update(ctx, rows, nil)
+			return err
+		}},
+		{"delete foreign row", func() error {
+			rows := rowIDBatch([]int64{3, 4}, nil)
+			defer rows.Release()
+			_, err := tbl.Delete(ctx, rows, nil)
+			return err
+		}},
+		{"delete without tenant", func() error {
+			rows := rowIDBatch([]int64{1}, nil)
+			defer rows.Release()
+			_, err := tbl.Delete(tenantContext(""), rows, nil)
+			return err
+		}},
+	}
+	for _, tt := range denied {
+		if err := tt.call(); !errors.Is(err, auth.ErrPermissionDenied) {
+			t.Errorf("%s: error = %v, want ErrPermissionDenied", tt.name, err)
+		}
+	}
+	if got := scanIDs(t, tenantContext("", "admin"), tbl, nil); !slices.Equal(got, []int32{1, 2, 3, 4}) {
+		t.Fatalf("rows after denied DML = %v, want [1 2 3 4]", got)
+	}
+
+	rows := rowIDBatch([]int64{3}, nil)
+	defer rows.Release()
+	if _, err := tbl.Delete(ctx, rows, nil); err != nil {
+		t.Fatalf("Delete of own row failed: %v", err)
+	}
+	if n := inner.NumRows(); n != 3 {
+		t.Errorf("table has %d rows after delete, want 3", n)
+	}
+}
+
+func TestPredicateErrors(t *testing.T) {
+	tests := map[string]Predicate{
+		"unknown column": Equal("missing", "a"),
+		"value type":     Equal("id", "a"),
+		"mixed values":   In("tenant", "a", -1),
+	}
+	for name, p := range tests {
+		if _, err := newRestriction(ordersSchema, []Predicate{p}); err == nil {
+			t.Errorf("%s: newRestriction should fail", name)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// CatalogOptions configures a Catalog.
+type CatalogOptions struct {
+	// Name is used for routing in multi-catalog servers and may be empty.
+	Name string
+
+	// RowID is the TableConfig.RowID of every base table, e.g. "rowid" for SQLite.
+	// Views are always read-only and have no rowid.
+	RowID string
+
+	// Allocator is used for scanned records. Defaults to memory.DefaultAllocator.
+	Allocator memory.Allocator
+}
+
+// Catalog is a catalog.Catalog exposing the tables and views of a *sql.DB.
+// Schemas and tables are listed from information_schema.tables (sqlite_schema for
+// SQLite, whose only schema is "main") on every lookup, so tables created while
+// the server runs are picked up. Tables are introspected when they are looked up.
+// All methods are goroutine-safe.
+type Catalog struct {
+	db      *sql.DB
+	dialect Dialect
+	opts    CatalogOptions
+}
+
+var (
+	_ catalog.Catalog      = (*Catalog)(nil)
+	_ catalog.NamedCatalog = (*Catalog)(nil)
+)
+
+// NewCatalog creates a catalog over the tables of db.
+func NewCatalog(db *sql.DB, dialect Dialect, opts CatalogOptions) (*Catalog, error) {
+	if !dialect.valid() {
+		return nil, fmt.Errorf("unknown SQL dialect %q", dialect)
+	}
+	return &Catalog{db: db, dialect: dialect, opts: opts}, nil
+}
+
+// Name implements catalog.NamedCatalog.
+func (c *Catalog) Name() string {
+	return c.opts.Name
+}
+
+// Schemas implements catalog.Catalog.
+// Schemas without tables or views are not listed.
+func (c *Catalog) Schemas(ctx context.Context) ([]catalog.Schema, error) {
+	entries, err := c.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]catalog.Schema, 0)
+	for i, e := range entries {
+		if i == 0 || e.schema != entries[i-1].schema {
+			result = append(result, &Schema{catalog: c, name: e.schema})
+		}
+	}
+	return result, nil
+}
+
+// Schema implements catalog.Catalog.
+func (c *Catalog) Schema(ctx context.Context, name string) (catalog.Schema, error) {
+	entries, err := c.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.schema == name {
+			return &Schema{catalog: c, name: name}, nil
+		}
+	}
+	return nil, nil // Not found, not an error
+}
+
+// tableEntry is a row of the dialect tables query.
+type tableEntry struct {
+	schema string
+	name   string
+	base   bool
+}
+
+// tables lists the tables and views of the database ordered by schema and name.
+func (c *Catalog) tables(ctx context.Context) ([]tableEntry, error) {
+	rows, err := c.db.QueryContext(ctx, c.dialect.tablesQuery())
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []tableEntry
+	for rows.Next() {
+		var e tableEntry
+		if err := rows.Scan(&e.schema, &e.name, &e.base); err != nil {
+			return nil, fmt.Errorf("list tables: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	return entries, nil
+}
+
+// Schema is a database schema of a Catalog.
+type Schema struct {
+	catalog *Catalog
+	name    string
+}
+
+var _ catalog.Schema = (*Schema)(nil)
+
+// Name implements catalog.Schema.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// Comment implements catalog.Schema.
+func (s *Schema) Comment() string {
+	return ""
+}
+
+// Tables implements catalog.Schema.
+func (s *Schema) Tables(ctx context.Context) ([]catalog.Table, error) {
+	entries, err := s.catalog.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]catalog.Table, 0, len(entries))
+	for _, e := range entries {
+		if e.schema != s.name {
+			continue
+		}
+		t, err := s.newTable(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// Table implements catalog.Schema.
+func (s *Schema) Table(ctx context.Context, name string) (catalog.Table, error) {
+	entries, err := s.catalog.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.schema == s.name && e.name == name {
+			return s.newTable(ctx, e)
+		}
+	}
+	return nil, nil // Not found, not an error
+}
+
+// ScalarFunctions implements catalog.Schema.
+func (s *Schema) ScalarFunctions(ctx context.Context) ([]catalog.ScalarFunction, error) {
+	return []catalog.ScalarFunction{}, nil
+}
+
+// TableFunctions implements catalog.Schema.
+func (s *Schema) TableFunctions(ctx context.Context) ([]catalog.TableFunction, error) {
+	return []catalog.TableFunction{}, nil
+}
+
+// TableFunctionsInOut implements catalog.Schema.
+func (s *Schema) TableFunctionsInOut(ctx context.Context) ([]catalog.TableFunctionInOut, error) {
+	return []catalog.TableFunctionInOut{}, nil
+}
+
+// newTable creates the Table of a listed table or view.
+func (s *Schema) newTable(ctx context.Context, e tableEntry) (*Table, error) {
+	cfg := TableConfig{
+		Name:      e.name,
+		Table:     e.schema + "." + e.name,
+		Allocator: s.catalog.opts.Allocator,
+	}
+	if e.base {
+		cfg.RowID = s.catalog.opts.RowID
+	}
+	return NewTable(ctx, s.catalog.db, s.catalog.dialect, cfg)
+}
@@ -0,0 +1,91 @@
+package sqldb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+// Dialect identifies the SQL dialect of the database behind a *sql.DB.
+// It selects identifier quoting, placeholders, the filter encoder used for
+// WHERE clauses and the catalog queries.
+type Dialect string
+
+const (
+	// SQLite is the SQLite dialect (e.g. modernc.org/sqlite or mattn/go-sqlite3).
+	SQLite Dialect = "sqlite"
+	// Postgres is the PostgreSQL dialect (e.g. pgx or lib/pq).
+	Postgres Dialect = "postgres"
+	// MySQL is the MySQL and MariaDB dialect (go-sql-driver/mysql).
+	MySQL Dialect = "mysql"
+	// DuckDB is the DuckDB dialect (duckdb-go).
+	DuckDB Dialect = "duckdb"
+)
+
+// valid reports whether d is a known dialect.
+func (d Dialect) valid() bool {
+	switch d {
+	case SQLite, Postgres, MySQL, DuckDB:
+		return true
+	}
+	return false
+}
+
+// quoteIdentifier quotes a single identifier.
+func (d Dialect) quoteIdentifier(name string) string {
+	if d == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteName quotes a possibly schema-qualified name such as "sales.orders".
+func (d Dialect) quoteName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = d.quoteIdentifier(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// placeholder returns the placeholder of the n-th (1-based) statement argument.
+func (d Dialect) placeholder(n int) string {
+	if d == Postgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// returning reports whether INSERT, UPDATE and DELETE support a RETURNING clause.
+func (d Dialect) returning() bool {
+	return d != MySQL
+}
+
+// encoder returns the filter encoder of the dialect.
+func (d Dialect) encoder(opts *filter.EncoderOptions) filter.ParameterizedEncoder {
+	switch d {
+	case Postgres:
+		return filter.NewPostgresEncoder(opts)
+	case MySQL:
+		return filter.NewMySQLEncoder(opts)
+	case DuckDB:
+		return filter.NewDuckDBEncoder(opts)
+	default:
+		return filter.NewSQLiteEncoder(opts)
+	}
+}
+
+// tablesQuery returns a query listing (schema, table, is base table) rows of
+// all user tables and views.
+func (d Dialect) tablesQuery() string {
+	if d == SQLite {
+		return `SELECT 'main', name, type = 'table' FROM sqlite_schema
+WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%'
+ORDER BY name`
+	}
+	return `SELECT table_schema, table_name, table_type = 'BASE TABLE' FROM information_schema.tables
+WHERE table_type IN ('BASE TABLE', 'VIEW')
+AND table_schema NOT IN ('information_schema', 'pg_catalog', 'mysql', 'performance_schema', 'sys')
+ORDER BY table_schema, table_name`
+}
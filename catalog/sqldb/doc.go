@@ -0,0 +1,55 @@
+// Package sqldb exposes tables, views and queries of a database/sql database
+// as Airport tables.
+//
+// A Table wraps one source table or a SELECT query; its Arrow schema is
+// introspected from the result columns reported by the driver. A Catalog lists
+// the tables and views of the database from information_schema.tables
+// (sqlite_schema for SQLite) and creates a Table for each of them.
+// The Dialect selects identifier quoting, placeholders and the filter encoder.
+//
+// # Basic Usage
+//
+//	db, err := sql.Open("sqlite", "app.db")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	cat, err := sqldb.NewCatalog(db, sqldb.SQLite, sqldb.CatalogOptions{RowID: "rowid"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	err = airport.NewServer(grpcServer, airport.ServerConfig{Catalog: cat})
+//
+// A single table or query can be served through a static catalog:
+//
+//	orders, err := sqldb.NewTable(ctx, db, sqldb.Postgres, sqldb.TableConfig{
+//		Table: "sales.orders",
+//		RowID: "order_id",
+//	})
+//
+// # Scans
+//
+// Scans select only the columns listed in ScanOptions.Columns; the other columns
+// of the returned records are null. The parsed ScanOptions.Filter is encoded by
+// the filter package encoder of the dialect into the WHERE clause, with constants
+// bound as query arguments. Expressions the encoder does not support are left to
+// DuckDB, which re-applies the filter. ScanOptions.Limit is pushed down only for
+// scans without a filter.
+//
+// # Data Modification
+//
+// Tables created from TableConfig.Table implement catalog.InsertableTable.
+// With TableConfig.RowID, an integer expression identifying source rows, they also
+// implement catalog.UpdatableBatchTable and catalog.DeletableBatchTable and expose
+// the expression as the rowid pseudo-column. Every call runs parameterized
+// statements, one per row, in a single transaction. RETURNING is supported for
+// dialects with a RETURNING clause (all but MySQL).
+//
+// # Types
+//
+// Column types are mapped by their database type name: integer, floating point,
+// boolean, DECIMAL/NUMERIC, DATE, TIME, TIMESTAMP and binary types map to the
+// matching Arrow types, other types to VARCHAR. SQLite INTEGER and REAL map to
+// BIGINT and DOUBLE.
+package sqldb
@@ -0,0 +1,128 @@
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// rowsReader is an array.RecordReader over the result of a scan query.
+// Records have the full table schema; fields not selected by the query are null.
+type rowsReader struct {
+	refCount atomic.Int64
+
+	alloc     memory.Allocator
+	schema    *arrow.Schema
+	rows      *sql.Rows
+	fields    []int // schema field of each result column
+	batchSize int
+
+	cur  arrow.RecordBatch
+	err  error
+	done bool
+}
+
+// newRowsReader creates a reader over rows, whose columns hold the schema fields
+// listed in fields. A query selecting no field has one ignored column.
+func newRowsReader(alloc memory.Allocator, schema *arrow.Schema, rows *sql.Rows, fields []int, batchSize int) *rowsReader {
+	r := &rowsReader{
+		alloc:     alloc,
+		schema:    schema,
+		rows:      rows,
+		fields:    fields,
+		batchSize: batchSize,
+	}
+	r.refCount.Store(1)
+	return r
+}
+
+func (r *rowsReader) Retain() {
+	r.refCount.Add(1)
+}
+
+func (r *rowsReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		r.rows.Close()
+	}
+}
+
+func (r *rowsReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *rowsReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+	if r.done || r.err != nil {
+		return false
+	}
+
+	b := array.NewRecordBuilder(r.alloc, r.schema)
+	defer b.Release()
+
+	ncols := max(len(r.fields), 1)
+	values := make([]any, ncols)
+	dest := make([]any, ncols)
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	n := 0
+	for n < r.batchSize {
+		if !r.rows.Next() {
+			r.done = true
+			if r.err = r.rows.Err(); r.err == nil {
+				r.err = r.rows.Close()
+			}
+			break
+		}
+		if r.err = r.rows.Scan(dest...); r.err != nil {
+			return false
+		}
+		for i, f := range r.fields {
+			if err := appendValue(b.Field(f), values[i]); err != nil {
+				r.err = fmt.Errorf("column %q: %w", r.schema.Field(f).Name, err)
+				return false
+			}
+		}
+		n++
+	}
+	if r.err != nil || n == 0 {
+		return false
+	}
+
+	read := make([]bool, r.schema.NumFields())
+	for _, f := range r.fields {
+		read[f] = true
+	}
+	for i, ok := range read {
+		if !ok {
+			b.Field(i).AppendNulls(n)
+		}
+	}
+	r.cur = b.NewRecordBatch()
+	return true
+}
+
+func (r *rowsReader) RecordBatch() arrow.RecordBatch {
+	return r.cur
+}
+
+// Deprecated: Use [rowsReader.RecordBatch] instead.
+func (r *rowsReader) Record() arrow.RecordBatch {
+	return r.cur
+}
+
+func (r *rowsReader) Err() error {
+	return r.err
+}
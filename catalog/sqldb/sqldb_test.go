@@ -0,0 +1,369 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	_ "modernc.org/sqlite"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// openTestDB opens an in-memory SQLite database with the tables
+//
+//	users(id INTEGER PRIMARY KEY, name TEXT, score REAL, active BOOLEAN, created TIMESTAMP, data BLOB)
+//	active_users view
+//
+// and users 1..5 named "user-<id>".
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to ":memory:" is a separate database.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	stmts := []string{
+		`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, score REAL,
+			active BOOLEAN, created TIMESTAMP, data BLOB)`,
+		`CREATE VIEW active_users AS SELECT id, name FROM users WHERE active`,
+	}
+	for id := 1; id <= 5; id++ {
+		stmts = append(stmts, fmt.Sprintf(
+			`INSERT INTO users VALUES (%d, 'user-%d', %d.5, %d, '2024-01-0%d 10:00:00', x'0%d')`,
+			id, id, id, id%2, id, id))
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	return db
+}
+
+func newUsersTable(t *testing.T, db *sql.DB, alloc memory.Allocator) *Table {
+	t.Helper()
+	tbl, err := NewTable(context.Background(), db, SQLite, TableConfig{
+		Table:     "users",
+		RowID:     "id",
+		Allocator: alloc,
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	return tbl
+}
+
+// scanRows scans tbl and returns the rows formatted as "col=value" lists.
+func scanRows(t *testing.T, tbl catalog.Table, opts *catalog.ScanOptions) []string {
+	t.Helper()
+
+	reader, err := tbl.Scan(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	defer reader.Release()
+
+	if !reader.Schema().Equal(tbl.ArrowSchema(nil)) {
+		t.Fatalf("reader schema %v, want %v", reader.Schema(), tbl.ArrowSchema(nil))
+	}
+	return formatRows(reader)
+}
+
+// formatRows formats the rows of reader, skipping null values.
+func formatRows(reader array.RecordReader) []string {
+	var rows []string
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		for r := range int(rec.NumRows()) {
+			var cols []string
+			for c, col := range rec.Columns() {
+				if col.IsValid(r) {
+					cols = append(cols, rec.ColumnName(c)+"="+col.ValueStr(r))
+				}
+			}
+			rows = append(rows, strings.Join(cols, " "))
+		}
+	}
+	return rows
+}
+
+// filterJSON returns a filter pushdown document with one comparison of
+// the BIGINT column "id" with a constant.
+func filterJSON(op string, value int64) []byte {
+	return []byte(fmt.Sprintf(`{"filters": [{"expression_class": "BOUND_COMPARISON", "type": %q, "alias": "",
+		"left": {"expression_class": "BOUND_COLUMN_REF", "type": "BOUND_COLUMN_REF", "alias": "",
+			"return_type": {"id": "BIGINT"}, "binding": {"table_index": 0, "column_index": 0}, "depth": 0},
+		"right": {"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "alias": "",
+			"value": {"type": {"id": "BIGINT"}, "is_null": false, "value": %d}}}],
+		"column_binding_names_by_index": ["id"]}`, op, value))
+}
+
+func TestNewTableSchema(t *testing.T) {
+	db := openTestDB(t)
+	tbl := newUsersTable(t, db, nil)
+
+	if tbl.Name() != "users" {
+		t.Errorf("Name() = %q, want users", tbl.Name())
+	}
+	want := []struct {
+		name string
+		typ  arrow.DataType
+	}{
+		{"rowid", arrow.PrimitiveTypes.Int64},
+		{"id", arrow.PrimitiveTypes.Int64},
+		{"name", arrow.BinaryTypes.String},
+		{"score", arrow.PrimitiveTypes.Float64},
+		{"active", arrow.FixedWidthTypes.Boolean},
+		{"created", &arrow.TimestampType{Unit: arrow.Microsecond}},
+		{"data", arrow.BinaryTypes.Binary},
+	}
+	schema := tbl.ArrowSchema(nil)
+	if schema.NumFields() != len(want) {
+		t.Fatalf("schema = %v, want %d fields", schema, len(want))
+	}
+	for i, w := range want {
+		f := schema.Field(i)
+		if f.Name != w.name || !arrow.TypeEqual(f.Type, w.typ) {
+			t.Errorf("field %d = %s %s, want %s %s", i, f.Name, f.Type, w.name, w.typ)
+		}
+	}
+	if catalog.FindRowIDColumn(schema) != 0 {
+		t.Errorf("rowid column not marked")
+	}
+
+	if _, err := NewTable(context.Background(), db, SQLite, TableConfig{Query: "SELECT 1"}); err == nil {
+		t.Errorf("query table without Name should fail")
+	}
+	if _, err := NewTable(context.Background(), db, "oracle", TableConfig{Table: "users"}); err == nil {
+		t.Errorf("unknown dialect should fail")
+	}
+}
+
+func TestScan(t *testing.T) {
+	alloc := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer alloc.AssertSize(t, 0)
+
+	db := openTestDB(t)
+	tbl := newUsersTable(t, db, alloc)
+
+	tests := []struct {
+		name string
+		opts *catalog.ScanOptions
+		want []string
+	}{
+		{
+			name: "all columns",
+			opts: &catalog.ScanOptions{Limit: 1},
+			want: []string{"rowid=1 id=1 name=user-1 score=1.5 active=true created=2024-01-01T10:00:00Z data=AQ=="},
+		},
+		{
+			name: "projection",
+			opts: &catalog.ScanOptions{Columns: []string{"name", "rowid"}, BatchSize: 2},
+			want: []string{"rowid=1 name=user-1", "rowid=2 name=user-2", "rowid=3 name=user-3",
+				"rowid=4 name=user-4", "rowid=5 name=user-5"},
+		},
+		{
+			name: "filter",
+			opts: &catalog.ScanOptions{Columns: []string{"id"}, Filter: filterJSON("COMPARE_GREATERTHAN", 3)},
+			want: []string{"id=4", "id=5"},
+		},
+		{
+			name: "filter ignores limit",
+			opts: &catalog.ScanOptions{Columns: []string{"id"}, Filter: filterJSON("COMPARE_LESSTHAN", 3), Limit: 1},
+			want: []string{"id=1", "id=2"},
+		},
+		{
+			name: "no columns",
+			opts: &catalog.ScanOptions{Columns: []string{"unknown"}, Limit: 2},
+			want: []string{"", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scanRows(t, tbl, tt.opts)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryTable(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	tbl, err := NewTable(ctx, db, SQLite, TableConfig{
+		Name:  "top",
+		Query: "SELECT id, upper(name) AS label FROM users WHERE score > 3",
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	got := scanRows(t, tbl, &catalog.ScanOptions{Filter: filterJSON("COMPARE_NOTEQUAL", 4)})
+	want := []string{"id=3 label=USER-3", "id=5 label=USER-5"}
+	if !slices.Equal(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+
+	_, err = tbl.Insert(ctx, nil, nil)
+	if !errors.Is(err, catalog.ErrUnimplemented) {
+		t.Errorf("Insert error = %v, want ErrUnimplemented", err)
+	}
+	if _, err := NewTable(ctx, db, SQLite, TableConfig{Name: "q", Query: "SELECT 1", RowID: "id"}); err == nil {
+		t.Errorf("RowID with Query should fail")
+	}
+}
+
+func TestDML(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	tbl := newUsersTable(t, db, nil)
+	returning := &catalog.DMLOptions{Returning: true, ReturningColumns: []string{"id", "name"}}
+
+	// INSERT with RETURNING; id is assigned by SQLite.
+	insertSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+	}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, insertSchema)
+	b.Field(0).(*array.StringBuilder).AppendValues([]string{"new-1", "new-2"}, nil)
+	b.Field(1).(*array.Float32Builder).AppendValues([]float32{1, 0}, []bool{true, false})
+	rec := b.NewRecordBatch()
+	b.Release()
+	reader, _ := array.NewRecordReader(insertSchema, []arrow.RecordBatch{rec})
+	rec.Release()
+
+	res, err := tbl.Insert(ctx, reader, returning)
+	reader.Release()
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if res.AffectedRows != 2 {
+		t.Errorf("inserted %d rows, want 2", res.AffectedRows)
+	}
+	got := formatRows(res.ReturningData)
+	res.ReturningData.Release()
+	if want := []string{"id=6 name=new-1", "id=7 name=new-2"}; !slices.Equal(got, want) {
+		t.Errorf("insert returned %q, want %q", got, want)
+	}
+
+	// UPDATE by rowid.
+	updateSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "rowid", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	b = array.NewRecordBuilder(memory.DefaultAllocator, updateSchema)
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{2, 7, 99}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"two", "seven", "none"}, nil)
+	rec = b.NewRecordBatch()
+	b.Release()
+	res, err = tbl.Update(ctx, rec, nil)
+	rec.Release()
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if res.AffectedRows != 2 || res.ReturningData != nil {
+		t.Errorf("Update = %d rows, returning %v; want 2 rows, nil", res.AffectedRows, res.ReturningData)
+	}
+
+	// DELETE by rowid with RETURNING.
+	deleteSchema := arrow.NewSchema([]arrow.Field{{Name: "rowid", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+	b = array.NewRecordBuilder(memory.DefaultAllocator, deleteSchema)
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 7}, nil)
+	rec = b.NewRecordBatch()
+	res, err = tbl.Delete(ctx, rec, returning)
+	rec.Release()
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	got = formatRows(res.ReturningData)
+	res.ReturningData.Release()
+	if want := []string{"id=1 name=user-1", "id=7 name=seven"}; res.AffectedRows != 2 || !slices.Equal(got, want) {
+		t.Errorf("Delete = %d rows %q, want 2 rows %q", res.AffectedRows, got, want)
+	}
+
+	// Null rowids are rejected.
+	b.Field(0).AppendNull()
+	rec = b.NewRecordBatch()
+	b.Release()
+	_, err = tbl.Delete(ctx, rec, nil)
+	rec.Release()
+	if !errors.Is(err, catalog.ErrNullRowID) {
+		t.Errorf("Delete with null rowid error = %v, want ErrNullRowID", err)
+	}
+
+	got = scanRows(t, tbl, &catalog.ScanOptions{Columns: []string{"name"}})
+	if want := []string{"name=two", "name=user-3", "name=user-4", "name=user-5", "name=new-1"}; !slices.Equal(got, want) {
+		t.Errorf("rows after DML = %q, want %q", got, want)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	cat, err := NewCatalog(db, SQLite, CatalogOptions{Name: "app", RowID: "rowid"})
+	if err != nil {
+		t.Fatalf("NewCatalog failed: %v", err)
+	}
+	if cat.Name() != "app" {
+		t.Errorf("Name() = %q, want app", cat.Name())
+	}
+
+	schemas, err := cat.Schemas(ctx)
+	if err != nil || len(schemas) != 1 || schemas[0].Name() != "main" {
+		t.Fatalf("Schemas() = %v, %v; want [main]", schemas, err)
+	}
+	if sc, err := cat.Schema(ctx, "other"); sc != nil || err != nil {
+		t.Errorf("Schema(other) = %v, %v; want nil, nil", sc, err)
+	}
+
+	tables, err := schemas[0].Tables(ctx)
+	if err != nil {
+		t.Fatalf("Tables failed: %v", err)
+	}
+	var names []string
+	for _, tbl := range tables {
+		names = append(names, tbl.Name())
+	}
+	if want := []string{"active_users", "users"}; !slices.Equal(names, want) {
+		t.Errorf("tables = %q, want %q", names, want)
+	}
+
+	view, err := schemas[0].Table(ctx, "active_users")
+	if err != nil || view == nil {
+		t.Fatalf("Table(active_users) = %v, %v", view, err)
+	}
+	if catalog.FindRowIDColumn(view.ArrowSchema(nil)) >= 0 {
+		t.Errorf("view should have no rowid column")
+	}
+	got := scanRows(t, view, nil)
+	if want := []string{"id=1 name=user-1", "id=3 name=user-3", "id=5 name=user-5"}; !slices.Equal(got, want) {
+		t.Errorf("view rows = %q, want %q", got, want)
+	}
+
+	users, err := schemas[0].Table(ctx, "users")
+	if err != nil || users == nil {
+		t.Fatalf("Table(users) = %v, %v", users, err)
+	}
+	got = scanRows(t, users, &catalog.ScanOptions{Columns: []string{"rowid"}, Filter: filterJSON("COMPARE_EQUAL", 2)})
+	if want := []string{"rowid=2"}; !slices.Equal(got, want) {
+		t.Errorf("users rows = %q, want %q", got, want)
+	}
+
+	if tbl, err := schemas[0].Table(ctx, "missing"); tbl != nil || err != nil {
+		t.Errorf("Table(missing) = %v, %v; want nil, nil", tbl, err)
+	}
+}
@@ -0,0 +1,546 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+// RowIDColumn is the name of the rowid pseudo-column of tables with TableConfig.RowID.
+const RowIDColumn = "rowid"
+
+// defaultBatchSize is the number of rows per record when ScanOptions.BatchSize is unset.
+const defaultBatchSize = 8192
+
+// TableConfig configures a Table.
+// Exactly one of Table and Query must be set.
+type TableConfig struct {
+	// Name is the table name exposed to clients.
+	// Defaults to the unqualified name of Table; required with Query.
+	Name string
+
+	// Comment is the optional table documentation.
+	Comment string
+
+	// Table is the source table or view, optionally schema-qualified ("sales.orders").
+	Table string
+
+	// Query is a SELECT statement used as the source of a read-only table.
+	Query string
+
+	// RowID is a SQL expression of a unique integer identifier of the source rows,
+	// such as "rowid" for SQLite or the name of an integer primary key column.
+	// When set, the table exposes it as the rowid pseudo-column and supports
+	// UPDATE and DELETE. Requires Table.
+	RowID string
+
+	// Allocator is used for scanned records. Defaults to memory.DefaultAllocator.
+	Allocator memory.Allocator
+}
+
+// Table is a catalog.Table backed by a table, view or query of a *sql.DB.
+// Scans run a SELECT with the requested columns and the pushed down filter;
+// INSERT, UPDATE and DELETE run parameterized statements in a transaction per call.
+// The Arrow schema is introspected once, when the table is created.
+type Table struct {
+	db      *sql.DB
+	dialect Dialect
+	alloc   memory.Allocator
+
+	name    string
+	comment string
+	source  string // FROM clause item
+	table   string // quoted table name, empty for query sources
+	rowID   string
+	schema  *arrow.Schema
+}
+
+var (
+	_ catalog.Table               = (*Table)(nil)
+	_ catalog.InsertableTable     = (*Table)(nil)
+	_ catalog.UpdatableBatchTable = (*Table)(nil)
+	_ catalog.DeletableBatchTable = (*Table)(nil)
+)
+
+// NewTable creates a table over cfg.Table or cfg.Query of db and introspects its columns.
+func NewTable(ctx context.Context, db *sql.DB, dialect Dialect, cfg TableConfig) (*Table, error) {
+	if !dialect.valid() {
+		return nil, fmt.Errorf("unknown SQL dialect %q", dialect)
+	}
+	if (cfg.Table == "") == (cfg.Query == "") {
+		return nil, errors.New("exactly one of Table and Query must be set")
+	}
+	if cfg.RowID != "" && cfg.Table == "" {
+		return nil, errors.New("RowID requires Table")
+	}
+
+	t := &Table{
+		db:      db,
+		dialect: dialect,
+		alloc:   cfg.Allocator,
+		name:    cfg.Name,
+		comment: cfg.Comment,
+		rowID:   cfg.RowID,
+	}
+	if t.alloc == nil {
+		t.alloc = memory.DefaultAllocator
+	}
+	if cfg.Table != "" {
+		t.table = dialect.quoteName(cfg.Table)
+		t.source = t.table
+		if t.name == "" {
+			t.name = cfg.Table[strings.LastIndexByte(cfg.Table, '.')+1:]
+		}
+	} else {
+		t.source = "(" + cfg.Query + ") AS q"
+	}
+	if t.name == "" {
+		return nil, errors.New("Name is required for query tables")
+	}
+
+	schema, err := t.introspect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("table %q: %w", t.name, err)
+	}
+	t.schema = schema
+	return t, nil
+}
+
+// introspect builds the Arrow schema from the result columns of an empty SELECT.
+func (t *Table) introspect(ctx context.Context) (*arrow.Schema, error) {
+	rows, err := t.db.QueryContext(ctx, "SELECT * FROM "+t.source+" WHERE 1 = 0")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cts, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]arrow.Field, 0, len(cts)+1)
+	if t.rowID != "" {
+		fields = append(fields, arrow.Field{
+			Name:     RowIDColumn,
+			Type:     arrow.PrimitiveTypes.Int64,
+			Metadata: arrow.NewMetadata([]string{"is_rowid"}, []string{"true"}),
+		})
+	}
+	for _, ct := range cts {
+		if t.rowID != "" && ct.Name() == RowIDColumn {
+			return nil, fmt.Errorf("column %q is reserved for row identifiers", RowIDColumn)
+		}
+		nullable, ok := ct.Nullable()
+		fields = append(fields, arrow.Field{
+			Name:     ct.Name(),
+			Type:     t.dialect.arrowType(ct),
+			Nullable: nullable || !ok,
+		})
+	}
+	return arrow.NewSchema(fields, nil), rows.Err()
+}
+
+// Name implements catalog.Table.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Comment implements catalog.Table.
+func (t *Table) Comment() string {
+	return t.comment
+}
+
+// ArrowSchema implements catalog.Table.
+// The returned schema includes the rowid pseudo-column if the table has one.
+func (t *Table) ArrowSchema(columns []string) *arrow.Schema {
+	return catalog.ProjectSchema(t.schema, columns)
+}
+
+// Scan implements catalog.Table.
+// Only the columns in opts.Columns are selected; the other columns of the returned
+// records are null. The filter is encoded with the dialect encoder into the WHERE
+// clause, with constants bound as arguments. Because unsupported filter expressions
+// are dropped from the WHERE clause, opts.Limit is pushed down only without a filter.
+func (t *Table) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	if opts == nil {
+		opts = &catalog.ScanOptions{}
+	}
+
+	fields := t.fieldIndices(opts.Columns)
+	exprs := make([]string, 0, len(fields))
+	for _, i := range fields {
+		exprs = append(exprs, t.selectExpr(i))
+	}
+	if len(exprs) == 0 {
+		exprs = append(exprs, "1")
+	}
+
+	query := "SELECT " + strings.Join(exprs, ", ") + " FROM " + t.source
+	where, args := t.where(opts.Filter)
+	if where != "" {
+		query += " WHERE " + where
+	}
+	if opts.Limit > 0 && len(opts.Filter) == 0 {
+		query += fmt.Sprintf(" LIMIT %d", opts.Limit)
+	}
+
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("scan %q: %w", t.name, err)
+	}
+
+	batchSize := int(opts.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return newRowsReader(t.alloc, t.schema, rows, fields, batchSize), nil
+}
+
+// Insert implements catalog.InsertableTable.
+// Input columns are matched to table columns by name; a rowid input column is ignored.
+// All rows are inserted in one transaction.
+func (t *Table) Insert(ctx context.Context, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	if t.table == "" {
+		return nil, fmt.Errorf("insert into query table %q: %w", t.name, catalog.ErrUnimplemented)
+	}
+	ret, err := t.newReturning(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer ret.release()
+
+	var affected int64
+	err = t.inTx(ctx, func(tx *sql.Tx) error {
+		for rows.Next() {
+			rec := rows.RecordBatch()
+			cols, names, err := t.inputColumns(rec.Schema())
+			if err != nil {
+				return err
+			}
+			if len(cols) == 0 {
+				return errors.New("insert without columns")
+			}
+
+			placeholders := make([]string, len(cols))
+			for i := range placeholders {
+				placeholders[i] = t.dialect.placeholder(i + 1)
+			}
+			stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)%s",
+				t.table, strings.Join(names, ", "), strings.Join(placeholders, ", "), ret.clause())
+
+			n, err := t.execRows(ctx, tx, stmt, rec, cols, ret)
+			if err != nil {
+				return err
+			}
+			affected += n
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert into %q: %w", t.name, err)
+	}
+	return ret.result(affected), nil
+}
+
+// Update implements catalog.UpdatableBatchTable.
+// Only the columns present in rows are modified; rows are identified by TableConfig.RowID.
+func (t *Table) Update(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	rowIDIdx, err := t.checkRowIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := t.newReturning(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer ret.release()
+
+	cols, names, err := t.inputColumns(rows.Schema())
+	if err != nil {
+		return nil, fmt.Errorf("update %q: %w", t.name, err)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("update %q: no columns to update", t.name)
+	}
+	set := make([]string, len(cols))
+	for i, name := range names {
+		set[i] = name + " = " + t.dialect.placeholder(i+1)
+	}
+	stmt := fmt.Sprintf("UPDATE %s SET %s WHERE %s = %s%s",
+		t.table, strings.Join(set, ", "), t.rowID, t.dialect.placeholder(len(cols)+1), ret.clause())
+
+	var affected int64
+	err = t.inTx(ctx, func(tx *sql.Tx) error {
+		affected, err = t.execRows(ctx, tx, stmt, rows, append(cols, rowIDIdx), ret)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update %q: %w", t.name, err)
+	}
+	return ret.result(affected), nil
+}
+
+// Delete implements catalog.DeletableBatchTable.
+// Rows are identified by TableConfig.RowID.
+func (t *Table) Delete(ctx context.Context, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	rowIDIdx, err := t.checkRowIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := t.newReturning(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer ret.release()
+
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE %s = %s%s",
+		t.table, t.rowID, t.dialect.placeholder(1), ret.clause())
+
+	var affected int64
+	err = t.inTx(ctx, func(tx *sql.Tx) error {
+		affected, err = t.execRows(ctx, tx, stmt, rows, []int{rowIDIdx}, ret)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete from %q: %w", t.name, err)
+	}
+	return ret.result(affected), nil
+}
+
+// fieldIndices returns the schema indices of the named columns in schema order,
+// or of all columns if names is empty. Unknown names are ignored.
+func (t *Table) fieldIndices(names []string) []int {
+	indices := make([]int, 0, t.schema.NumFields())
+	for i, f := range t.schema.Fields() {
+		if len(names) == 0 || slices.Contains(names, f.Name) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// selectExpr returns the SELECT expression of schema field i.
+func (t *Table) selectExpr(i int) string {
+	if t.rowID != "" && i == 0 {
+		return t.rowID
+	}
+	return t.dialect.quoteIdentifier(t.schema.Field(i).Name)
+}
+
+// where encodes a serialized filter into a WHERE clause body and its arguments.
+// Filters that cannot be parsed are not pushed down.
+func (t *Table) where(data []byte) (string, []any) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	fp, err := filter.Parse(data)
+	if err != nil {
+		return "", nil
+	}
+	var opts *filter.EncoderOptions
+	if t.rowID != "" {
+		opts = &filter.EncoderOptions{ColumnExpressions: map[string]string{RowIDColumn: t.rowID}}
+	}
+	return t.dialect.encoder(opts).EncodeFiltersWithArgs(fp)
+}
+
+// inputColumns matches the columns of a DML input schema to table columns.
+// It returns the input column indices and the quoted table column names,
+// skipping the rowid column.
+func (t *Table) inputColumns(schema *arrow.Schema) ([]int, []string, error) {
+	rowIDIdx := catalog.FindRowIDColumn(schema)
+	var (
+		cols  []int
+		names []string
+	)
+	for i, f := range schema.Fields() {
+		if i == rowIDIdx {
+			continue
+		}
+		if !t.schema.HasField(f.Name) {
+			return nil, nil, fmt.Errorf("column %q: %w", f.Name, catalog.ErrNotFound)
+		}
+		cols = append(cols, i)
+		names = append(names, t.dialect.quoteIdentifier(f.Name))
+	}
+	return cols, names, nil
+}
+
+// checkRowIDs validates that the table supports row addressing and that rows
+// has a rowid column without nulls. It returns the index of the rowid column.
+func (t *Table) checkRowIDs(rows arrow.RecordBatch) (int, error) {
+	if t.rowID == "" {
+		return -1, fmt.Errorf("table %q has no rowid: %w", t.name, catalog.ErrUnimplemented)
+	}
+	idx := catalog.FindRowIDColumn(rows.Schema())
+	if idx < 0 {
+		return -1, errors.New("rowid column required")
+	}
+	if rows.Column(idx).NullN() > 0 {
+		return -1, catalog.ErrNullRowID
+	}
+	return idx, nil
+}
+
+// execRows executes stmt once per row of rec, binding the values of the columns
+// cols in order, and returns the number of affected rows.
+func (t *Table) execRows(ctx context.Context, tx *sql.Tx, stmt string, rec arrow.RecordBatch, cols []int, ret *returning) (int64, error) {
+	prepared, err := tx.PrepareContext(ctx, stmt)
+	if err != nil {
+		return 0, err
+	}
+	defer prepared.Close()
+
+	args := make([]any, len(cols))
+	var affected int64
+	for row := range int(rec.NumRows()) {
+		for i, c := range cols {
+			if args[i], err = driverValue(rec.Column(c), row); err != nil {
+				return 0, fmt.Errorf("column %q: %w", rec.ColumnName(c), err)
+			}
+		}
+
+		if ret != nil {
+			rows, err := prepared.QueryContext(ctx, args...)
+			if err != nil {
+				return 0, err
+			}
+			n, err := ret.append(rows)
+			if err != nil {
+				return 0, err
+			}
+			affected += n
+			continue
+		}
+
+		res, err := prepared.ExecContext(ctx, args...)
+		if err != nil {
+			return 0, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		affected += n
+	}
+	return affected, nil
+}
+
+// inTx runs fn in a transaction that is committed if fn succeeds.
+func (t *Table) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// returning collects the rows of a RETURNING clause.
+// A nil *returning means RETURNING was not requested.
+type returning struct {
+	table  *Table
+	fields []int
+	schema *arrow.Schema
+	b      *array.RecordBuilder
+}
+
+// newReturning prepares RETURNING data collection for opts.
+// The returned columns are opts.ReturningColumns, or all columns except rowid.
+func (t *Table) newReturning(opts *catalog.DMLOptions) (*returning, error) {
+	if opts == nil || !opts.Returning {
+		return nil, nil
+	}
+	if !t.dialect.returning() {
+		return nil, fmt.Errorf("RETURNING with dialect %s: %w", t.dialect, catalog.ErrUnimplemented)
+	}
+
+	var fields []int
+	for i, f := range t.schema.Fields() {
+		if t.rowID != "" && i == 0 {
+			continue
+		}
+		if len(opts.ReturningColumns) == 0 || slices.Contains(opts.ReturningColumns, f.Name) {
+			fields = append(fields, i)
+		}
+	}
+	schemaFields := make([]arrow.Field, len(fields))
+	for i, f := range fields {
+		schemaFields[i] = t.schema.Field(f)
+	}
+	schema := arrow.NewSchema(schemaFields, nil)
+	return &returning{
+		table:  t,
+		fields: fields,
+		schema: schema,
+		b:      array.NewRecordBuilder(t.alloc, schema),
+	}, nil
+}
+
+// clause returns the RETURNING clause, with a leading space.
+func (r *returning) clause() string {
+	if r == nil {
+		return ""
+	}
+	exprs := make([]string, len(r.fields))
+	for i, f := range r.fields {
+		exprs[i] = r.table.selectExpr(f)
+	}
+	return " RETURNING " + strings.Join(exprs, ", ")
+}
+
+// append appends the rows returned by a statement and closes them.
+func (r *returning) append(rows *sql.Rows) (int64, error) {
+	defer rows.Close()
+
+	values := make([]any, len(r.fields))
+	dest := make([]any, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	var n int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		for i, v := range values {
+			if err := appendValue(r.b.Field(i), v); err != nil {
+				return 0, fmt.Errorf("column %q: %w", r.schema.Field(i).Name, err)
+			}
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// result returns the DML result with the collected RETURNING data.
+func (r *returning) result(affected int64) *catalog.DMLResult {
+	result := &catalog.DMLResult{AffectedRows: affected}
+	if r == nil {
+		return result
+	}
+	rec := r.b.NewRecordBatch()
+	defer rec.Release()
+	result.ReturningData, _ = array.NewRecordReader(r.schema, []arrow.RecordBatch{rec})
+	return result
+}
+
+func (r *returning) release() {
+	if r != nil {
+		r.b.Release()
+	}
+}
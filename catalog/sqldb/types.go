@@ -0,0 +1,379 @@
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+)
+
+// arrowType maps a database column type to an Arrow type.
+// Types without a better match, including types the driver does not report, map to String.
+func (d Dialect) arrowType(ct *sql.ColumnType) arrow.DataType {
+	name := strings.ToUpper(strings.TrimSpace(ct.DatabaseTypeName()))
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	unsigned := strings.Contains(name, "UNSIGNED")
+	if unsigned {
+		name = strings.TrimSpace(strings.ReplaceAll(name, "UNSIGNED", ""))
+	}
+
+	switch name {
+	case "BOOL", "BOOLEAN":
+		return arrow.FixedWidthTypes.Boolean
+	case "TINYINT", "INT1":
+		if unsigned {
+			return arrow.PrimitiveTypes.Uint8
+		}
+		return arrow.PrimitiveTypes.Int8
+	case "SMALLINT", "INT2", "SMALLSERIAL":
+		if unsigned {
+			return arrow.PrimitiveTypes.Uint16
+		}
+		return arrow.PrimitiveTypes.Int16
+	case "INT", "INTEGER", "INT4", "MEDIUMINT", "SERIAL":
+		switch {
+		case d == SQLite:
+			// SQLite integers are always 64-bit.
+			return arrow.PrimitiveTypes.Int64
+		case unsigned:
+			return arrow.PrimitiveTypes.Uint32
+		}
+		return arrow.PrimitiveTypes.Int32
+	case "BIGINT", "INT8", "BIGSERIAL":
+		if unsigned {
+			return arrow.PrimitiveTypes.Uint64
+		}
+		return arrow.PrimitiveTypes.Int64
+	case "UTINYINT":
+		return arrow.PrimitiveTypes.Uint8
+	case "USMALLINT":
+		return arrow.PrimitiveTypes.Uint16
+	case "UINTEGER":
+		return arrow.PrimitiveTypes.Uint32
+	case "UBIGINT":
+		return arrow.PrimitiveTypes.Uint64
+	case "REAL", "FLOAT4", "FLOAT":
+		if d == SQLite {
+			// SQLite REAL is an 8-byte float.
+			return arrow.PrimitiveTypes.Float64
+		}
+		return arrow.PrimitiveTypes.Float32
+	case "DOUBLE", "DOUBLE PRECISION", "FLOAT8":
+		return arrow.PrimitiveTypes.Float64
+	case "NUMERIC", "DECIMAL":
+		if p, s, ok := ct.DecimalSize(); ok && p > 0 && p <= 38 {
+			return &arrow.Decimal128Type{Precision: int32(p), Scale: int32(s)}
+		}
+		return arrow.PrimitiveTypes.Float64
+	case "DATE":
+		return arrow.FixedWidthTypes.Date32
+	case "TIMESTAMP", "DATETIME", "TIMESTAMP WITHOUT TIME ZONE":
+		return &arrow.TimestampType{Unit: arrow.Microsecond}
+	case "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE":
+		return arrow.FixedWidthTypes.Timestamp_us
+	case "TIME", "TIME WITHOUT TIME ZONE":
+		return arrow.FixedWidthTypes.Time64us
+	case "BLOB", "BYTEA", "BINARY", "VARBINARY", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB":
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// appendValue appends a value scanned by database/sql to b, converting it to
+// the builder type.
+func appendValue(b array.Builder, v any) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	var err error
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		var x bool
+		if x, err = toBool(v); err == nil {
+			b.Append(x)
+		}
+	case *array.Int8Builder:
+		var n int64
+		if n, err = toInt64(v); err == nil {
+			b.Append(int8(n))
+		}
+	case *array.Int16Builder:
+		var n int64
+		if n, err = toInt64(v); err == nil {
+			b.Append(int16(n))
+		}
+	case *array.Int32Builder:
+		var n int64
+		if n, err = toInt64(v); err == nil {
+			b.Append(int32(n))
+		}
+	case *array.Int64Builder:
+		var n int64
+		if n, err = toInt64(v); err == nil {
+			b.Append(n)
+		}
+	case *array.Uint8Builder:
+		var n uint64
+		if n, err = toUint64(v); err == nil {
+			b.Append(uint8(n))
+		}
+	case *array.Uint16Builder:
+		var n uint64
+		if n, err = toUint64(v); err == nil {
+			b.Append(uint16(n))
+		}
+	case *array.Uint32Builder:
+		var n uint64
+		if n, err = toUint64(v); err == nil {
+			b.Append(uint32(n))
+		}
+	case *array.Uint64Builder:
+		var n uint64
+		if n, err = toUint64(v); err == nil {
+			b.Append(n)
+		}
+	case *array.Float32Builder:
+		var f float64
+		if f, err = toFloat64(v); err == nil {
+			b.Append(float32(f))
+		}
+	case *array.Float64Builder:
+		var f float64
+		if f, err = toFloat64(v); err == nil {
+			b.Append(f)
+		}
+	case *array.Decimal128Builder:
+		dt := b.Type().(*arrow.Decimal128Type)
+		var n decimal128.Num
+		if f, ok := v.(float64); ok {
+			n, err = decimal128.FromFloat64(f, dt.Precision, dt.Scale)
+		} else {
+			n, err = decimal128.FromString(toString(v), dt.Precision, dt.Scale)
+		}
+		if err == nil {
+			b.Append(n)
+		}
+	case *array.StringBuilder:
+		b.Append(toString(v))
+	case *array.BinaryBuilder:
+		switch x := v.(type) {
+		case []byte:
+			b.Append(x)
+		default:
+			b.Append([]byte(toString(v)))
+		}
+	case *array.Date32Builder:
+		var t time.Time
+		if t, err = toTime(v); err == nil {
+			b.Append(arrow.Date32FromTime(t))
+		}
+	case *array.TimestampBuilder:
+		var t time.Time
+		if t, err = toTime(v); err == nil {
+			var ts arrow.Timestamp
+			if ts, err = arrow.TimestampFromTime(t, b.Type().(*arrow.TimestampType).Unit); err == nil {
+				b.Append(ts)
+			}
+		}
+	case *array.Time64Builder:
+		var t time.Time
+		if t, err = toClock(v); err == nil {
+			midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+			d := t.Sub(midnight)
+			if b.Type().(*arrow.Time64Type).Unit == arrow.Nanosecond {
+				b.Append(arrow.Time64(d.Nanoseconds()))
+			} else {
+				b.Append(arrow.Time64(d.Microseconds()))
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported column type %s", b.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("cannot convert %T to %s: %w", v, b.Type(), err)
+	}
+	return nil
+}
+
+// driverValue returns the value at index i of arr as a database/sql argument.
+func driverValue(arr arrow.Array, i int) (any, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i), nil
+	case *array.Int8:
+		return int64(a.Value(i)), nil
+	case *array.Int16:
+		return int64(a.Value(i)), nil
+	case *array.Int32:
+		return int64(a.Value(i)), nil
+	case *array.Int64:
+		return a.Value(i), nil
+	case *array.Uint8:
+		return int64(a.Value(i)), nil
+	case *array.Uint16:
+		return int64(a.Value(i)), nil
+	case *array.Uint32:
+		return int64(a.Value(i)), nil
+	case *array.Uint64:
+		return a.Value(i), nil
+	case *array.Float32:
+		return float64(a.Value(i)), nil
+	case *array.Float64:
+		return a.Value(i), nil
+	case *array.String:
+		return a.Value(i), nil
+	case *array.LargeString:
+		return a.Value(i), nil
+	case *array.Binary:
+		return a.Value(i), nil
+	case *array.LargeBinary:
+		return a.Value(i), nil
+	case *array.Date32:
+		return a.Value(i).ToTime(), nil
+	case *array.Timestamp:
+		return a.Value(i).ToTime(a.DataType().(*arrow.TimestampType).Unit), nil
+	case *array.Time64:
+		return a.Value(i).ToTime(a.DataType().(*arrow.Time64Type).Unit).Format("15:04:05.999999999"), nil
+	case *array.Decimal128:
+		return a.ValueStr(i), nil
+	default:
+		return nil, fmt.Errorf("unsupported column type %s", arr.DataType())
+	}
+}
+
+// timeLayouts are the text formats accepted for DATE and TIMESTAMP values.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	time.DateOnly,
+}
+
+func toTime(v any) (time.Time, error) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, nil
+	case string:
+		return parseTime(x)
+	case []byte:
+		return parseTime(string(x))
+	}
+	return time.Time{}, fmt.Errorf("unexpected %T", v)
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// toClock converts a TIME value; the date part of the result is meaningless.
+func toClock(v any) (time.Time, error) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, nil
+	case string:
+		return time.Parse("15:04:05.999999999", x)
+	case []byte:
+		return time.Parse("15:04:05.999999999", string(x))
+	}
+	return time.Time{}, fmt.Errorf("unexpected %T", v)
+}
+
+func toBool(v any) (bool, error) {
+	switch x := v.(type) {
+	case bool:
+		return x, nil
+	case int64:
+		return x != 0, nil
+	case string:
+		return strconv.ParseBool(x)
+	case []byte:
+		return strconv.ParseBool(string(x))
+	}
+	return false, fmt.Errorf("unexpected %T", v)
+}
+
+func toInt64(v any) (int64, error) {
+	switch x := v.(type) {
+	case int64:
+		return x, nil
+	case int32:
+		return int64(x), nil
+	case int:
+		return int64(x), nil
+	case uint64:
+		return int64(x), nil
+	case float64:
+		return int64(x), nil
+	case bool:
+		if x {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseInt(x, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(x), 10, 64)
+	}
+	return 0, fmt.Errorf("unexpected %T", v)
+}
+
+func toUint64(v any) (uint64, error) {
+	switch x := v.(type) {
+	case uint64:
+		return x, nil
+	case string:
+		return strconv.ParseUint(x, 10, 64)
+	case []byte:
+		return strconv.ParseUint(string(x), 10, 64)
+	}
+	n, err := toInt64(v)
+	return uint64(n), err
+}
+
+func toFloat64(v any) (float64, error) {
+	switch x := v.(type) {
+	case float64:
+		return x, nil
+	case float32:
+		return float64(x), nil
+	case int64:
+		return float64(x), nil
+	case string:
+		return strconv.ParseFloat(x, 64)
+	case []byte:
+		return strconv.ParseFloat(string(x), 64)
+	}
+	return 0, fmt.Errorf("unexpected %T", v)
+}
+
+func toString(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case []byte:
+		return string(x)
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
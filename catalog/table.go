@@ -34,6 +34,9 @@ type Table interface {
 	//   - Full schema records (server will apply projection)
 	//   - Projected schema records (optimization)
 	// Either way, returned data will match what client expects.
+	// If the scan was planned inside a transaction, the transaction ID is
+	// available via TransactionIDFromContext, so that tables can return the
+	// transaction's own writes and a consistent snapshot.
 	Scan(ctx context.Context, opts *ScanOptions) (array.RecordReader, error)
 }
 
@@ -190,3 +193,20 @@ type StatisticsTable interface {
 	// Returns ErrNotFound if the column doesn't exist.
 	ColumnStatistics(ctx context.Context, columnName string, columnType string) (*ColumnStats, error)
 }
+
+// PartitionedTable extends Table with partitioned scans.
+// Tables implement this interface to let DuckDB fetch a single scan over
+// several parallel DoGet streams, one per partition.
+// Implementations MUST be goroutine-safe.
+type PartitionedTable interface {
+	Table
+
+	// Partitions splits a scan into independent partitions.
+	// opts carries the requested columns, filter and time point; Partition is unset.
+	// Each returned value is an opaque, implementation-defined partition descriptor.
+	// It is embedded in its own endpoint ticket and passed back to Scan
+	// via ScanOptions.Partition when DuckDB fetches that endpoint.
+	// Partitions together MUST cover every row of the scan exactly once.
+	// Returning nil or an empty slice falls back to a single unpartitioned endpoint.
+	Partitions(ctx context.Context, opts *ScanOptions) ([][]byte, error)
+}
@@ -24,8 +24,15 @@ const (
 //
 // Usage:
 //   - Client calls create_transaction action to get a transaction ID
-//   - Client includes transaction ID in x-transaction-id header for DML operations
-//   - Server automatically commits on success, rolls back on failure
+//   - Client includes transaction ID in airport-transaction-id header for DML operations,
+//     which see it through TransactionIDFromContext
+//   - By default, the server commits the transaction when an operation succeeds
+//     and rolls it back when it fails (auto-commit)
+//   - With multi-statement transactions enabled, the transaction stays open across
+//     operations until the client sends the commit_transaction or rollback_transaction
+//     action; the server rolls it back when an operation fails or when it stays idle
+//     longer than the configured transaction timeout, and only executes operations
+//     in transactions in the TransactionActive state
 //
 // Implementations MUST be goroutine-safe.
 type TransactionManager interface {
@@ -34,21 +41,24 @@ type TransactionManager interface {
 	// Returns error if transaction creation fails.
 	BeginTransaction(ctx context.Context) (txID string, err error)
 
-	// CommitTransaction marks a transaction as successfully completed.
-	// Called automatically by Flight handlers on operation success.
+	// CommitTransaction makes the changes of all operations of a transaction durable.
+	// Called after each successful operation in auto-commit mode, and when the
+	// client sends the commit_transaction action.
 	// Idempotent - safe to call multiple times with same txID.
-	// Returns error if commit fails or txID is invalid.
+	// Returns error if commit fails or txID is invalid; the client sees the commit as failed.
 	CommitTransaction(ctx context.Context, txID string) error
 
-	// RollbackTransaction aborts a transaction.
-	// Called automatically by Flight handlers on operation failure.
+	// RollbackTransaction aborts a transaction, discarding the changes of all its operations.
+	// Called when the client sends the rollback_transaction action, when an operation
+	// of the transaction fails and when the transaction times out.
 	// Idempotent - safe to call multiple times with same txID.
 	// Returns error only for infrastructure failures (not "already rolled back").
 	RollbackTransaction(ctx context.Context, txID string) error
 
 	// GetTransactionStatus returns the current state of a transaction.
 	// Returns (state, true) if transaction exists, ("", false) otherwise.
-	// Used by handlers to validate transaction state before operations.
+	// Used by handlers to validate transaction state before every operation of a
+	// multi-statement transaction.
 	GetTransactionStatus(ctx context.Context, txID string) (TransactionState, bool)
 }
 
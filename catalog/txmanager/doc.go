@@ -0,0 +1,47 @@
+// Package txmanager provides an in-memory transaction manager for Airport servers.
+//
+// Manager implements catalog.CatalogTransactionManager for multi-catalog servers;
+// ForCatalog adapts it to catalog.TransactionManager for single-catalog servers.
+// It issues UUID transaction IDs, tracks the catalog.TransactionState of every
+// transaction and rolls back transactions that stay unused longer than a TTL.
+// The TTL is a backstop behind the server's transaction timeout, see Options.TTL.
+//
+// # Basic Usage
+//
+//	txm := txmanager.New(txmanager.Options{TTL: 10 * time.Minute})
+//	defer txm.Close()
+//
+//	err := airport.NewServer(grpcServer, airport.ServerConfig{
+//		Catalog:            cat,
+//		TransactionManager: txm.ForCatalog(""),
+//	})
+//
+// # Commit and Rollback Callbacks
+//
+// The manager does not stage data itself. A table that supports transactions
+// reads the transaction ID of an operation from its context and registers
+// callbacks that apply or discard the work of the operation:
+//
+//	func (t *Table) Insert(ctx context.Context, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+//		txID, ok := catalog.TransactionIDFromContext(ctx)
+//		if !ok {
+//			return t.insertNow(ctx, rows)
+//		}
+//		pending, err := t.stage(rows)
+//		if err != nil {
+//			return nil, err
+//		}
+//		if err := t.txm.OnCommit(txID, pending.apply); err != nil {
+//			return nil, err
+//		}
+//		if err := t.txm.OnRollback(txID, pending.discard); err != nil {
+//			return nil, err
+//		}
+//		return pending.result(), nil
+//	}
+//
+// Commit callbacks run in registration order when the client commits; rollback
+// callbacks run in reverse order when the client rolls back, when an operation of
+// the transaction fails, when a commit callback fails and when the transaction
+// expires.
+package txmanager
@@ -0,0 +1,380 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// DefaultRetention is how long finished transactions stay queryable when
+// Options.Retention is zero.
+const DefaultRetention = 5 * time.Minute
+
+// ErrNotActive is returned when committing a rolled back transaction, rolling back
+// a committed one, or registering a callback on a transaction that is finishing
+// or finished.
+var ErrNotActive = errors.New("transaction is not active")
+
+// Options configures a Manager.
+type Options struct {
+	// TTL is how long an active transaction may stay unused before it is rolled back.
+	// A transaction is used when it is begun, its status is read and a callback is
+	// registered. The manager does not see operations in progress: a DoGet stream
+	// reading a transaction does not use it while it runs.
+	//
+	// The server's transaction timeout owns idle expiry of multi-statement
+	// transactions: it is paused while an operation holds the transaction and
+	// rolls back through the manager. TTL is a backstop for transactions no
+	// server ends, such as those a client begins and never uses for DML in
+	// auto-commit mode. When both are set, TTL must exceed the server timeout
+	// plus the longest operation, or the manager may roll back a transaction a
+	// stream still reads.
+	// If zero, transactions never expire.
+	TTL time.Duration
+
+	// Retention is how long committed and rolled back transactions stay queryable
+	// through GetTransactionStatus. Defaults to DefaultRetention.
+	Retention time.Duration
+
+	// GCInterval is how often expired transactions are rolled back and finished
+	// transactions are removed. Defaults to half of the shorter of TTL and Retention.
+	GCInterval time.Duration
+
+	// Logger receives expiry and callback failure events. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// Callback is a commit or rollback callback of a transaction.
+// The context carries the transaction ID (see catalog.TransactionIDFromContext).
+type Callback func(ctx context.Context) error
+
+// Manager is an in-memory catalog.CatalogTransactionManager.
+// Use ForCatalog to get a catalog.TransactionManager for a single-catalog server.
+//
+// Transaction IDs are random UUIDs. Backends attach their work to a transaction
+// with OnCommit and OnRollback; the callbacks run when the transaction ends.
+// A background goroutine rolls back transactions unused for longer than
+// Options.TTL and forgets finished transactions after Options.Retention;
+// Close stops it. All methods are goroutine-safe.
+type Manager struct {
+	opts Options
+	now  func() time.Time
+
+	mu  sync.Mutex
+	txs map[string]*transaction
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// transaction is the state of one transaction.
+// Fields other than endMu are guarded by Manager.mu.
+type transaction struct {
+	endMu sync.Mutex // serializes commit and rollback
+
+	catalog    string
+	state      catalog.TransactionState
+	ending     bool      // commit or rollback in progress
+	lastUsed   time.Time // end time once finished
+	onCommit   []Callback
+	onRollback []Callback
+}
+
+var _ catalog.CatalogTransactionManager = (*Manager)(nil)
+
+// New creates a Manager and starts its garbage collector.
+func New(opts Options) *Manager {
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultRetention
+	}
+	if opts.GCInterval <= 0 {
+		interval := opts.Retention
+		if opts.TTL > 0 && opts.TTL < interval {
+			interval = opts.TTL
+		}
+		opts.GCInterval = interval / 2
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+
+	m := &Manager{
+		opts: opts,
+		now:  time.Now,
+		txs:  make(map[string]*transaction),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go m.gcLoop()
+	return m
+}
+
+// BeginTransaction implements catalog.CatalogTransactionManager.
+func (m *Manager) BeginTransaction(ctx context.Context, catalogName string) (string, error) {
+	txID := uuid.NewString()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.txs[txID] = &transaction{
+		catalog:  catalogName,
+		state:    catalog.TransactionActive,
+		lastUsed: m.now(),
+	}
+	return txID, nil
+}
+
+// CommitTransaction implements catalog.CatalogTransactionManager.
+// Commit callbacks run in registration order. If one fails, the remaining commit
+// callbacks are skipped, the rollback callbacks run and the transaction ends
+// rolled back. Committing a committed transaction is a no-op.
+func (m *Manager) CommitTransaction(ctx context.Context, txID string) error {
+	return m.finish(ctx, txID, true, false)
+}
+
+// RollbackTransaction implements catalog.CatalogTransactionManager.
+// Rollback callbacks run in reverse registration order; all of them run even if
+// some fail. Rolling back a rolled back transaction is a no-op.
+func (m *Manager) RollbackTransaction(ctx context.Context, txID string) error {
+	return m.finish(ctx, txID, false, false)
+}
+
+// GetTransactionStatus implements catalog.CatalogTransactionManager.
+// Reading the status of an active transaction counts as using it.
+func (m *Manager) GetTransactionStatus(ctx context.Context, txID string) (catalog.TransactionState, string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, ok := m.txs[txID]
+	if !ok {
+		return "", "", false
+	}
+	if tx.state == catalog.TransactionActive {
+		tx.lastUsed = m.now()
+	}
+	return tx.state, tx.catalog, true
+}
+
+// OnCommit registers fn to run when transaction txID commits.
+// Returns ErrNotActive if the transaction is finishing or finished.
+func (m *Manager) OnCommit(txID string, fn Callback) error {
+	return m.register(txID, fn, true)
+}
+
+// OnRollback registers fn to run when transaction txID rolls back, including
+// when it expires or when a commit callback fails.
+// Returns ErrNotActive if the transaction is finishing or finished.
+func (m *Manager) OnRollback(txID string, fn Callback) error {
+	return m.register(txID, fn, false)
+}
+
+// ForCatalog returns a catalog.TransactionManager that begins transactions in
+// catalogName, for use in ServerConfig.TransactionManager.
+func (m *Manager) ForCatalog(catalogName string) catalog.TransactionManager {
+	return &catalogManager{m: m, catalog: catalogName}
+}
+
+// Close stops the garbage collector and rolls back all active transactions.
+// Returns the errors of failed rollback callbacks.
+func (m *Manager) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.stop)
+		<-m.done
+	})
+
+	m.mu.Lock()
+	var active []string
+	for txID, tx := range m.txs {
+		if tx.state == catalog.TransactionActive {
+			active = append(active, txID)
+		}
+	}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, txID := range active {
+		errs = append(errs, m.finish(context.Background(), txID, false, false))
+	}
+	return errors.Join(errs...)
+}
+
+// register adds a commit or rollback callback to an active transaction.
+func (m *Manager) register(txID string, fn Callback, commit bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, ok := m.txs[txID]
+	if !ok {
+		return fmt.Errorf("transaction %q: %w", txID, catalog.ErrNotFound)
+	}
+	if tx.state != catalog.TransactionActive || tx.ending {
+		return fmt.Errorf("transaction %q: %w", txID, ErrNotActive)
+	}
+	tx.lastUsed = m.now()
+	if commit {
+		tx.onCommit = append(tx.onCommit, fn)
+	} else {
+		tx.onRollback = append(tx.onRollback, fn)
+	}
+	return nil
+}
+
+// finish commits or rolls back txID and runs its callbacks.
+// With expire, an active transaction is only rolled back if it is still unused
+// for longer than the TTL.
+func (m *Manager) finish(ctx context.Context, txID string, commit, expire bool) error {
+	m.mu.Lock()
+	tx, ok := m.txs[txID]
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("transaction %q: %w", txID, catalog.ErrNotFound)
+	}
+
+	tx.endMu.Lock()
+	defer tx.endMu.Unlock()
+
+	m.mu.Lock()
+	switch {
+	case tx.state == catalog.TransactionCommitted:
+		m.mu.Unlock()
+		if commit {
+			return nil
+		}
+		return fmt.Errorf("transaction %q is committed: %w", txID, ErrNotActive)
+	case tx.state == catalog.TransactionAborted:
+		m.mu.Unlock()
+		if !commit {
+			return nil
+		}
+		return fmt.Errorf("transaction %q was rolled back: %w", txID, ErrNotActive)
+	case expire && m.now().Sub(tx.lastUsed) < m.opts.TTL:
+		m.mu.Unlock()
+		return nil
+	}
+	tx.ending = true
+	onCommit, onRollback := tx.onCommit, tx.onRollback
+	m.mu.Unlock()
+
+	ctx = catalog.WithTransactionID(ctx, txID)
+	state := catalog.TransactionAborted
+	var err error
+	if commit {
+		if err = runCommit(ctx, onCommit); err == nil {
+			state = catalog.TransactionCommitted
+		}
+	}
+	if state == catalog.TransactionAborted {
+		err = errors.Join(err, runRollback(ctx, onRollback))
+	}
+
+	m.mu.Lock()
+	tx.state = state
+	tx.ending = false
+	tx.onCommit, tx.onRollback = nil, nil
+	tx.lastUsed = m.now()
+	m.mu.Unlock()
+
+	switch {
+	case err == nil:
+		return nil
+	case commit:
+		return fmt.Errorf("commit transaction %q: %w", txID, err)
+	default:
+		return fmt.Errorf("roll back transaction %q: %w", txID, err)
+	}
+}
+
+// runCommit runs commit callbacks in order and stops at the first error.
+func runCommit(ctx context.Context, callbacks []Callback) error {
+	for _, fn := range callbacks {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRollback runs rollback callbacks in reverse order and joins their errors.
+func runRollback(ctx context.Context, callbacks []Callback) error {
+	var errs []error
+	for i := len(callbacks) - 1; i >= 0; i-- {
+		if err := callbacks[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// gcLoop runs collect every GCInterval until Close.
+func (m *Manager) gcLoop() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.opts.GCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.collect()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// collect rolls back expired transactions and forgets finished transactions
+// older than the retention period.
+func (m *Manager) collect() {
+	now := m.now()
+	var expired []string
+
+	m.mu.Lock()
+	for txID, tx := range m.txs {
+		age := now.Sub(tx.lastUsed)
+		switch {
+		case tx.state == catalog.TransactionActive:
+			if m.opts.TTL > 0 && !tx.ending && age >= m.opts.TTL {
+				expired = append(expired, txID)
+			}
+		case age >= m.opts.Retention:
+			delete(m.txs, txID)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, txID := range expired {
+		m.opts.Logger.Warn("transaction expired, rolling back", "tx_id", txID, "ttl", m.opts.TTL)
+		if err := m.finish(context.Background(), txID, false, true); err != nil {
+			m.opts.Logger.Error("transaction rollback failed", "tx_id", txID, "error", err)
+		}
+	}
+}
+
+// catalogManager is the catalog.TransactionManager view of a Manager for one catalog.
+type catalogManager struct {
+	m       *Manager
+	catalog string
+}
+
+func (c *catalogManager) BeginTransaction(ctx context.Context) (string, error) {
+	return c.m.BeginTransaction(ctx, c.catalog)
+}
+
+func (c *catalogManager) CommitTransaction(ctx context.Context, txID string) error {
+	return c.m.CommitTransaction(ctx, txID)
+}
+
+func (c *catalogManager) RollbackTransaction(ctx context.Context, txID string) error {
+	return c.m.RollbackTransaction(ctx, txID)
+}
+
+func (c *catalogManager) GetTransactionStatus(ctx context.Context, txID string) (catalog.TransactionState, bool) {
+	state, _, ok := c.m.GetTransactionStatus(ctx, txID)
+	return state, ok
+}
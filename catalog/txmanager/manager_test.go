@@ -0,0 +1,251 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// Compile-time interface checks.
+var (
+	_ catalog.CatalogTransactionManager = (*Manager)(nil)
+	_ catalog.TransactionManager        = (*catalogManager)(nil)
+)
+
+// testClock is a manually advanced clock.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestManager creates a manager whose garbage collector only runs when
+// collect is called.
+func newTestManager(t *testing.T, ttl time.Duration) (*Manager, *testClock) {
+	t.Helper()
+	m := New(Options{
+		TTL:        ttl,
+		Retention:  time.Minute,
+		GCInterval: time.Hour,
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+	m.now = clock.Now
+	t.Cleanup(func() { m.Close() })
+	return m, clock
+}
+
+// recorder records the callbacks it creates as they run.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) callback(name string, err error) Callback {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		txID, _ := catalog.TransactionIDFromContext(ctx)
+		r.calls = append(r.calls, name+"@"+txID[:4])
+		return err
+	}
+}
+
+func (r *recorder) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+func TestCommit(t *testing.T) {
+	m, _ := newTestManager(t, 0)
+	ctx := context.Background()
+	rec := &recorder{}
+
+	txID, err := m.BeginTransaction(ctx, "sales")
+	if err != nil {
+		t.Fatalf("BeginTransaction failed: %v", err)
+	}
+	other, _ := m.BeginTransaction(ctx, "sales")
+	if len(txID) != 36 || txID == other {
+		t.Errorf("transaction IDs %q and %q should be distinct UUIDs", txID, other)
+	}
+
+	state, cat, ok := m.GetTransactionStatus(ctx, txID)
+	if !ok || state != catalog.TransactionActive || cat != "sales" {
+		t.Errorf("status = %q, %q, %v; want active, sales, true", state, cat, ok)
+	}
+
+	for _, name := range []string{"c1", "c2"} {
+		if err := m.OnCommit(txID, rec.callback(name, nil)); err != nil {
+			t.Fatalf("OnCommit failed: %v", err)
+		}
+	}
+	if err := m.OnRollback(txID, rec.callback("r1", nil)); err != nil {
+		t.Fatalf("OnRollback failed: %v", err)
+	}
+
+	if err := m.CommitTransaction(ctx, txID); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	p := txID[:4]
+	if got, want := rec.names(), []string{"c1@" + p, "c2@" + p}; !slices.Equal(got, want) {
+		t.Errorf("callbacks = %q, want %q", got, want)
+	}
+	if state, _, _ := m.GetTransactionStatus(ctx, txID); state != catalog.TransactionCommitted {
+		t.Errorf("state = %q, want committed", state)
+	}
+
+	// Committing again is a no-op; rolling back or registering fails.
+	if err := m.CommitTransaction(ctx, txID); err != nil {
+		t.Errorf("second commit error = %v, want nil", err)
+	}
+	if err := m.RollbackTransaction(ctx, txID); !errors.Is(err, ErrNotActive) {
+		t.Errorf("rollback after commit error = %v, want ErrNotActive", err)
+	}
+	if err := m.OnCommit(txID, rec.callback("late", nil)); !errors.Is(err, ErrNotActive) {
+		t.Errorf("OnCommit after commit error = %v, want ErrNotActive", err)
+	}
+	if len(rec.names()) != 2 {
+		t.Errorf("callbacks after commit = %q", rec.names())
+	}
+
+	if err := m.CommitTransaction(ctx, "unknown"); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("commit of unknown transaction error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	m, _ := newTestManager(t, 0)
+	ctx := context.Background()
+	rec := &recorder{}
+	errDiscard := errors.New("discard failed")
+
+	txID, _ := m.BeginTransaction(ctx, "")
+	m.OnCommit(txID, rec.callback("c1", nil))
+	m.OnRollback(txID, rec.callback("r1", nil))
+	m.OnRollback(txID, rec.callback("r2", errDiscard))
+
+	// All rollback callbacks run, in reverse order, and their errors are returned.
+	if err := m.RollbackTransaction(ctx, txID); !errors.Is(err, errDiscard) {
+		t.Fatalf("RollbackTransaction error = %v, want %v", err, errDiscard)
+	}
+	p := txID[:4]
+	if got, want := rec.names(), []string{"r2@" + p, "r1@" + p}; !slices.Equal(got, want) {
+		t.Errorf("callbacks = %q, want %q", got, want)
+	}
+	if state, _, _ := m.GetTransactionStatus(ctx, txID); state != catalog.TransactionAborted {
+		t.Errorf("state = %q, want aborted", state)
+	}
+	if err := m.RollbackTransaction(ctx, txID); err != nil {
+		t.Errorf("second rollback error = %v, want nil", err)
+	}
+	if err := m.CommitTransaction(ctx, txID); !errors.Is(err, ErrNotActive) {
+		t.Errorf("commit after rollback error = %v, want ErrNotActive", err)
+	}
+}
+
+func TestFailedCommitRollsBack(t *testing.T) {
+	m, _ := newTestManager(t, 0)
+	ctx := context.Background()
+	rec := &recorder{}
+	errApply := errors.New("apply failed")
+
+	txID, _ := m.BeginTransaction(ctx, "")
+	m.OnCommit(txID, rec.callback("c1", nil))
+	m.OnCommit(txID, rec.callback("c2", errApply))
+	m.OnCommit(txID, rec.callback("c3", nil))
+	m.OnRollback(txID, rec.callback("r1", nil))
+
+	if err := m.CommitTransaction(ctx, txID); !errors.Is(err, errApply) {
+		t.Fatalf("CommitTransaction error = %v, want %v", err, errApply)
+	}
+	p := txID[:4]
+	if got, want := rec.names(), []string{"c1@" + p, "c2@" + p, "r1@" + p}; !slices.Equal(got, want) {
+		t.Errorf("callbacks = %q, want %q", got, want)
+	}
+	if state, _, _ := m.GetTransactionStatus(ctx, txID); state != catalog.TransactionAborted {
+		t.Errorf("state = %q, want aborted", state)
+	}
+}
+
+func TestExpiryAndRetention(t *testing.T) {
+	m, clock := newTestManager(t, 10*time.Second)
+	ctx := context.Background()
+	rec := &recorder{}
+
+	idle, _ := m.BeginTransaction(ctx, "")
+	used, _ := m.BeginTransaction(ctx, "")
+	done, _ := m.BeginTransaction(ctx, "")
+	m.OnRollback(idle, rec.callback("idle", nil))
+	m.OnRollback(used, rec.callback("used", nil))
+	m.CommitTransaction(ctx, done)
+
+	clock.Advance(8 * time.Second)
+	m.GetTransactionStatus(ctx, used)
+	clock.Advance(4 * time.Second)
+	m.collect()
+
+	if state, _, _ := m.GetTransactionStatus(ctx, idle); state != catalog.TransactionAborted {
+		t.Errorf("idle transaction state = %q, want aborted", state)
+	}
+	if state, _, _ := m.GetTransactionStatus(ctx, used); state != catalog.TransactionActive {
+		t.Errorf("used transaction state = %q, want active", state)
+	}
+	if got, want := rec.names(), []string{"idle@" + idle[:4]}; !slices.Equal(got, want) {
+		t.Errorf("callbacks = %q, want %q", got, want)
+	}
+
+	// Finished transactions are forgotten after the retention period.
+	clock.Advance(time.Minute)
+	m.collect()
+	for _, txID := range []string{idle, done} {
+		if _, _, ok := m.GetTransactionStatus(ctx, txID); ok {
+			t.Errorf("transaction %s should be forgotten", txID)
+		}
+	}
+}
+
+func TestForCatalogAndClose(t *testing.T) {
+	m, _ := newTestManager(t, 0)
+	ctx := context.Background()
+	rec := &recorder{}
+
+	txm := m.ForCatalog("analytics")
+	txID, err := txm.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatalf("BeginTransaction failed: %v", err)
+	}
+	if _, cat, _ := m.GetTransactionStatus(ctx, txID); cat != "analytics" {
+		t.Errorf("catalog = %q, want analytics", cat)
+	}
+	m.OnRollback(txID, rec.callback("r", nil))
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if state, ok := txm.GetTransactionStatus(ctx, txID); !ok || state != catalog.TransactionAborted {
+		t.Errorf("state after Close = %q, %v; want aborted", state, ok)
+	}
+	if len(rec.names()) != 1 {
+		t.Errorf("callbacks = %q, want the rollback callback", rec.names())
+	}
+}
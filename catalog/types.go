@@ -50,17 +50,27 @@ type ScanOptions struct {
 
 	// Limit is maximum rows to return.
 	// If 0 or negative, no limit.
+	// Set from the limit passed by the client in the endpoints request.
+	// Implementations MAY ignore this hint; the server stops streaming
+	// once the limit is reached.
 	Limit int64
 
 	// BatchSize is hint for RecordReader batch size.
 	// If 0, implementation chooses default.
 	// Implementations MAY ignore this hint.
+	// Set from the server's batch row target; the server re-batches
+	// DoGet output toward the target either way.
 	BatchSize int
 
 	// TimePoint specifies point-in-time for time-travel queries.
 	// Nil for "current" time (no time travel).
 	// Supports DuckDB Airport Extension "endpoints" action.
 	TimePoint *TimePoint
+
+	// Partition is the partition descriptor returned by PartitionedTable.Partitions.
+	// Nil for unpartitioned scans (return all rows).
+	// When set, Scan MUST return only the rows of this partition.
+	Partition []byte
 }
 
 // TimePoint represents a point-in-time for time-travel queries.
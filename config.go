@@ -3,13 +3,60 @@ package airport
 import (
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/flight"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
+// Compression selects the Arrow IPC body compression used for result streams.
+type Compression = flight.Compression
+
+// Supported result stream compression codecs.
+const (
+	CompressionNone = flight.CompressionNone
+	CompressionLZ4  = flight.CompressionLZ4
+	CompressionZSTD = flight.CompressionZSTD
+)
+
+// TicketSigner signs Flight tickets with HMAC-SHA256 so that DoGet only accepts
+// unmodified, unexpired tickets issued by the server to the calling identity.
+type TicketSigner = flight.TicketSigner
+
+// TicketKey is a named HMAC key used by TicketSigner.
+type TicketKey = flight.TicketKey
+
+// NewTicketSigner creates a TicketSigner whose tickets expire after ttl (0 = never).
+// The first key signs new tickets; all keys verify, which allows key rotation.
+func NewTicketSigner(ttl time.Duration, keys ...TicketKey) (*TicketSigner, error) {
+	return flight.NewTicketSigner(ttl, keys...)
+}
+
+// TicketStore keeps ticket data server-side and hands out short opaque handles.
+type TicketStore = flight.TicketStore
+
+// NewMemoryTicketStore creates an in-memory TicketStore that keeps tickets for ttl
+// (flight.DefaultTicketTTL if zero).
+func NewMemoryTicketStore(ttl time.Duration) *flight.MemoryTicketStore {
+	return flight.NewMemoryTicketStore(ttl)
+}
+
+// RateLimits configures per-principal, per-catalog request rates, concurrent
+// stream counts and streamed bytes, enforced by the ServerOptions interceptors.
+type RateLimits = flight.RateLimits
+
+// RateLimit configures the limits of one principal in one catalog.
+type RateLimit = flight.RateLimit
+
+// DefaultTransactionTimeout is the idle timeout of multi-statement transactions
+// when ServerConfig.TransactionTimeout is 0.
+const DefaultTransactionTimeout = flight.DefaultTransactionTimeout
+
 // ServerConfig contains configuration for Airport Flight server.
 type ServerConfig struct {
 	// Catalog provides schemas, tables, and functions.
@@ -40,6 +87,25 @@ type ServerConfig struct {
 	// Recommended: 16MB for large Arrow batches.
 	MaxMessageSize int
 
+	// BatchTargetRows is the target number of rows per DoGet record batch.
+	// OPTIONAL: If 0, row count is not used for re-batching.
+	// Larger batches are sliced and smaller ones coalesced toward this size.
+	// Tables receive it as ScanOptions.BatchSize.
+	BatchTargetRows int
+
+	// BatchTargetBytes is the target size in bytes of each DoGet record batch.
+	// OPTIONAL: If 0 and MaxMessageSize is set, half of MaxMessageSize is used;
+	// if both are 0, byte size is not used for re-batching.
+	// Keep it well below MaxMessageSize (or the 4MB gRPC default).
+	// If both BatchTargetRows and BatchTargetBytes are 0, batches are sent as returned by tables.
+	BatchTargetBytes int64
+
+	// Compression enables Arrow IPC buffer compression for result data:
+	// DoGet streams, scalar and table function exchanges and DML RETURNING.
+	// OPTIONAL: If empty (CompressionNone), data is sent uncompressed.
+	// Trades CPU for bandwidth; most useful for wide or string-heavy results over slow links.
+	Compression Compression
+
 	// Address is the server's public address (e.g., "localhost:50051").
 	// OPTIONAL: If empty, FlightEndpoint locations will not include URI.
 	// Required for proper DoGet routing when DuckDB needs to reconnect.
@@ -47,8 +113,72 @@ type ServerConfig struct {
 
 	// TransactionManager coordinates multi-operation transactions.
 	// OPTIONAL: If nil, operations execute without transaction coordination.
-	// When configured, DML operations support automatic commit/rollback.
+	// When configured, each DML operation commits its transaction when it
+	// succeeds and rolls it back when it fails.
 	TransactionManager catalog.TransactionManager
+
+	// MultiStatementTransactions keeps a transaction open across the DML operations
+	// sharing its ID, until the client sends the commit_transaction or
+	// rollback_transaction action or the transaction times out.
+	// OPTIONAL: If false, each DML operation commits its transaction (auto-commit).
+	// Only enable it for clients that end their transactions explicitly: DuckDB
+	// Airport clients never send commit_transaction, so their changes would be
+	// rolled back after TransactionTimeout.
+	MultiStatementTransactions bool
+
+	// TransactionTimeout is how long a multi-statement transaction may stay idle
+	// between operations before the server rolls it back, including transactions
+	// of clients that disconnected without commit_transaction or rollback_transaction.
+	// OPTIONAL: If 0, DefaultTransactionTimeout (5 minutes) is used.
+	// Only used with MultiStatementTransactions.
+	TransactionTimeout time.Duration
+
+	// TicketSigner signs the tickets returned for scans.
+	// OPTIONAL: If nil, tickets are plain JSON that clients can forge or modify.
+	// When set, DoGet rejects tickets that are unsigned, modified, expired or
+	// redeemed by an identity other than the one they were issued to.
+	TicketSigner *TicketSigner
+
+	// TicketStore keeps the data of scan tickets server-side.
+	// OPTIONAL: If nil, tickets carry the full ticket data, including filters and
+	// function parameters, and are re-sent by the client on every DoGet.
+	// When set, clients receive short opaque handles that DoGet resolves; use
+	// NewMemoryTicketStore for a single server instance.
+	TicketStore TicketStore
+
+	// Authorizer checks each operation (scan, DML, DDL, function execution) on
+	// schemas, tables and columns for the request principal.
+	// OPTIONAL: If nil, authenticated callers may perform any operation.
+	// Denials are returned as PermissionDenied; schemas, tables and functions
+	// the caller may not list are hidden from list_schemas and ListFlights.
+	Authorizer auth.Authorizer
+
+	// TracerProvider creates the OpenTelemetry spans of RPCs and the catalog
+	// calls they make.
+	// OPTIONAL: If nil, the global provider (otel.GetTracerProvider) is used,
+	// which records nothing until the application installs one.
+	TracerProvider trace.TracerProvider
+
+	// Metrics records request counts, latencies, streamed and changed rows,
+	// active streams and transaction outcomes.
+	// OPTIONAL: If nil, no metrics are recorded. See metrics/prometheus for a
+	// Recorder that exports to Prometheus.
+	Metrics metrics.Recorder
+
+	// AuditSink records who performed which DDL action, DML operation or scan,
+	// including denied and failed attempts.
+	// OPTIONAL: If nil, no audit records are written. See the audit package for
+	// JSON-lines file and slog sinks.
+	AuditSink AuditSink
+
+	// RateLimits limits the requests per second, concurrent DoGet and DoExchange
+	// streams and streamed bytes per minute of each principal.
+	// OPTIONAL: If nil, requests are not limited.
+	// Enforced by the interceptors of ServerOptions; violations fail with
+	// ResourceExhausted, a RetryInfo detail and a retry-after trailer.
+	// The interceptors of every ServerOptions call with the same RateLimits
+	// share one limiter, so the limits hold across gRPC servers.
+	RateLimits *RateLimits
 }
 
 // Standard errors returned by airport package.
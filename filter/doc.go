@@ -2,9 +2,10 @@
 //
 // This package enables Flight server developers to:
 //   - Parse filter pushdown JSON from DuckDB into strongly-typed Go structures
-//   - Encode parsed expressions to SQL for backend databases (primarily DuckDB)
+//   - Encode parsed expressions to SQL for backend databases (DuckDB, PostgreSQL, MySQL, SQLite)
 //   - Map column names during encoding to translate between schemas
 //   - Replace column names with SQL expressions for computed columns
+//   - Evaluate filters directly against Arrow record batches
 //
 // # Basic Usage
 //
@@ -23,6 +24,20 @@
 //	    query := "SELECT * FROM table WHERE " + whereClause
 //	}
 //
+// # Parameterized Queries
+//
+// EncodeFiltersWithArgs binds constants as query arguments instead of SQL literals,
+// so the backend can reuse prepared statements:
+//
+//	whereClause, args := enc.EncodeFiltersWithArgs(fp)
+//	rows, err := db.QueryContext(ctx, "SELECT * FROM table WHERE "+whereClause, args...)
+//
+// Placeholders are ? for DuckDB, MySQL and SQLite and $1, $2, ... for PostgreSQL.
+// Arguments are database/sql driver values: int64, uint64, float64, bool, string,
+// []byte and time.Time (UTC) for dates and timestamps. DECIMAL values and HUGEINT
+// values outside the int64 range are passed as decimal strings, INTERVAL and TIME
+// values as strings. NULL, LIST, STRUCT and MAP constants stay inline.
+//
 // # Column Mapping
 //
 // Map DuckDB column names to backend storage names:
@@ -54,13 +69,37 @@
 // This produces the widest possible filter, which is safe because DuckDB
 // client applies filters client-side as a fallback.
 //
-// # Custom Dialects
+// # Evaluating Filters on Arrow Data
+//
+// Backends that are not SQL engines can apply filters to Arrow data with an Evaluator:
+//
+//	ev := filter.NewEvaluator(fp, nil)
+//	mask, err := ev.Evaluate(ctx, batch) // *array.Boolean, one entry per row
+//
+//	// Or filter a whole reader (takes ownership of reader)
+//	filtered := filter.FilterRecordReader(ctx, reader, ev)
+//
+// Comparisons, IN, BETWEEN, IS [NOT] NULL, IS [NOT] DISTINCT FROM, AND/OR/NOT,
+// casts, +, - and * are evaluated with Arrow compute kernels. The string functions
+// LOWER, UPPER, LENGTH, PREFIX, SUFFIX, CONTAINS, REGEXP_MATCHES and LIKE/ILIKE are
+// evaluated directly. Unsupported expressions follow the encoder rules below and
+// select every row they cannot decide.
+//
+// # SQL Dialects
+//
+// Encoders for other databases share the traversal and unsupported expression
+// rules of the DuckDB encoder:
+//
+//	enc := filter.NewPostgresEncoder(nil) // or NewMySQLEncoder, NewSQLiteEncoder
+//	whereClause := enc.EncodeFilters(fp)
 //
-// Implement the Encoder interface for other SQL dialects:
+// DuckDB functions, operators, casts and literals are translated to the target
+// dialect (for example prefix(name, 'J') becomes starts_with(name, 'J') in
+// PostgreSQL). Constructs without an equivalent, such as regular expressions in
+// SQLite or TRY_CAST outside DuckDB, are treated as unsupported expressions.
+// See the encoder types for dialect-specific notes.
 //
-//	type PostgreSQLEncoder struct { ... }
-//	func (e *PostgreSQLEncoder) Encode(expr Expression) string { ... }
-//	func (e *PostgreSQLEncoder) EncodeFilters(fp *FilterPushdown) string { ... }
+// Implement the Encoder interface for other SQL dialects.
 //
 // # Expression Types
 //
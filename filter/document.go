@@ -0,0 +1,110 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Document is a filter pushdown document whose filters are kept serialized.
+// It allows filters to be added or removed without parsing the expressions;
+// top-level fields other than filters and column bindings are preserved.
+type Document struct {
+	// Filters are the serialized filter expressions.
+	Filters []json.RawMessage
+
+	// ColumnBindings maps column binding indices to column names.
+	ColumnBindings []string
+
+	fields map[string]json.RawMessage
+}
+
+const (
+	documentFilters        = "filters"
+	documentColumnBindings = "column_binding_names_by_index"
+)
+
+// ParseDocument decodes a serialized filter pushdown document.
+// Empty data returns an empty document.
+func ParseDocument(data []byte) (*Document, error) {
+	doc := &Document{}
+	if len(data) == 0 {
+		return doc, nil
+	}
+	if err := json.Unmarshal(data, &doc.fields); err != nil {
+		return nil, fmt.Errorf("filter: invalid JSON: %w", err)
+	}
+	if raw, ok := doc.fields[documentFilters]; ok {
+		if err := json.Unmarshal(raw, &doc.Filters); err != nil {
+			return nil, fmt.Errorf("filter: invalid filters: %w", err)
+		}
+	}
+	if raw, ok := doc.fields[documentColumnBindings]; ok {
+		if err := json.Unmarshal(raw, &doc.ColumnBindings); err != nil {
+			return nil, fmt.Errorf("filter: invalid column bindings: %w", err)
+		}
+	}
+	return doc, nil
+}
+
+// Marshal encodes the document with its current filters and column bindings.
+func (d *Document) Marshal() ([]byte, error) {
+	fields := make(map[string]json.RawMessage, len(d.fields)+2)
+	for k, v := range d.fields {
+		fields[k] = v
+	}
+	filters, err := json.Marshal(d.Filters)
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := json.Marshal(d.ColumnBindings)
+	if err != nil {
+		return nil, err
+	}
+	fields[documentFilters] = filters
+	fields[documentColumnBindings] = bindings
+	return json.Marshal(fields)
+}
+
+// Columns returns the names of the columns referenced by filter i, in no
+// particular order and possibly repeated. It returns an error if a
+// column reference cannot be resolved through the column bindings.
+func (d *Document) Columns(i int) ([]string, error) {
+	var expr any
+	if err := json.Unmarshal(d.Filters[i], &expr); err != nil {
+		return nil, fmt.Errorf("filter: invalid filter %d: %w", i, err)
+	}
+	var columns []string
+	if err := d.collectColumns(expr, &columns); err != nil {
+		return nil, fmt.Errorf("filter: filter %d: %w", i, err)
+	}
+	return columns, nil
+}
+
+// collectColumns walks a decoded expression and appends the names of the
+// bound column references.
+func (d *Document) collectColumns(v any, columns *[]string) error {
+	switch v := v.(type) {
+	case map[string]any:
+		if v["expression_class"] == string(ClassBoundColumnRef) {
+			binding, _ := v["binding"].(map[string]any)
+			idx, ok := binding["column_index"].(float64)
+			if !ok || idx < 0 || int(idx) >= len(d.ColumnBindings) {
+				return errors.New("unresolvable column reference")
+			}
+			*columns = append(*columns, d.ColumnBindings[int(idx)])
+		}
+		for _, child := range v {
+			if err := d.collectColumns(child, columns); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if err := d.collectColumns(child, columns); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
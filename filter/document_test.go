@@ -0,0 +1,89 @@
+package filter
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDocumentEmpty(t *testing.T) {
+	doc, err := ParseDocument(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(doc.Filters) != 0 || len(doc.ColumnBindings) != 0 {
+		t.Errorf("expected empty document, got %+v", doc)
+	}
+}
+
+func TestDocumentPreservesUnknownFields(t *testing.T) {
+	data := []byte(`{
+		"filters": [{"expression_class": "BOUND_CONSTANT"}],
+		"column_binding_names_by_index": ["id"],
+		"extra": {"kept": [1, 2]}
+	}`)
+	doc, err := ParseDocument(data)
+	if err != nil {
+		t.Fatalf("ParseDocument failed: %v", err)
+	}
+	doc.Filters = append(doc.Filters, json.RawMessage(`{"expression_class":"BOUND_COLUMN_REF"}`))
+	doc.ColumnBindings = append(doc.ColumnBindings, "name")
+
+	out, err := doc.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("invalid output: %v", err)
+	}
+	want := map[string]any{
+		"filters": []any{
+			map[string]any{"expression_class": "BOUND_CONSTANT"},
+			map[string]any{"expression_class": "BOUND_COLUMN_REF"},
+		},
+		"column_binding_names_by_index": []any{"id", "name"},
+		"extra":                         map[string]any{"kept": []any{1.0, 2.0}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("document = %v, want %v", got, want)
+	}
+}
+
+func TestDocumentInvalid(t *testing.T) {
+	for _, data := range []string{`[]`, `{"filters": {}}`, `{"column_binding_names_by_index": [1]}`} {
+		if _, err := ParseDocument([]byte(data)); err == nil {
+			t.Errorf("ParseDocument(%s) succeeded, want error", data)
+		}
+	}
+}
+
+func TestDocumentColumns(t *testing.T) {
+	// name = 'x' AND id > 0, and a reference to an unbound column
+	doc, err := ParseDocument([]byte(`{
+		"filters": [
+			{"expression_class": "BOUND_CONJUNCTION", "type": "CONJUNCTION_AND", "children": [
+				{"expression_class": "BOUND_COMPARISON", "left": {"expression_class": "BOUND_COLUMN_REF", "binding": {"table_index": 0, "column_index": 1}}},
+				{"expression_class": "BOUND_COMPARISON", "left": {"expression_class": "BOUND_COLUMN_REF", "binding": {"table_index": 0, "column_index": 0}}}
+			]},
+			{"expression_class": "BOUND_COLUMN_REF", "binding": {"table_index": 0, "column_index": 2}}
+		],
+		"column_binding_names_by_index": ["id", "name"]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseDocument failed: %v", err)
+	}
+
+	columns, err := doc.Columns(0)
+	if err != nil {
+		t.Fatalf("Columns(0) failed: %v", err)
+	}
+	slices.Sort(columns)
+	if want := []string{"id", "name"}; !slices.Equal(columns, want) {
+		t.Errorf("Columns(0) = %v, want %v", columns, want)
+	}
+	if _, err := doc.Columns(1); err == nil {
+		t.Error("Columns(1) succeeded with an unbound column, want error")
+	}
+}
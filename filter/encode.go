@@ -15,6 +15,18 @@ type Encoder interface {
 	EncodeFilters(fp *FilterPushdown) string
 }
 
+// ParameterizedEncoder is an Encoder that can bind constants as query arguments
+// instead of inlining them as SQL literals.
+type ParameterizedEncoder interface {
+	Encoder
+
+	// EncodeFiltersWithArgs converts all filters to a WHERE clause body with
+	// placeholders (? or $1 depending on the dialect) and returns the arguments
+	// bound to them in order. Unsupported expressions are handled as in EncodeFilters.
+	// Returns empty string and nil args if no filters can be encoded.
+	EncodeFiltersWithArgs(fp *FilterPushdown) (string, []any)
+}
+
 // EncoderOptions configures encoding behavior.
 type EncoderOptions struct {
 	// ColumnMapping maps original column names to target names.
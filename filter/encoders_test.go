@@ -0,0 +1,652 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// corpusColumns are the column bindings used by the encoder corpus.
+// They follow the filter_schema.data table of the filter pushdown integration tests.
+var corpusColumns = []string{
+	"id", "name", "email", "status", "age", "price", "score", "rating",
+	"is_active", "birth_date", "created_at", "event_time", "deleted_at",
+	"userName", "order", "duration",
+}
+
+// corpusCol returns a reference to the named corpus column.
+func corpusCol(t *testing.T, name string) Expression {
+	t.Helper()
+	for i, c := range corpusColumns {
+		if c == name {
+			return evalCol(i)
+		}
+	}
+	t.Fatalf("unknown corpus column %q", name)
+	return nil
+}
+
+// corpusEncoders returns one encoder per supported dialect.
+func corpusEncoders(opts *EncoderOptions) map[string]Encoder {
+	return map[string]Encoder{
+		"duckdb":   NewDuckDBEncoder(opts),
+		"postgres": NewPostgresEncoder(opts),
+		"mysql":    NewMySQLEncoder(opts),
+		"sqlite":   NewSQLiteEncoder(opts),
+	}
+}
+
+// encoderCase holds the expected output of every dialect for a filter.
+// An empty expectation means the filter is not pushed down.
+type encoderCase struct {
+	name     string
+	expr     Expression
+	duckdb   string
+	postgres string
+	mysql    string
+	sqlite   string
+}
+
+func (c encoderCase) expected(dialect string) string {
+	switch dialect {
+	case "duckdb":
+		return c.duckdb
+	case "postgres":
+		return c.postgres
+	case "mysql":
+		return c.mysql
+	default:
+		return c.sqlite
+	}
+}
+
+// same returns a case with identical output for every dialect.
+func same(name string, expr Expression, sql string) encoderCase {
+	return encoderCase{name: name, expr: expr, duckdb: sql, postgres: sql, mysql: sql, sqlite: sql}
+}
+
+func encoderCorpus(t *testing.T) []encoderCase {
+	col := func(name string) Expression { return corpusCol(t, name) }
+	i32 := func(v int64) Expression { return evalConst(TypeIDInteger, v) }
+	str := func(v string) Expression { return evalConst(TypeIDVarchar, v) }
+	eq := func(l, r Expression) Expression { return evalCmp(TypeCompareEqual, l, r) }
+	gt := func(l, r Expression) Expression { return evalCmp(TypeCompareGreaterThan, l, r) }
+	fn := func(name string, args ...Expression) Expression { return evalFunc(name, false, args...) }
+	op := func(name string, args ...Expression) Expression { return evalFunc(name, true, args...) }
+	tryCast := evalCast(col("name"), TypeIDDouble)
+	tryCast.(*CastExpression).TryCast = true
+
+	return []encoderCase{
+		// Comparisons and conjunctions
+		same("equal", eq(col("id"), i32(42)), "id = 42"),
+		same("not equal", evalCmp(TypeCompareNotEqual, col("id"), i32(0)), "id <> 0"),
+		same("and", evalConj(TypeConjunctionAnd,
+			gt(col("id"), i32(0)),
+			evalCmp(TypeCompareLessThan, col("age"), i32(100)),
+		), "(id > 0 AND age < 100)"),
+		same("or", evalConj(TypeConjunctionOr, eq(col("id"), i32(1)), eq(col("id"), i32(2))),
+			"(id = 1 OR id = 2)"),
+		same("nested", evalConj(TypeConjunctionAnd,
+			eq(col("is_active"), evalConst(TypeIDBoolean, true)),
+			evalConj(TypeConjunctionOr, eq(col("status"), str("active")), eq(col("status"), str("pending"))),
+		), "(is_active = TRUE AND (status = 'active' OR status = 'pending'))"),
+
+		// IN, BETWEEN and NULL checks
+		same("in", evalCmp(TypeCompareIn, col("status"), fn("list_value", str("active"), str("pending"))),
+			"status IN ('active', 'pending')"),
+		same("not in", evalOp(TypeCompareNotIn, col("id"), i32(1), i32(2), i32(3)),
+			"id NOT IN (1, 2, 3)"),
+		same("between", evalBetween(TypeCompareBetween, col("age"), i32(18), i32(65), true, true),
+			"age BETWEEN 18 AND 65"),
+		same("not between", evalBetween(TypeCompareNotBetween, col("age"), i32(0), i32(17), true, true),
+			"age NOT BETWEEN 0 AND 17"),
+		same("exclusive between", evalBetween(TypeCompareBetween, col("age"), i32(18), i32(65), false, false),
+			"(age > 18 AND age < 65)"),
+		same("is null", evalOp(TypeOperatorIsNull, col("deleted_at")), "deleted_at IS NULL"),
+		same("is not null", evalOp(TypeOperatorIsNotNull, col("email")), "email IS NOT NULL"),
+		same("not", evalOp(TypeOperatorNot, eq(col("id"), i32(1))), "NOT (id = 1)"),
+		same("coalesce", eq(evalOp(TypeOperatorCoalesce, col("email"), col("name")), str("x")),
+			"COALESCE(email, name) = 'x'"),
+		{
+			name:     "distinct from",
+			expr:     evalCmp(TypeCompareDistinctFrom, col("status"), str("x")),
+			duckdb:   "status IS DISTINCT FROM 'x'",
+			postgres: "status IS DISTINCT FROM 'x'",
+			mysql:    "NOT (status <=> 'x')",
+			sqlite:   "status IS NOT 'x'",
+		},
+		{
+			name:     "not distinct from",
+			expr:     evalCmp(TypeCompareNotDistinctFrom, col("status"), str("x")),
+			duckdb:   "status IS NOT DISTINCT FROM 'x'",
+			postgres: "status IS NOT DISTINCT FROM 'x'",
+			mysql:    "(status <=> 'x')",
+			sqlite:   "status IS 'x'",
+		},
+
+		// String operations
+		same("like", op("~~", col("name"), str("test%")), "name LIKE 'test%'"),
+		{
+			name:     "not like",
+			expr:     op("!~~", col("name"), str("test%")),
+			duckdb:   "name NOT LIKE 'test%'",
+			postgres: "name NOT LIKE 'test%'",
+			mysql:    "name NOT LIKE 'test%'",
+		},
+		{
+			name:     "ilike",
+			expr:     op("~~*", col("name"), str("JOHN%")),
+			duckdb:   "name ILIKE 'JOHN%'",
+			postgres: "name ILIKE 'JOHN%'",
+			mysql:    "LOWER(name) LIKE LOWER('JOHN%')",
+			sqlite:   "name LIKE 'JOHN%'",
+		},
+		{
+			name:     "not ilike",
+			expr:     op("!~~*", col("name"), str("j%")),
+			duckdb:   "name NOT ILIKE 'j%'",
+			postgres: "name NOT ILIKE 'j%'",
+			mysql:    "LOWER(name) NOT LIKE LOWER('j%')",
+			sqlite:   "name NOT LIKE 'j%'",
+		},
+		{
+			name:     "like with backslash",
+			expr:     op("~~", col("name"), str(`a\b%`)),
+			duckdb:   `name LIKE 'a\b%'`,
+			postgres: `name LIKE 'a\b%' ESCAPE ''`,
+			mysql:    `name LIKE 'a\\b%' ESCAPE ''`,
+			sqlite:   `name LIKE 'a\b%'`,
+		},
+		{
+			name:     "regexp_matches",
+			expr:     fn("regexp_matches", col("name"), str("^J.*")),
+			duckdb:   "regexp_matches(name, '^J.*')",
+			postgres: "(name ~ '^J.*')",
+			mysql:    "REGEXP_LIKE(name, '^J.*', 'c')",
+		},
+		{
+			name:     "regexp full match",
+			expr:     op("~", col("name"), str("J.*")),
+			duckdb:   "name ~ 'J.*'",
+			postgres: "name ~ '^(?:J.*)$'",
+			mysql:    "REGEXP_LIKE(name, '^(?:J.*)$', 'c')",
+		},
+		{
+			name:     "lower",
+			expr:     eq(fn("lower", col("name")), str("john")),
+			duckdb:   "lower(name) = 'john'",
+			postgres: "lower(name) = 'john'",
+			mysql:    "LOWER(name) = 'john'",
+			sqlite:   "lower(name) = 'john'",
+		},
+		{
+			name:     "length",
+			expr:     gt(fn("length", col("name")), i32(5)),
+			duckdb:   "length(name) > 5",
+			postgres: "length(name) > 5",
+			mysql:    "CHAR_LENGTH(name) > 5",
+			sqlite:   "length(name) > 5",
+		},
+		{
+			name:     "substring",
+			expr:     eq(fn("substring", col("name"), i32(1), i32(1)), str("J")),
+			duckdb:   "substring(name, 1, 1) = 'J'",
+			postgres: "substr(name, 1, 1) = 'J'",
+			mysql:    "SUBSTRING(name, 1, 1) = 'J'",
+			sqlite:   "substr(name, 1, 1) = 'J'",
+		},
+		{
+			name:     "trim",
+			expr:     eq(fn("trim", col("name")), str("John")),
+			duckdb:   "trim(name) = 'John'",
+			postgres: "trim(name) = 'John'",
+			mysql:    "TRIM(name) = 'John'",
+			sqlite:   "trim(name) = 'John'",
+		},
+		{
+			name:     "concat",
+			expr:     op("~~", fn("concat", col("name"), col("email")), str("%example%")),
+			duckdb:   "concat(name, email) LIKE '%example%'",
+			postgres: "concat(name, email) LIKE '%example%'",
+			mysql:    "CONCAT_WS('', name, email) LIKE '%example%'",
+			sqlite:   "(COALESCE(name, '') || COALESCE(email, '')) LIKE '%example%'",
+		},
+		{
+			name:     "concat operator",
+			expr:     eq(op("||", col("name"), col("email")), str("ab")),
+			duckdb:   "(name || email) = 'ab'",
+			postgres: "(name || email) = 'ab'",
+			mysql:    "CONCAT(name, email) = 'ab'",
+			sqlite:   "(name || email) = 'ab'",
+		},
+		{
+			name:     "prefix",
+			expr:     fn("prefix", col("name"), str("J")),
+			duckdb:   "prefix(name, 'J')",
+			postgres: "starts_with(name, 'J')",
+			mysql:    "(LEFT(name, CHAR_LENGTH('J')) = 'J')",
+			sqlite:   "(substr(name, 1, length('J')) = 'J')",
+		},
+		{
+			name:     "suffix",
+			expr:     fn("suffix", col("email"), str(".com")),
+			duckdb:   "suffix(email, '.com')",
+			postgres: "(right(email, length('.com')) = '.com')",
+			mysql:    "(RIGHT(email, CHAR_LENGTH('.com')) = '.com')",
+			sqlite:   "(substr(email, length(email) - length('.com') + 1) = '.com')",
+		},
+		{
+			name:     "contains",
+			expr:     fn("contains", col("name"), str("oh")),
+			duckdb:   "contains(name, 'oh')",
+			postgres: "(strpos(name, 'oh') > 0)",
+			mysql:    "(LOCATE('oh', name) > 0)",
+			sqlite:   "(instr(name, 'oh') > 0)",
+		},
+
+		// Math operations
+		same("modulo", eq(op("%", col("id"), i32(5)), i32(0)), "(id % 5) = 0"),
+		same("add", gt(op("+", col("id"), col("age")), i32(100)), "(id + age) > 100"),
+		same("negate", evalCmp(TypeCompareLessThan, op("-", col("score")), i32(0)), "-score < 0"),
+		{
+			name:     "divide",
+			expr:     gt(op("/", col("price"), i32(10)), i32(5)),
+			duckdb:   "(price / 10) > 5",
+			postgres: "(CAST(price AS DOUBLE PRECISION) / 10) > 5",
+			mysql:    "(price / 10) > 5",
+			sqlite:   "(CAST(price AS REAL) / 10) > 5",
+		},
+		{
+			name:     "abs",
+			expr:     evalCmp(TypeCompareLessThan, fn("abs", op("-", col("score"), i32(50))), i32(10)),
+			duckdb:   "abs((score - 50)) < 10",
+			postgres: "abs((score - 50)) < 10",
+			mysql:    "ABS((score - 50)) < 10",
+			sqlite:   "abs((score - 50)) < 10",
+		},
+		{
+			name:     "floor",
+			expr:     evalCmp(TypeCompareGreaterThanOrEqual, fn("floor", col("rating")), i32(3)),
+			duckdb:   "floor(rating) >= 3",
+			postgres: "floor(rating) >= 3",
+			mysql:    "FLOOR(rating) >= 3",
+		},
+		{
+			name:   "unknown function",
+			expr:   eq(fn("my_udf", col("id")), i32(1)),
+			duckdb: "my_udf(id) = 1",
+		},
+
+		// Temporal values and functions
+		{
+			name:     "date",
+			expr:     gt(col("birth_date"), evalConst(TypeIDDate, int64(7305))),
+			duckdb:   "birth_date > DATE '1990-01-01'",
+			postgres: "birth_date > DATE '1990-01-01'",
+			mysql:    "birth_date > DATE '1990-01-01'",
+			sqlite:   "birth_date > '1990-01-01'",
+		},
+		{
+			name:     "timestamp",
+			expr:     gt(col("created_at"), evalConst(TypeIDTimestamp, int64(1704067200000000))),
+			duckdb:   "created_at > TIMESTAMP '2024-01-01 00:00:00'",
+			postgres: "created_at > TIMESTAMP '2024-01-01 00:00:00'",
+			mysql:    "created_at > TIMESTAMP '2024-01-01 00:00:00'",
+			sqlite:   "created_at > '2024-01-01 00:00:00'",
+		},
+		{
+			name:     "timestamp with time zone",
+			expr:     gt(col("created_at"), evalConst(TypeIDTimestampTZ, int64(1704067200123456))),
+			duckdb:   "created_at > TIMESTAMP '2024-01-01 00:00:00.123456'",
+			postgres: "created_at > TIMESTAMPTZ '2024-01-01 00:00:00.123456+00'",
+			mysql:    "created_at > TIMESTAMP '2024-01-01 00:00:00.123456+00:00'",
+			sqlite:   "created_at > '2024-01-01 00:00:00.123456'",
+		},
+		{
+			name:     "timestamp milliseconds",
+			expr:     gt(col("created_at"), evalConst(TypeIDTimestampMs, int64(1704067200250))),
+			duckdb:   "created_at > TIMESTAMP '2024-01-01 00:00:00.250000'",
+			postgres: "created_at > TIMESTAMP '2024-01-01 00:00:00.250000'",
+			mysql:    "created_at > TIMESTAMP '2024-01-01 00:00:00.250000'",
+			sqlite:   "created_at > '2024-01-01 00:00:00.250000'",
+		},
+		{
+			name:     "time",
+			expr:     evalCmp(TypeCompareGreaterThanOrEqual, col("event_time"), evalConst(TypeIDTime, int64(32400000000))),
+			duckdb:   "event_time >= TIME '09:00:00'",
+			postgres: "event_time >= TIME '09:00:00'",
+			mysql:    "event_time >= TIME '09:00:00'",
+			sqlite:   "event_time >= '09:00:00'",
+		},
+		{
+			name:     "interval",
+			expr:     gt(col("duration"), evalConst(TypeIDInterval, Interval{Months: 14, Days: 3})),
+			duckdb:   "duration > INTERVAL '1 years 2 months 3 days'",
+			postgres: "duration > INTERVAL '1 years 2 months 3 days'",
+		},
+		{
+			name:     "year",
+			expr:     eq(fn("year", col("birth_date")), i32(1990)),
+			duckdb:   "year(birth_date) = 1990",
+			postgres: "EXTRACT(YEAR FROM birth_date) = 1990",
+			mysql:    "YEAR(birth_date) = 1990",
+			sqlite:   "CAST(strftime('%Y', birth_date) AS INTEGER) = 1990",
+		},
+		{
+			name:     "date_part",
+			expr:     eq(fn("date_part", str("year"), col("birth_date")), i32(1990)),
+			duckdb:   "date_part('year', birth_date) = 1990",
+			postgres: "date_part('year', birth_date) = 1990",
+			mysql:    "EXTRACT(YEAR FROM birth_date) = 1990",
+		},
+
+		// Casts and literals
+		{
+			name:     "cast",
+			expr:     gt(evalCast(col("name"), TypeIDInteger), i32(10)),
+			duckdb:   "CAST(name AS INTEGER) > 10",
+			postgres: "CAST(name AS INTEGER) > 10",
+			mysql:    "CAST(name AS SIGNED) > 10",
+			sqlite:   "CAST(name AS INTEGER) > 10",
+		},
+		{
+			name:   "try cast",
+			expr:   gt(tryCast, evalConst(TypeIDDouble, 1.5)),
+			duckdb: "TRY_CAST(name AS DOUBLE) > 1.5",
+		},
+		{
+			name:     "string escaping",
+			expr:     eq(col("name"), str(`it's a \ test`)),
+			duckdb:   `name = 'it''s a \ test'`,
+			postgres: `name = 'it''s a \ test'`,
+			mysql:    `name = 'it''s a \\ test'`,
+			sqlite:   `name = 'it''s a \ test'`,
+		},
+		{
+			name:     "blob",
+			expr:     eq(col("name"), evalConst(TypeIDBlob, []byte{0xde, 0xad})),
+			duckdb:   `name = '\xdead'`,
+			postgres: `name = '\xdead'::bytea`,
+			mysql:    "name = X'dead'",
+			sqlite:   "name = X'dead'",
+		},
+		{
+			name:     "uuid",
+			expr:     eq(col("name"), evalConst(TypeIDUUID, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")),
+			duckdb:   "name = '6ba7b810-9dad-11d1-80b4-00c04fd430c8'",
+			postgres: "name = '6ba7b810-9dad-11d1-80b4-00c04fd430c8'::uuid",
+			mysql:    "name = '6ba7b810-9dad-11d1-80b4-00c04fd430c8'",
+			sqlite:   "name = '6ba7b810-9dad-11d1-80b4-00c04fd430c8'",
+		},
+		{
+			name: "list",
+			expr: eq(col("name"), evalConst(TypeIDList, ListValue{Children: []Value{
+				{Type: LogicalType{ID: TypeIDInteger}, Data: int64(1)},
+				{Type: LogicalType{ID: TypeIDInteger}, Data: int64(2)},
+			}})),
+			duckdb:   "name = [1, 2]",
+			postgres: "name = ARRAY[1, 2]",
+		},
+		same("hugeint", eq(col("id"), evalConst(TypeIDHugeInt, HugeInt{Upper: 1})),
+			"id = 18446744073709551616"),
+		same("null", eq(col("id"), evalConst(TypeIDInteger, nil)), "id = NULL"),
+
+		// Identifiers
+		{
+			name:     "mixed case identifier",
+			expr:     eq(col("userName"), i32(1)),
+			duckdb:   "userName = 1",
+			postgres: `"userName" = 1`,
+			mysql:    "userName = 1",
+			sqlite:   "userName = 1",
+		},
+		{
+			name:     "reserved identifier",
+			expr:     eq(col("order"), i32(1)),
+			duckdb:   `"order" = 1`,
+			postgres: `"order" = 1`,
+			mysql:    "`order` = 1",
+			sqlite:   `"order" = 1`,
+		},
+
+		// Unsupported children
+		same("and with unsupported child",
+			evalConj(TypeConjunctionAnd, gt(col("id"), i32(0)), evalUnsupported()), "id > 0"),
+		same("or with unsupported child",
+			evalConj(TypeConjunctionOr, eq(col("id"), i32(1)), evalUnsupported()), ""),
+		{
+			name:     "and with dialect unsupported child",
+			expr:     evalConj(TypeConjunctionAnd, gt(col("id"), i32(0)), fn("floor", col("rating"))),
+			duckdb:   "(id > 0 AND floor(rating))",
+			postgres: "(id > 0 AND floor(rating))",
+			mysql:    "(id > 0 AND FLOOR(rating))",
+			sqlite:   "id > 0",
+		},
+		{
+			name: "or with dialect unsupported child",
+			expr: evalConj(TypeConjunctionOr,
+				eq(col("id"), i32(1)),
+				fn("regexp_matches", col("name"), str("^J")),
+			),
+			duckdb:   "(id = 1 OR regexp_matches(name, '^J'))",
+			postgres: "(id = 1 OR (name ~ '^J'))",
+			mysql:    "(id = 1 OR REGEXP_LIKE(name, '^J', 'c'))",
+		},
+	}
+}
+
+func TestEncoderCorpus(t *testing.T) {
+	for _, tc := range encoderCorpus(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			fp := &FilterPushdown{
+				Filters:        []Expression{tc.expr},
+				ColumnBindings: corpusColumns,
+			}
+			for dialect, enc := range corpusEncoders(nil) {
+				want := tc.expected(dialect)
+				if got := enc.EncodeFilters(fp); got != want {
+					t.Errorf("%s: expected %q, got %q", dialect, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestEncoderCorpusMultipleFilters(t *testing.T) {
+	// Filters unsupported by a dialect are dropped from the top-level AND.
+	fp := &FilterPushdown{
+		Filters: []Expression{
+			evalCmp(TypeCompareGreaterThan, corpusCol(t, "id"), evalConst(TypeIDInteger, int64(0))),
+			evalFunc("regexp_matches", false, corpusCol(t, "name"), evalConst(TypeIDVarchar, "^J")),
+		},
+		ColumnBindings: corpusColumns,
+	}
+
+	expected := map[string]string{
+		"duckdb":   "(id > 0) AND (regexp_matches(name, '^J'))",
+		"postgres": "(id > 0) AND ((name ~ '^J'))",
+		"mysql":    "(id > 0) AND (REGEXP_LIKE(name, '^J', 'c'))",
+		"sqlite":   "id > 0",
+	}
+	for dialect, enc := range corpusEncoders(nil) {
+		if got := enc.EncodeFilters(fp); got != expected[dialect] {
+			t.Errorf("%s: expected %q, got %q", dialect, expected[dialect], got)
+		}
+	}
+}
+
+func TestEncoderCorpusColumnMapping(t *testing.T) {
+	fp := &FilterPushdown{
+		Filters: []Expression{
+			evalCmp(TypeCompareEqual, corpusCol(t, "name"), evalConst(TypeIDVarchar, "x")),
+			evalCmp(TypeCompareEqual, corpusCol(t, "id"), evalConst(TypeIDInteger, int64(1))),
+		},
+		ColumnBindings: corpusColumns,
+	}
+	opts := &EncoderOptions{
+		ColumnMapping:     map[string]string{"name": "Full Name"},
+		ColumnExpressions: map[string]string{"id": "raw_id + 1"},
+	}
+
+	expected := map[string]string{
+		"duckdb":   `("Full Name" = 'x') AND (raw_id + 1 = 1)`,
+		"postgres": `("Full Name" = 'x') AND (raw_id + 1 = 1)`,
+		"mysql":    "(`Full Name` = 'x') AND (raw_id + 1 = 1)",
+		"sqlite":   `("Full Name" = 'x') AND (raw_id + 1 = 1)`,
+	}
+	for dialect, enc := range corpusEncoders(opts) {
+		if got := enc.EncodeFilters(fp); got != expected[dialect] {
+			t.Errorf("%s: expected %q, got %q", dialect, expected[dialect], got)
+		}
+	}
+}
+
+func TestEncoderCorpusWithArgs(t *testing.T) {
+	col := func(name string) Expression { return corpusCol(t, name) }
+	str := func(v string) Expression { return evalConst(TypeIDVarchar, v) }
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		sql  string
+		args []any
+	}
+	tests := []struct {
+		name     string
+		filters  []Expression
+		duckdb   result
+		postgres result
+		mysql    result
+		sqlite   result
+	}{
+		{
+			name: "comparison and like",
+			filters: []Expression{evalConj(TypeConjunctionAnd,
+				evalCmp(TypeCompareEqual, col("id"), evalConst(TypeIDInteger, int64(42))),
+				evalFunc("~~", true, col("name"), str("it's%")),
+			)},
+			duckdb:   result{"(id = ? AND name LIKE ?)", []any{int64(42), "it's%"}},
+			postgres: result{"(id = $1 AND name LIKE $2::text ESCAPE '')", []any{int64(42), "it's%"}},
+			mysql:    result{"(id = ? AND name LIKE ? ESCAPE '')", []any{int64(42), "it's%"}},
+			sqlite:   result{"(id = ? AND name LIKE ?)", []any{int64(42), "it's%"}},
+		},
+		{
+			name:     "repeated argument",
+			filters:  []Expression{evalFunc("suffix", false, col("email"), str(".com"))},
+			duckdb:   result{"suffix(email, ?)", []any{".com"}},
+			postgres: result{"(right(email, length($1::text)) = $2::text)", []any{".com", ".com"}},
+			mysql:    result{"(RIGHT(email, CHAR_LENGTH(?)) = ?)", []any{".com", ".com"}},
+			sqlite:   result{"(substr(email, length(email) - length(?) + 1) = ?)", []any{".com", ".com"}},
+		},
+		{
+			name: "dropped filter arguments",
+			filters: []Expression{
+				evalFunc("regexp_matches", false, col("name"), str("^J")),
+				evalCmp(TypeCompareGreaterThan, col("created_at"), evalConst(TypeIDTimestamp, created.UnixMicro())),
+			},
+			duckdb:   result{"(regexp_matches(name, ?)) AND (created_at > ?)", []any{"^J", created}},
+			postgres: result{"((name ~ $1::text)) AND (created_at > $2)", []any{"^J", created}},
+			mysql:    result{"(REGEXP_LIKE(name, ?, 'c')) AND (created_at > ?)", []any{"^J", created}},
+			sqlite:   result{"created_at > ?", []any{created}},
+		},
+		{
+			name: "date part stays literal",
+			filters: []Expression{evalCmp(TypeCompareEqual,
+				evalFunc("date_part", false, str("year"), col("birth_date")),
+				evalConst(TypeIDInteger, int64(1990)),
+			)},
+			duckdb:   result{"date_part('year', birth_date) = ?", []any{int64(1990)}},
+			postgres: result{"date_part('year', birth_date) = $1", []any{int64(1990)}},
+			mysql:    result{"EXTRACT(YEAR FROM birth_date) = ?", []any{int64(1990)}},
+		},
+		{
+			name:     "regexp full match",
+			filters:  []Expression{evalFunc("~", true, col("name"), str("J.*"))},
+			duckdb:   result{"name ~ ?", []any{"J.*"}},
+			postgres: result{"name ~ ('^(?:' || $1::text || ')$')", []any{"J.*"}},
+			mysql:    result{"REGEXP_LIKE(name, CONCAT('^(?:', ?, ')$'), 'c')", []any{"J.*"}},
+		},
+		{
+			name: "null and list stay inline",
+			filters: []Expression{evalConj(TypeConjunctionAnd,
+				evalCmp(TypeCompareEqual, col("id"), evalConst(TypeIDInteger, nil)),
+				evalCmp(TypeCompareEqual, col("name"), evalConst(TypeIDList, ListValue{Children: []Value{
+					{Type: LogicalType{ID: TypeIDVarchar}, Data: "a"},
+				}})),
+			)},
+			duckdb:   result{"(id = NULL AND name = ['a'])", nil},
+			postgres: result{"(id = NULL AND name = ARRAY['a'])", nil},
+			mysql:    result{"id = NULL", nil},
+			sqlite:   result{"id = NULL", nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := &FilterPushdown{Filters: tt.filters, ColumnBindings: corpusColumns}
+			expected := map[string]result{
+				"duckdb":   tt.duckdb,
+				"postgres": tt.postgres,
+				"mysql":    tt.mysql,
+				"sqlite":   tt.sqlite,
+			}
+			for dialect, enc := range corpusEncoders(nil) {
+				sql, args := enc.(ParameterizedEncoder).EncodeFiltersWithArgs(fp)
+				want := expected[dialect]
+				if sql != want.sql {
+					t.Errorf("%s: expected %q, got %q", dialect, want.sql, sql)
+				}
+				if !reflect.DeepEqual(args, want.args) {
+					t.Errorf("%s: expected args %#v, got %#v", dialect, want.args, args)
+				}
+
+				// Literal encoding is not affected by a parameterized call.
+				fresh := corpusEncoders(nil)[dialect].EncodeFilters(fp)
+				if literal := enc.EncodeFilters(fp); literal != fresh {
+					t.Errorf("%s: expected %q after parameterized call, got %q", dialect, fresh, literal)
+				}
+			}
+		})
+	}
+}
+
+func TestEncoderArgTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value Expression
+		arg   any
+	}{
+		{"boolean", evalConst(TypeIDBoolean, true), true},
+		{"smallint", evalConst(TypeIDSmallInt, float64(7)), int64(7)},
+		{"ubigint", evalConst(TypeIDUBigInt, uint64(1<<63)), uint64(1 << 63)},
+		{"hugeint", evalConst(TypeIDHugeInt, HugeInt{Upper: -1, Lower: 1<<64 - 5}), int64(-5)},
+		{"large hugeint", evalConst(TypeIDHugeInt, HugeInt{Upper: 1}), "18446744073709551616"},
+		{"uhugeint", evalConst(TypeIDUHugeInt, UHugeInt{Lower: 9}), uint64(9)},
+		{"double", evalConst(TypeIDDouble, 1.5), 1.5},
+		{"decimal", evalConst(TypeIDDecimal, "10.50"), "10.50"},
+		{"varchar", evalConst(TypeIDVarchar, "x"), "x"},
+		{"uuid", evalConst(TypeIDUUID, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"), "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"blob", evalConst(TypeIDBlob, []byte{1, 2}), []byte{1, 2}},
+		{"date", evalConst(TypeIDDate, int64(7305)), time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"time", evalConst(TypeIDTime, int64(32400000001)), "09:00:00.000001"},
+		{"timestamp ms", evalConst(TypeIDTimestampMs, int64(1704067200250)), time.Date(2024, 1, 1, 0, 0, 0, 250e6, time.UTC)},
+		{"timestamp ns", evalConst(TypeIDTimestampNs, int64(1704067200000000001)), time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC)},
+		{"timestamp tz", evalConst(TypeIDTimestampTZ, int64(1704067200000000)), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"interval", evalConst(TypeIDInterval, Interval{Days: 1, Micros: 3600000000}), "1 days 1 hours"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := &FilterPushdown{
+				Filters:        []Expression{evalCmp(TypeCompareEqual, evalCol(0), tt.value)},
+				ColumnBindings: []string{"col"},
+			}
+			sql, args := NewDuckDBEncoder(nil).EncodeFiltersWithArgs(fp)
+			if sql != "col = ?" {
+				t.Errorf("expected 'col = ?', got %q", sql)
+			}
+			if len(args) != 1 || !reflect.DeepEqual(args[0], tt.arg) {
+				t.Errorf("expected arg %#v, got %#v", tt.arg, args)
+			}
+		})
+	}
+}
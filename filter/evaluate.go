@@ -0,0 +1,296 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/arrow/scalar"
+)
+
+// errUnsupported marks expressions the evaluator cannot compute.
+// Filters that hit it are skipped following the same rules as the SQL encoders.
+var errUnsupported = errors.New("unsupported expression")
+
+// EvaluatorOptions configures evaluation behavior.
+type EvaluatorOptions struct {
+	// ColumnMapping maps filter column names to record batch field names.
+	// Columns not in the map are looked up by their original names.
+	ColumnMapping map[string]string
+
+	// Allocator is used for masks and intermediate arrays.
+	// Uses memory.DefaultAllocator if nil.
+	Allocator memory.Allocator
+}
+
+// Evaluator applies parsed filter expressions to Arrow record batches.
+// It is the in-process counterpart of the SQL encoders for backends that
+// hold data as Arrow (in-memory tables, Parquet files, key-value stores).
+//
+// Expressions are compiled once by NewEvaluator and evaluated per batch with
+// Arrow compute kernels. Unsupported expressions follow the encoder rules:
+//   - For AND: unsupported children are skipped
+//   - For OR: if any child is unsupported, the entire OR is skipped
+//   - A skipped top-level filter selects every row
+//
+// The resulting selection may be wider than the original filter, which is
+// safe because DuckDB re-applies filters client-side.
+//
+// An Evaluator is safe for concurrent use.
+type Evaluator struct {
+	filters []predicate
+	alloc   memory.Allocator
+}
+
+// valueFunc evaluates an expression against a record batch.
+// The returned datum is owned by the caller and must be released.
+type valueFunc func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error)
+
+// predicate is a compiled boolean expression.
+// exact is false when unsupported AND children were skipped, so the
+// predicate may select more rows than the original expression.
+type predicate struct {
+	eval  valueFunc
+	exact bool
+}
+
+// NewEvaluator compiles the filters of fp for evaluation.
+// If fp is nil or has no filters, the evaluator selects every row.
+// If opts is nil, default options are used.
+func NewEvaluator(fp *FilterPushdown, opts *EvaluatorOptions) *Evaluator {
+	if opts == nil {
+		opts = &EvaluatorOptions{}
+	}
+	alloc := opts.Allocator
+	if alloc == nil {
+		alloc = memory.DefaultAllocator
+	}
+
+	ev := &Evaluator{alloc: alloc}
+	if fp == nil {
+		return ev
+	}
+
+	c := &evalCompiler{fp: fp, opts: opts}
+	for _, f := range fp.Filters {
+		p, err := c.predicate(f)
+		if err != nil {
+			continue
+		}
+		ev.filters = append(ev.filters, p)
+	}
+	return ev
+}
+
+// Empty reports whether the evaluator has no filters it can apply,
+// in which case every row is selected.
+func (e *Evaluator) Empty() bool {
+	return len(e.filters) == 0
+}
+
+// Evaluate computes the selection mask for rec.
+// The mask has one non-null entry per row: true if the row passes all filters.
+// Rows where a filter evaluates to NULL are not selected, as in a SQL WHERE clause.
+// The caller must release the returned array.
+func (e *Evaluator) Evaluate(ctx context.Context, rec arrow.RecordBatch) (*array.Boolean, error) {
+	ctx = compute.WithAllocator(ctx, e.alloc)
+	n := int(rec.NumRows())
+
+	var mask compute.Datum
+	for _, f := range e.filters {
+		d, err := f.eval(ctx, rec)
+		if errors.Is(err, errUnsupported) {
+			// Skipping a top-level filter only widens the selection.
+			continue
+		}
+		if err != nil {
+			if mask != nil {
+				mask.Release()
+			}
+			return nil, err
+		}
+		if mask == nil {
+			mask = d
+			continue
+		}
+		combined, err := compute.CallFunction(ctx, "and_kleene", nil, mask, d)
+		mask.Release()
+		d.Release()
+		if err != nil {
+			return nil, fmt.Errorf("failed to combine filters: %w", err)
+		}
+		mask = combined
+	}
+
+	if mask == nil {
+		return selectAll(e.alloc, n, true), nil
+	}
+	defer mask.Release()
+	return selectionMask(e.alloc, mask, n)
+}
+
+// Filter returns a record batch with the rows of rec selected by the evaluator.
+// The caller must release the returned batch.
+func (e *Evaluator) Filter(ctx context.Context, rec arrow.RecordBatch) (arrow.RecordBatch, error) {
+	if e.Empty() {
+		rec.Retain()
+		return rec, nil
+	}
+
+	mask, err := e.Evaluate(ctx, rec)
+	if err != nil {
+		return nil, err
+	}
+	defer mask.Release()
+
+	if mask.NullN() == 0 && countTrue(mask) == mask.Len() {
+		rec.Retain()
+		return rec, nil
+	}
+	return compute.FilterRecordBatch(compute.WithAllocator(ctx, e.alloc), rec, mask, compute.DefaultFilterOptions())
+}
+
+// selectAll returns a boolean array of length n with every entry set to v.
+func selectAll(alloc memory.Allocator, n int, v bool) *array.Boolean {
+	b := array.NewBooleanBuilder(alloc)
+	defer b.Release()
+	b.Reserve(n)
+	for range n {
+		b.UnsafeAppend(v)
+	}
+	return b.NewBooleanArray()
+}
+
+// selectionMask converts a boolean datum into a mask of length n with nulls mapped to false.
+func selectionMask(alloc memory.Allocator, d compute.Datum, n int) (*array.Boolean, error) {
+	switch v := d.(type) {
+	case *compute.ScalarDatum:
+		b, ok := v.Value.(*scalar.Boolean)
+		if !ok {
+			return nil, fmt.Errorf("filter evaluated to %s, expected boolean", v.Value.DataType())
+		}
+		return selectAll(alloc, n, b.Valid && b.Value), nil
+	case *compute.ArrayDatum:
+		arr := v.MakeArray()
+		defer arr.Release()
+		b, ok := arr.(*array.Boolean)
+		if !ok {
+			return nil, fmt.Errorf("filter evaluated to %s, expected boolean", arr.DataType())
+		}
+		if b.Len() != n {
+			return nil, fmt.Errorf("filter produced %d values for %d rows", b.Len(), n)
+		}
+		if b.NullN() == 0 {
+			b.Retain()
+			return b, nil
+		}
+		bld := array.NewBooleanBuilder(alloc)
+		defer bld.Release()
+		bld.Reserve(n)
+		for i := range n {
+			bld.UnsafeAppend(b.IsValid(i) && b.Value(i))
+		}
+		return bld.NewBooleanArray(), nil
+	default:
+		return nil, fmt.Errorf("unexpected filter result %s", d)
+	}
+}
+
+// countTrue returns the number of true entries in a mask without nulls.
+func countTrue(b *array.Boolean) int {
+	count := 0
+	for i := range b.Len() {
+		if b.Value(i) {
+			count++
+		}
+	}
+	return count
+}
+
+// FilterRecordReader returns a reader that yields the rows of rdr selected by ev.
+// Batches left empty by the filter are dropped.
+//
+// The returned reader takes ownership of rdr and releases it when released.
+// Evaluation errors are reported through Err.
+func FilterRecordReader(ctx context.Context, rdr array.RecordReader, ev *Evaluator) array.RecordReader {
+	r := &filterReader{ctx: ctx, src: rdr, ev: ev}
+	r.refCount.Add(1)
+	return r
+}
+
+// filterReader applies an Evaluator to each batch of a source reader.
+type filterReader struct {
+	refCount atomic.Int64
+
+	ctx context.Context
+	src array.RecordReader
+	ev  *Evaluator
+	out arrow.RecordBatch
+	err error
+}
+
+func (r *filterReader) Retain() {
+	r.refCount.Add(1)
+}
+
+func (r *filterReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		if r.out != nil {
+			r.out.Release()
+			r.out = nil
+		}
+		r.src.Release()
+	}
+}
+
+func (r *filterReader) Schema() *arrow.Schema {
+	return r.src.Schema()
+}
+
+func (r *filterReader) RecordBatch() arrow.RecordBatch {
+	return r.out
+}
+
+// Deprecated: Use [filterReader.RecordBatch] instead.
+func (r *filterReader) Record() arrow.RecordBatch {
+	return r.out
+}
+
+func (r *filterReader) Err() error {
+	return r.err
+}
+
+func (r *filterReader) Next() bool {
+	if r.out != nil {
+		r.out.Release()
+		r.out = nil
+	}
+	if r.err != nil {
+		return false
+	}
+
+	for r.src.Next() {
+		if err := r.ctx.Err(); err != nil {
+			r.err = err
+			return false
+		}
+		out, err := r.ev.Filter(r.ctx, r.src.RecordBatch())
+		if err != nil {
+			r.err = err
+			return false
+		}
+		if out.NumRows() == 0 {
+			out.Release()
+			continue
+		}
+		r.out = out
+		return true
+	}
+	r.err = r.src.Err()
+	return false
+}
@@ -0,0 +1,613 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/scalar"
+)
+
+// evalCompiler turns filter expressions into evaluation closures.
+// Expressions that cannot be evaluated return errUnsupported.
+type evalCompiler struct {
+	fp   *FilterPushdown
+	opts *EvaluatorOptions
+}
+
+// predicate compiles a boolean expression.
+// Only conjunctions may be compiled partially; everything else is exact or unsupported.
+func (c *evalCompiler) predicate(expr Expression) (predicate, error) {
+	if conj, ok := expr.(*ConjunctionExpression); ok {
+		return c.conjunction(conj)
+	}
+	fn, err := c.value(expr)
+	if err != nil {
+		return predicate{}, err
+	}
+	return predicate{eval: fn, exact: true}, nil
+}
+
+// exactPredicate compiles a boolean expression whose result is used as a value
+// (e.g. under NOT), where a widened selection would change the outcome.
+func (c *evalCompiler) exactPredicate(expr Expression) (valueFunc, error) {
+	p, err := c.predicate(expr)
+	if err != nil {
+		return nil, err
+	}
+	if !p.exact {
+		return nil, errUnsupported
+	}
+	return p.eval, nil
+}
+
+// value compiles an expression producing a datum.
+func (c *evalCompiler) value(expr Expression) (valueFunc, error) {
+	switch ex := expr.(type) {
+	case *ColumnRefExpression:
+		return c.columnRef(ex)
+	case *ConstantExpression:
+		return constant(ex.Value)
+	case *ComparisonExpression:
+		return c.comparison(ex)
+	case *ConjunctionExpression:
+		return c.exactPredicate(ex)
+	case *CastExpression:
+		return c.cast(ex)
+	case *BetweenExpression:
+		return c.between(ex)
+	case *OperatorExpression:
+		return c.operator(ex)
+	case *FunctionExpression:
+		return c.function(ex)
+	default:
+		// CASE, parameters, aggregates and window functions are not evaluated
+		return nil, errUnsupported
+	}
+}
+
+// values compiles a list of expressions.
+func (c *evalCompiler) values(exprs []Expression) ([]valueFunc, error) {
+	fns := make([]valueFunc, len(exprs))
+	for i, expr := range exprs {
+		fn, err := c.value(expr)
+		if err != nil {
+			return nil, err
+		}
+		fns[i] = fn
+	}
+	return fns, nil
+}
+
+// columnRef compiles a column reference resolved by name against each batch.
+func (c *evalCompiler) columnRef(ref *ColumnRefExpression) (valueFunc, error) {
+	name, err := c.fp.ColumnName(ref)
+	if err != nil {
+		return nil, errUnsupported
+	}
+	if mapped, ok := c.opts.ColumnMapping[name]; ok {
+		name = mapped
+	}
+
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		indices := rec.Schema().FieldIndices(name)
+		if len(indices) == 0 {
+			return nil, fmt.Errorf("column %q not found in record batch", name)
+		}
+		return compute.NewDatum(rec.Column(indices[0])), nil
+	}, nil
+}
+
+// constant compiles a literal value into a scalar.
+func constant(v Value) (valueFunc, error) {
+	sc, err := makeScalar(v)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		return compute.NewDatum(sc), nil
+	}, nil
+}
+
+// conjunction compiles AND/OR.
+// Unsupported AND children are skipped; an unsupported OR child makes the OR unsupported.
+func (c *evalCompiler) conjunction(conj *ConjunctionExpression) (predicate, error) {
+	isOr := conj.Type() == TypeConjunctionOr
+	if !isOr && conj.Type() != TypeConjunctionAnd {
+		return predicate{}, errUnsupported
+	}
+
+	exact := true
+	var children []valueFunc
+	for _, child := range conj.Children {
+		p, err := c.predicate(child)
+		if err != nil {
+			if isOr {
+				return predicate{}, err
+			}
+			exact = false
+			continue
+		}
+		exact = exact && p.exact
+		children = append(children, p.eval)
+	}
+	if len(children) == 0 {
+		return predicate{}, errUnsupported
+	}
+
+	fn := "and_kleene"
+	if isOr {
+		fn = "or_kleene"
+	}
+	return predicate{eval: fold(fn, children), exact: exact}, nil
+}
+
+// comparison compiles binary comparisons, IN and DISTINCT FROM.
+func (c *evalCompiler) comparison(cmp *ComparisonExpression) (valueFunc, error) {
+	switch cmp.Type() {
+	case TypeCompareIn, TypeCompareNotIn:
+		list, ok := cmp.Right.(*FunctionExpression)
+		if !ok {
+			return nil, errUnsupported
+		}
+		return c.in(cmp.Left, list.Children, cmp.Type() == TypeCompareNotIn)
+	}
+
+	left, err := c.value(cmp.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.value(cmp.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cmp.Type() {
+	case TypeCompareEqual:
+		return compare("equal", left, right), nil
+	case TypeCompareNotEqual:
+		return compare("not_equal", left, right), nil
+	case TypeCompareLessThan:
+		return compare("less", left, right), nil
+	case TypeCompareGreaterThan:
+		return compare("greater", left, right), nil
+	case TypeCompareLessThanOrEqual:
+		return compare("less_equal", left, right), nil
+	case TypeCompareGreaterThanOrEqual:
+		return compare("greater_equal", left, right), nil
+	case TypeCompareDistinctFrom:
+		return distinctFrom(left, right, false), nil
+	case TypeCompareNotDistinctFrom:
+		return distinctFrom(left, right, true), nil
+	default:
+		// BETWEEN is handled by BetweenExpression
+		return nil, errUnsupported
+	}
+}
+
+// between compiles BETWEEN and NOT BETWEEN with either bound inclusive or exclusive.
+func (c *evalCompiler) between(b *BetweenExpression) (valueFunc, error) {
+	input, err := c.value(b.Input)
+	if err != nil {
+		return nil, err
+	}
+	lower, err := c.value(b.Lower)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := c.value(b.Upper)
+	if err != nil {
+		return nil, err
+	}
+
+	lowerFn, upperFn := "greater", "less"
+	if b.LowerInclusive {
+		lowerFn = "greater_equal"
+	}
+	if b.UpperInclusive {
+		upperFn = "less_equal"
+	}
+
+	fn := fold("and_kleene", []valueFunc{compare(lowerFn, input, lower), compare(upperFn, input, upper)})
+	if b.Type() == TypeCompareNotBetween {
+		return apply("not", fn), nil
+	}
+	return fn, nil
+}
+
+// operator compiles IS NULL, IS NOT NULL, NOT, IN and NOT IN.
+func (c *evalCompiler) operator(o *OperatorExpression) (valueFunc, error) {
+	if len(o.Children) == 0 {
+		return nil, errUnsupported
+	}
+
+	switch o.Type() {
+	case TypeOperatorIsNull, TypeOperatorIsNotNull:
+		child, err := c.value(o.Children[0])
+		if err != nil {
+			return nil, err
+		}
+		if o.Type() == TypeOperatorIsNull {
+			return apply("is_null", child), nil
+		}
+		return apply("is_not_null", child), nil
+
+	case TypeOperatorNot:
+		// NOT over a widened predicate would narrow the selection.
+		child, err := c.exactPredicate(o.Children[0])
+		if err != nil {
+			return nil, err
+		}
+		return apply("not", child), nil
+
+	case TypeCompareIn, TypeCompareNotIn:
+		if len(o.Children) < 2 {
+			return nil, errUnsupported
+		}
+		return c.in(o.Children[0], o.Children[1:], o.Type() == TypeCompareNotIn)
+
+	default:
+		// COALESCE and NULLIF are not evaluated
+		return nil, errUnsupported
+	}
+}
+
+// in compiles IN and NOT IN over a list of constants.
+// NULL list entries follow SQL semantics: rows that do not match are NULL, not false.
+func (c *evalCompiler) in(inputExpr Expression, list []Expression, negate bool) (valueFunc, error) {
+	input, err := c.value(inputExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	var set []scalar.Scalar
+	hasNull := false
+	for _, item := range list {
+		k, ok := item.(*ConstantExpression)
+		if !ok {
+			return nil, errUnsupported
+		}
+		if k.Value.IsNull {
+			hasNull = true
+			continue
+		}
+		sc, err := makeScalar(k.Value)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, sc)
+	}
+	if len(set) == 0 && !hasNull {
+		return nil, errUnsupported
+	}
+
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		if len(set) == 0 {
+			return compute.NewDatum(scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean)), nil
+		}
+
+		d, err := input(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		defer d.Release()
+
+		values, err := materialize(ctx, d, int(rec.NumRows()))
+		if err != nil {
+			return nil, err
+		}
+		defer values.Release()
+
+		valueSet, err := makeValueSet(ctx, set, values.DataType())
+		if err != nil {
+			return nil, err
+		}
+		defer valueSet.Release()
+
+		out, err := compute.IsIn(ctx, compute.SetOptions{
+			ValueSet:     compute.NewDatumWithoutOwning(valueSet),
+			NullBehavior: compute.NullMatchingEmitNull,
+		}, compute.NewDatumWithoutOwning(values))
+		if err != nil {
+			return nil, unsupportedKernel("is_in", err)
+		}
+
+		if hasNull {
+			withNull, err := call(ctx, "or_kleene", out, compute.NewDatum(scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean)))
+			out.Release()
+			if err != nil {
+				return nil, err
+			}
+			out = withNull
+		}
+		if !negate {
+			return out, nil
+		}
+		defer out.Release()
+		return call(ctx, "not", out)
+	}, nil
+}
+
+// cast compiles CAST and TRY_CAST.
+// Values that cannot be converted make the expression unsupported rather than failing.
+func (c *evalCompiler) cast(ce *CastExpression) (valueFunc, error) {
+	child, err := c.value(ce.Child)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := arrowType(ce.ReturnType)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		d, err := child(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		if arrow.TypeEqual(datumType(d), target) {
+			return d, nil
+		}
+		defer d.Release()
+		return castDatum(ctx, d, target)
+	}, nil
+}
+
+// function compiles arithmetic operators and the common string functions.
+func (c *evalCompiler) function(f *FunctionExpression) (valueFunc, error) {
+	args, err := c.values(f.Children)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(f.Name)
+	switch name {
+	case "+":
+		if len(args) == 1 {
+			return args[0], nil
+		}
+		if len(args) == 2 {
+			return apply("add", args...), nil
+		}
+	case "-":
+		if len(args) == 1 {
+			return apply("negate", args...), nil
+		}
+		if len(args) == 2 {
+			return apply("subtract", args...), nil
+		}
+	case "*":
+		if len(args) == 2 {
+			return apply("multiply", args...), nil
+		}
+	}
+
+	if fn, ok := stringFunction(name, args); ok {
+		return fn, nil
+	}
+	return nil, errUnsupported
+}
+
+// compare compiles a binary comparison kernel call.
+// If the operand types have no common kernel, a constant operand is cast to the
+// type of the other side, e.g. a VARCHAR constant compared to a UUID column.
+func compare(name string, left, right valueFunc) valueFunc {
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		args, err := evalArgs(ctx, rec, left, right)
+		if err != nil {
+			return nil, err
+		}
+		defer releaseAll(args)
+
+		out, err := call(ctx, name, args...)
+		if err == nil || !errors.Is(err, errUnsupported) {
+			return out, err
+		}
+		if !alignScalar(ctx, args) {
+			return nil, err
+		}
+		return call(ctx, name, args...)
+	}
+}
+
+// alignScalar casts a scalar argument to the type of the other argument in place.
+// Returns false if there is no scalar to cast or the cast fails.
+func alignScalar(ctx context.Context, args []compute.Datum) bool {
+	for i, arg := range args {
+		if arg.Kind() != compute.KindScalar {
+			continue
+		}
+		other := args[1-i]
+		if other.Kind() == compute.KindScalar {
+			return false
+		}
+		cast, err := castDatum(ctx, arg, datumType(other))
+		if err != nil {
+			return false
+		}
+		arg.Release()
+		args[i] = cast
+		return true
+	}
+	return false
+}
+
+// distinctFrom compiles IS [NOT] DISTINCT FROM, which treats NULLs as comparable values.
+func distinctFrom(left, right valueFunc, negate bool) valueFunc {
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		args, err := evalArgs(ctx, rec, left, right)
+		if err != nil {
+			return nil, err
+		}
+		defer releaseAll(args)
+
+		// distinct = (a <> b AND a, b NOT NULL) OR (a IS NULL XOR b IS NULL)
+		ne := compare("not_equal", borrowed(args[0]), borrowed(args[1]))
+		bothValid := fold("and_kleene", []valueFunc{apply("is_not_null", borrowed(args[0])), apply("is_not_null", borrowed(args[1]))})
+		oneNull := apply("xor", apply("is_null", borrowed(args[0])), apply("is_null", borrowed(args[1])))
+		distinct := fold("or_kleene", []valueFunc{fold("and_kleene", []valueFunc{ne, bothValid}), oneNull})
+		if negate {
+			distinct = apply("not", distinct)
+		}
+		return distinct(ctx, rec)
+	}
+}
+
+// borrowed returns a valueFunc yielding an already computed datum.
+// Each call hands out a new reference, so callers release it as usual.
+func borrowed(d compute.Datum) valueFunc {
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		switch v := d.(type) {
+		case *compute.ArrayDatum:
+			v.Value.Retain()
+			return &compute.ArrayDatum{Value: v.Value}, nil
+		case *compute.ScalarDatum:
+			return compute.NewDatum(v.Value), nil
+		default:
+			return nil, fmt.Errorf("%w: unexpected datum %s", errUnsupported, d)
+		}
+	}
+}
+
+// apply compiles a call of the compute function name over the results of args.
+func apply(name string, args ...valueFunc) valueFunc {
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		vals, err := evalArgs(ctx, rec, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer releaseAll(vals)
+		return call(ctx, name, vals...)
+	}
+}
+
+// fold compiles a left fold of the binary compute function name over args.
+func fold(name string, args []valueFunc) valueFunc {
+	if len(args) == 1 {
+		return args[0]
+	}
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		acc, err := args[0](ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range args[1:] {
+			d, err := arg(ctx, rec)
+			if err != nil {
+				acc.Release()
+				return nil, err
+			}
+			next, err := call(ctx, name, acc, d)
+			acc.Release()
+			d.Release()
+			if err != nil {
+				return nil, err
+			}
+			acc = next
+		}
+		return acc, nil
+	}
+}
+
+// evalArgs evaluates fns in order.
+// On error, already computed datums are released.
+func evalArgs(ctx context.Context, rec arrow.RecordBatch, fns ...valueFunc) ([]compute.Datum, error) {
+	out := make([]compute.Datum, 0, len(fns))
+	for _, fn := range fns {
+		d, err := fn(ctx, rec)
+		if err != nil {
+			releaseAll(out)
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// releaseAll releases every datum in ds.
+func releaseAll(ds []compute.Datum) {
+	for _, d := range ds {
+		d.Release()
+	}
+}
+
+// call invokes the compute function name.
+// Missing kernels for the argument types are reported as unsupported.
+func call(ctx context.Context, name string, args ...compute.Datum) (compute.Datum, error) {
+	out, err := compute.CallFunction(ctx, name, nil, args...)
+	if err != nil {
+		return nil, unsupportedKernel(name, err)
+	}
+	return out, nil
+}
+
+// unsupportedKernel wraps a compute error, marking type mismatches as unsupported.
+func unsupportedKernel(name string, err error) error {
+	if errors.Is(err, arrow.ErrNotImplemented) || errors.Is(err, arrow.ErrType) {
+		return fmt.Errorf("%w: %s: %v", errUnsupported, name, err)
+	}
+	return fmt.Errorf("failed to evaluate %s: %w", name, err)
+}
+
+// castDatum casts d to target, rejecting lossy conversions.
+// Failed casts are reported as unsupported.
+func castDatum(ctx context.Context, d compute.Datum, target arrow.DataType) (compute.Datum, error) {
+	out, err := compute.CastDatum(ctx, d, compute.SafeCastOptions(target))
+	if err != nil {
+		return nil, fmt.Errorf("%w: cast to %s: %v", errUnsupported, target, err)
+	}
+	return out, nil
+}
+
+// datumType returns the data type of an array or scalar datum.
+func datumType(d compute.Datum) arrow.DataType {
+	if ad, ok := d.(compute.ArrayLikeDatum); ok {
+		return ad.Type()
+	}
+	return arrow.Null
+}
+
+// materialize returns d as an array of n rows, broadcasting scalars.
+// The caller must release the returned array.
+func materialize(ctx context.Context, d compute.Datum, n int) (arrow.Array, error) {
+	switch v := d.(type) {
+	case *compute.ArrayDatum:
+		return v.MakeArray(), nil
+	case *compute.ScalarDatum:
+		return scalar.MakeArrayFromScalar(v.Value, n, compute.GetAllocator(ctx))
+	default:
+		return nil, fmt.Errorf("%w: unexpected datum %s", errUnsupported, d)
+	}
+}
+
+// makeValueSet builds an array of type dt from the IN list constants.
+func makeValueSet(ctx context.Context, set []scalar.Scalar, dt arrow.DataType) (arrow.Array, error) {
+	alloc := compute.GetAllocator(ctx)
+	arrs := make([]arrow.Array, 0, len(set))
+	defer func() {
+		for _, a := range arrs {
+			a.Release()
+		}
+	}()
+
+	for _, sc := range set {
+		arr, err := scalar.MakeArrayFromScalar(sc, 1, alloc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errUnsupported, err)
+		}
+		if !arrow.TypeEqual(arr.DataType(), dt) {
+			cast, err := compute.CastArray(ctx, arr, compute.SafeCastOptions(dt))
+			arr.Release()
+			if err != nil {
+				return nil, fmt.Errorf("%w: cast to %s: %v", errUnsupported, dt, err)
+			}
+			arr = cast
+		}
+		arrs = append(arrs, arr)
+	}
+	return array.Concatenate(arrs, alloc)
+}
@@ -0,0 +1,243 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+)
+
+// stringValues is implemented by the Arrow string, large string and string view arrays.
+type stringValues interface {
+	arrow.Array
+	Value(i int) string
+}
+
+// stringMatcher tests a string against a compiled pattern.
+type stringMatcher func(s string) bool
+
+// stringFunction compiles the common DuckDB string functions.
+// Arrow Go has no string compute kernels, so these loop over the values directly.
+func stringFunction(name string, args []valueFunc) (valueFunc, bool) {
+	switch name {
+	case "lower", "lcase":
+		if len(args) == 1 {
+			return mapString(args[0], strings.ToLower), true
+		}
+	case "upper", "ucase":
+		if len(args) == 1 {
+			return mapString(args[0], strings.ToUpper), true
+		}
+	case "length", "len", "char_length", "character_length":
+		if len(args) == 1 {
+			return mapLength(args[0], utf8.RuneCountInString), true
+		}
+	case "strlen", "octet_length":
+		if len(args) == 1 {
+			return mapLength(args[0], func(s string) int { return len(s) }), true
+		}
+	case "prefix", "starts_with":
+		if len(args) == 2 {
+			return matchString(args[0], args[1], func(p string) (stringMatcher, error) {
+				return func(s string) bool { return strings.HasPrefix(s, p) }, nil
+			}, false), true
+		}
+	case "suffix", "ends_with":
+		if len(args) == 2 {
+			return matchString(args[0], args[1], func(p string) (stringMatcher, error) {
+				return func(s string) bool { return strings.HasSuffix(s, p) }, nil
+			}, false), true
+		}
+	case "contains":
+		if len(args) == 2 {
+			return matchString(args[0], args[1], func(p string) (stringMatcher, error) {
+				return func(s string) bool { return strings.Contains(s, p) }, nil
+			}, false), true
+		}
+	case "~~": // LIKE
+		if len(args) == 2 {
+			return matchString(args[0], args[1], likeMatcher(false), false), true
+		}
+	case "!~~": // NOT LIKE
+		if len(args) == 2 {
+			return matchString(args[0], args[1], likeMatcher(false), true), true
+		}
+	case "~~*": // ILIKE
+		if len(args) == 2 {
+			return matchString(args[0], args[1], likeMatcher(true), false), true
+		}
+	case "!~~*": // NOT ILIKE
+		if len(args) == 2 {
+			return matchString(args[0], args[1], likeMatcher(true), true), true
+		}
+	case "regexp_matches":
+		if len(args) == 2 {
+			return matchString(args[0], args[1], func(p string) (stringMatcher, error) {
+				re, err := regexp.Compile(p)
+				if err != nil {
+					return nil, err
+				}
+				return re.MatchString, nil
+			}, false), true
+		}
+	}
+	return nil, false
+}
+
+// likeMatcher compiles LIKE patterns: % matches any sequence and _ any single character.
+func likeMatcher(caseInsensitive bool) func(p string) (stringMatcher, error) {
+	return func(p string) (stringMatcher, error) {
+		var sb strings.Builder
+		sb.WriteString("(?s)")
+		if caseInsensitive {
+			sb.WriteString("(?i)")
+		}
+		sb.WriteByte('^')
+		for _, r := range p {
+			switch r {
+			case '%':
+				sb.WriteString(".*")
+			case '_':
+				sb.WriteByte('.')
+			default:
+				sb.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		sb.WriteByte('$')
+
+		re, err := regexp.Compile(sb.String())
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+}
+
+// mapString compiles a string-to-string function.
+func mapString(arg valueFunc, fn func(string) string) valueFunc {
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		vals, err := evalStrings(ctx, rec, arg)
+		if err != nil {
+			return nil, err
+		}
+		defer releaseArrays(vals)
+		in := vals[0]
+
+		b := array.NewStringBuilder(compute.GetAllocator(ctx))
+		defer b.Release()
+		b.Reserve(in.Len())
+		for i := range in.Len() {
+			if in.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(fn(in.Value(i)))
+		}
+		arr := b.NewArray()
+		defer arr.Release()
+		return compute.NewDatum(arr), nil
+	}
+}
+
+// mapLength compiles a string length function returning BIGINT.
+func mapLength(arg valueFunc, fn func(string) int) valueFunc {
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		vals, err := evalStrings(ctx, rec, arg)
+		if err != nil {
+			return nil, err
+		}
+		defer releaseArrays(vals)
+		in := vals[0]
+
+		b := array.NewInt64Builder(compute.GetAllocator(ctx))
+		defer b.Release()
+		b.Reserve(in.Len())
+		for i := range in.Len() {
+			if in.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(int64(fn(in.Value(i))))
+		}
+		arr := b.NewArray()
+		defer arr.Release()
+		return compute.NewDatum(arr), nil
+	}
+}
+
+// matchString compiles a string predicate over a value and a pattern.
+// Patterns are compiled once per distinct value within a batch.
+func matchString(arg, pattern valueFunc, compile func(p string) (stringMatcher, error), negate bool) valueFunc {
+	return func(ctx context.Context, rec arrow.RecordBatch) (compute.Datum, error) {
+		vals, err := evalStrings(ctx, rec, arg, pattern)
+		if err != nil {
+			return nil, err
+		}
+		defer releaseArrays(vals)
+		in, pat := vals[0], vals[1]
+
+		matchers := make(map[string]stringMatcher)
+		b := array.NewBooleanBuilder(compute.GetAllocator(ctx))
+		defer b.Release()
+		b.Reserve(in.Len())
+		for i := range in.Len() {
+			if in.IsNull(i) || pat.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			p := pat.Value(i)
+			m, ok := matchers[p]
+			if !ok {
+				m, err = compile(p)
+				if err != nil {
+					return nil, fmt.Errorf("%w: invalid pattern %q: %v", errUnsupported, p, err)
+				}
+				matchers[p] = m
+			}
+			b.Append(m(in.Value(i)) != negate)
+		}
+		arr := b.NewArray()
+		defer arr.Release()
+		return compute.NewDatum(arr), nil
+	}
+}
+
+// evalStrings evaluates args as string arrays with one value per row of rec.
+// The caller must release the returned arrays.
+func evalStrings(ctx context.Context, rec arrow.RecordBatch, args ...valueFunc) ([]stringValues, error) {
+	n := int(rec.NumRows())
+	out := make([]stringValues, 0, len(args))
+	for _, arg := range args {
+		d, err := arg(ctx, rec)
+		if err != nil {
+			releaseArrays(out)
+			return nil, err
+		}
+		arr, err := materialize(ctx, d, n)
+		d.Release()
+		if err != nil {
+			releaseArrays(out)
+			return nil, err
+		}
+		s, ok := arr.(stringValues)
+		if !ok {
+			arr.Release()
+			releaseArrays(out)
+			return nil, fmt.Errorf("%w: %s is not a string type", errUnsupported, arr.DataType())
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// releaseArrays releases every array in arrs.
+func releaseArrays(arrs []stringValues) {
+	for _, a := range arrs {
+		a.Release()
+	}
+}
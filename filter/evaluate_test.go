@@ -0,0 +1,400 @@
+package filter
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// Expression builders for evaluator tests.
+
+func evalCol(index int) Expression {
+	return &ColumnRefExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundColumnRef, ExprType: TypeBoundColumnRef},
+		Binding:        ColumnBinding{ColumnIndex: index},
+	}
+}
+
+func evalConst(id LogicalTypeID, data any) Expression {
+	return &ConstantExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundConstant, ExprType: TypeValueConstant},
+		Value:          Value{Type: LogicalType{ID: id}, IsNull: data == nil, Data: data},
+	}
+}
+
+func evalCmp(typ ExpressionType, left, right Expression) Expression {
+	return &ComparisonExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundComparison, ExprType: typ},
+		Left:           left,
+		Right:          right,
+	}
+}
+
+func evalConj(typ ExpressionType, children ...Expression) Expression {
+	return &ConjunctionExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundConjunction, ExprType: typ},
+		Children:       children,
+	}
+}
+
+func evalOp(typ ExpressionType, children ...Expression) Expression {
+	return &OperatorExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundOperator, ExprType: typ},
+		Children:       children,
+	}
+}
+
+func evalFunc(name string, isOperator bool, children ...Expression) Expression {
+	return &FunctionExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundFunction, ExprType: TypeBoundFunction},
+		Name:           name,
+		Children:       children,
+		IsOperator:     isOperator,
+	}
+}
+
+func evalBetween(typ ExpressionType, input, lower, upper Expression, lowerInclusive, upperInclusive bool) Expression {
+	return &BetweenExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundBetween, ExprType: typ},
+		Input:          input,
+		Lower:          lower,
+		Upper:          upper,
+		LowerInclusive: lowerInclusive,
+		UpperInclusive: upperInclusive,
+	}
+}
+
+func evalCast(child Expression, id LogicalTypeID) Expression {
+	return &CastExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundCast, ExprType: TypeCast},
+		Child:          child,
+		ReturnType:     LogicalType{ID: id},
+	}
+}
+
+func evalUnsupported() Expression {
+	return &UnsupportedExpression{
+		BaseExpression: BaseExpression{ExprClass: ClassBoundWindow, ExprType: TypeWindowRowNumber},
+	}
+}
+
+// evalBatch builds the batch used by evaluator tests:
+//
+//	id | name  | amount | created
+//	1  | alice | 10.5   | 2024-01-01
+//	2  | Bob   | NULL   | 2024-01-02
+//	3  | NULL  | 30     | 2024-01-03
+//	4  | carol | 40     | 2024-01-04
+//	5  | dave  | 50.25  | 2024-01-05
+//	6  | Eve   | 60     | 2024-01-06
+func evalBatch(t *testing.T, alloc memory.Allocator) arrow.RecordBatch {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "amount", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "created", Type: arrow.FixedWidthTypes.Date32},
+	}, nil)
+
+	b := array.NewRecordBuilder(alloc, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4, 5, 6}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues(
+		[]string{"alice", "Bob", "", "carol", "dave", "Eve"},
+		[]bool{true, true, false, true, true, true})
+	b.Field(2).(*array.Float64Builder).AppendValues(
+		[]float64{10.5, 0, 30, 40, 50.25, 60},
+		[]bool{true, false, true, true, true, true})
+	// 2024-01-01 is day 19723 since the epoch
+	b.Field(3).(*array.Date32Builder).AppendValues(
+		[]arrow.Date32{19723, 19724, 19725, 19726, 19727, 19728}, nil)
+	return b.NewRecordBatch()
+}
+
+// selectedIDs returns the id column values of rows selected by mask.
+func selectedIDs(rec arrow.RecordBatch, mask *array.Boolean) []int64 {
+	ids := rec.Column(0).(*array.Int64)
+	out := []int64{}
+	for i := range mask.Len() {
+		if mask.IsValid(i) && mask.Value(i) {
+			out = append(out, ids.Value(i))
+		}
+	}
+	return out
+}
+
+func TestEvaluatorExpressions(t *testing.T) {
+	id, name, amount, created := evalCol(0), evalCol(1), evalCol(2), evalCol(3)
+
+	tests := []struct {
+		name     string
+		filters  []Expression
+		expected []int64
+	}{
+		{"equal", []Expression{evalCmp(TypeCompareEqual, id, evalConst(TypeIDInteger, int64(3)))}, []int64{3}},
+		{"not equal", []Expression{evalCmp(TypeCompareNotEqual, id, evalConst(TypeIDBigInt, int64(3)))}, []int64{1, 2, 4, 5, 6}},
+		{"less than", []Expression{evalCmp(TypeCompareLessThan, id, evalConst(TypeIDInteger, int64(3)))}, []int64{1, 2}},
+		{"greater or equal", []Expression{evalCmp(TypeCompareGreaterThanOrEqual, id, evalConst(TypeIDInteger, int64(5)))}, []int64{5, 6}},
+		{"constant on left", []Expression{evalCmp(TypeCompareGreaterThan, evalConst(TypeIDInteger, int64(2)), id)}, []int64{1}},
+		{"string equal", []Expression{evalCmp(TypeCompareEqual, name, evalConst(TypeIDVarchar, "Bob"))}, []int64{2}},
+		{"double with null", []Expression{evalCmp(TypeCompareGreaterThan, amount, evalConst(TypeIDDouble, 35.0))}, []int64{4, 5, 6}},
+		{"date", []Expression{evalCmp(TypeCompareLessThanOrEqual, created, evalConst(TypeIDDate, int64(19724)))}, []int64{1, 2}},
+		{"decimal", []Expression{evalCmp(TypeCompareGreaterThan, amount, evalCast(evalConst(TypeIDDecimal, "50.2"), TypeIDDouble))}, []int64{5, 6}},
+
+		{"in", []Expression{evalCmp(TypeCompareIn, id, evalFunc("list_value", false,
+			evalConst(TypeIDInteger, int64(1)), evalConst(TypeIDInteger, int64(4)), evalConst(TypeIDInteger, int64(9))))}, []int64{1, 4}},
+		{"not in", []Expression{evalCmp(TypeCompareNotIn, id, evalFunc("list_value", false,
+			evalConst(TypeIDInteger, int64(1)), evalConst(TypeIDInteger, int64(4))))}, []int64{2, 3, 5, 6}},
+		{"in operator strings", []Expression{evalOp(TypeCompareIn, name,
+			evalConst(TypeIDVarchar, "Bob"), evalConst(TypeIDVarchar, "Eve"))}, []int64{2, 6}},
+		{"in with null", []Expression{evalOp(TypeCompareIn, id,
+			evalConst(TypeIDInteger, int64(2)), evalConst(TypeIDInteger, nil))}, []int64{2}},
+		{"not in with null", []Expression{evalOp(TypeCompareNotIn, id,
+			evalConst(TypeIDInteger, int64(2)), evalConst(TypeIDInteger, nil))}, []int64{}},
+		{"not of not in with null", []Expression{evalOp(TypeOperatorNot, evalOp(TypeCompareNotIn, id,
+			evalConst(TypeIDInteger, int64(2)), evalConst(TypeIDInteger, nil)))}, []int64{2}},
+
+		{"between", []Expression{evalBetween(TypeCompareBetween, id,
+			evalConst(TypeIDInteger, int64(2)), evalConst(TypeIDInteger, int64(4)), true, true)}, []int64{2, 3, 4}},
+		{"between exclusive", []Expression{evalBetween(TypeCompareBetween, id,
+			evalConst(TypeIDInteger, int64(2)), evalConst(TypeIDInteger, int64(4)), false, false)}, []int64{3}},
+		{"not between", []Expression{evalBetween(TypeCompareNotBetween, id,
+			evalConst(TypeIDInteger, int64(2)), evalConst(TypeIDInteger, int64(4)), true, true)}, []int64{1, 5, 6}},
+
+		{"is null", []Expression{evalOp(TypeOperatorIsNull, name)}, []int64{3}},
+		{"is not null", []Expression{evalOp(TypeOperatorIsNotNull, amount)}, []int64{1, 3, 4, 5, 6}},
+		{"not", []Expression{evalOp(TypeOperatorNot, evalCmp(TypeCompareEqual, name, evalConst(TypeIDVarchar, "Bob")))}, []int64{1, 4, 5, 6}},
+		{"distinct from", []Expression{evalCmp(TypeCompareDistinctFrom, name, evalConst(TypeIDVarchar, "Bob"))}, []int64{1, 3, 4, 5, 6}},
+		{"not distinct from null", []Expression{evalCmp(TypeCompareNotDistinctFrom, amount, evalConst(TypeIDDouble, nil))}, []int64{2}},
+
+		{"and", []Expression{evalConj(TypeConjunctionAnd,
+			evalCmp(TypeCompareGreaterThan, id, evalConst(TypeIDInteger, int64(2))),
+			evalOp(TypeOperatorIsNotNull, name))}, []int64{4, 5, 6}},
+		{"or", []Expression{evalConj(TypeConjunctionOr,
+			evalCmp(TypeCompareEqual, id, evalConst(TypeIDInteger, int64(1))),
+			evalOp(TypeOperatorIsNull, amount))}, []int64{1, 2}},
+		{"multiple filters", []Expression{
+			evalCmp(TypeCompareGreaterThan, id, evalConst(TypeIDInteger, int64(1))),
+			evalCmp(TypeCompareLessThan, amount, evalConst(TypeIDDouble, 45.0))}, []int64{3, 4}},
+
+		{"cast", []Expression{evalCmp(TypeCompareEqual, evalCast(id, TypeIDDouble), evalConst(TypeIDDouble, 5.0))}, []int64{5}},
+		{"arithmetic", []Expression{evalCmp(TypeCompareEqual,
+			evalFunc("+", true, id, evalConst(TypeIDBigInt, int64(1))), evalConst(TypeIDBigInt, int64(4)))}, []int64{3}},
+
+		{"lower", []Expression{evalCmp(TypeCompareEqual, evalFunc("lower", false, name), evalConst(TypeIDVarchar, "bob"))}, []int64{2}},
+		{"upper", []Expression{evalCmp(TypeCompareEqual, evalFunc("upper", false, name), evalConst(TypeIDVarchar, "EVE"))}, []int64{6}},
+		{"length", []Expression{evalCmp(TypeCompareEqual, evalFunc("length", false, name), evalConst(TypeIDBigInt, int64(3)))}, []int64{2, 6}},
+		{"prefix", []Expression{evalFunc("prefix", false, name, evalConst(TypeIDVarchar, "ca"))}, []int64{4}},
+		{"suffix", []Expression{evalFunc("suffix", false, name, evalConst(TypeIDVarchar, "e"))}, []int64{1, 5, 6}},
+		{"contains", []Expression{evalFunc("contains", false, name, evalConst(TypeIDVarchar, "ro"))}, []int64{4}},
+		{"like", []Expression{evalFunc("~~", true, name, evalConst(TypeIDVarchar, "_a%"))}, []int64{4, 5}},
+		{"not like", []Expression{evalFunc("!~~", true, name, evalConst(TypeIDVarchar, "%e"))}, []int64{2, 4}},
+		{"ilike", []Expression{evalFunc("~~*", true, name, evalConst(TypeIDVarchar, "%E"))}, []int64{1, 5, 6}},
+		{"like escapes regexp", []Expression{evalFunc("~~", true, name, evalConst(TypeIDVarchar, "a.*"))}, []int64{}},
+
+		{"no filters", nil, []int64{1, 2, 3, 4, 5, 6}},
+		{"unsupported in and", []Expression{evalConj(TypeConjunctionAnd,
+			evalCmp(TypeCompareEqual, id, evalConst(TypeIDInteger, int64(2))),
+			evalUnsupported())}, []int64{2}},
+		{"unsupported in or", []Expression{evalConj(TypeConjunctionOr,
+			evalCmp(TypeCompareEqual, id, evalConst(TypeIDInteger, int64(2))),
+			evalUnsupported())}, []int64{1, 2, 3, 4, 5, 6}},
+		{"not over partial and", []Expression{evalOp(TypeOperatorNot, evalConj(TypeConjunctionAnd,
+			evalCmp(TypeCompareEqual, id, evalConst(TypeIDInteger, int64(2))),
+			evalUnsupported()))}, []int64{1, 2, 3, 4, 5, 6}},
+		{"unsupported function", []Expression{evalFunc("jaro_winkler_similarity", false, name, evalConst(TypeIDVarchar, "x"))}, []int64{1, 2, 3, 4, 5, 6}},
+		{"type mismatch", []Expression{evalCmp(TypeCompareEqual, created, evalConst(TypeIDVarchar, "not a date"))}, []int64{1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer alloc.AssertSize(t, 0)
+
+			rec := evalBatch(t, alloc)
+			defer rec.Release()
+
+			fp := &FilterPushdown{
+				Filters:        tt.filters,
+				ColumnBindings: []string{"id", "name", "amount", "created"},
+			}
+			ev := NewEvaluator(fp, &EvaluatorOptions{Allocator: alloc})
+			mask, err := ev.Evaluate(context.Background(), rec)
+			if err != nil {
+				t.Fatalf("Evaluate failed: %v", err)
+			}
+			defer mask.Release()
+
+			if mask.Len() != int(rec.NumRows()) || mask.NullN() != 0 {
+				t.Fatalf("expected %d non-null mask values, got %d with %d nulls", rec.NumRows(), mask.Len(), mask.NullN())
+			}
+			if got := selectedIDs(rec, mask); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected ids %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvaluatorParsedFilter(t *testing.T) {
+	// WHERE lower(name) = 'bob' OR id IN (5, 6)
+	json := []byte(`{
+		"filters": [
+			{
+				"expression_class": "BOUND_CONJUNCTION",
+				"type": "CONJUNCTION_OR",
+				"alias": "",
+				"children": [
+					{
+						"expression_class": "BOUND_COMPARISON",
+						"type": "COMPARE_EQUAL",
+						"alias": "",
+						"left": {
+							"expression_class": "BOUND_FUNCTION",
+							"type": "BOUND_FUNCTION",
+							"alias": "",
+							"return_type": {"id": "VARCHAR", "type_info": null},
+							"children": [
+								{
+									"expression_class": "BOUND_COLUMN_REF",
+									"type": "BOUND_COLUMN_REF",
+									"alias": "",
+									"return_type": {"id": "VARCHAR", "type_info": null},
+									"binding": {"table_index": 0, "column_index": 1},
+									"depth": 0
+								}
+							],
+							"name": "lower",
+							"arguments": [{"id": "VARCHAR", "type_info": null}],
+							"original_arguments": [{"id": "VARCHAR", "type_info": null}],
+							"has_serialize": false,
+							"is_operator": false
+						},
+						"right": {
+							"expression_class": "BOUND_CONSTANT",
+							"type": "VALUE_CONSTANT",
+							"alias": "",
+							"value": {"type": {"id": "VARCHAR", "type_info": null}, "is_null": false, "value": "bob"}
+						}
+					},
+					{
+						"expression_class": "BOUND_COMPARISON",
+						"type": "COMPARE_IN",
+						"alias": "",
+						"left": {
+							"expression_class": "BOUND_COLUMN_REF",
+							"type": "BOUND_COLUMN_REF",
+							"alias": "",
+							"return_type": {"id": "BIGINT", "type_info": null},
+							"binding": {"table_index": 0, "column_index": 0},
+							"depth": 0
+						},
+						"right": {
+							"expression_class": "BOUND_FUNCTION",
+							"type": "BOUND_FUNCTION",
+							"alias": "",
+							"return_type": {"id": "LIST", "type_info": null},
+							"children": [
+								{"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "alias": "", "value": {"type": {"id": "BIGINT"}, "is_null": false, "value": 5}},
+								{"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "alias": "", "value": {"type": {"id": "BIGINT"}, "is_null": false, "value": 6}}
+							],
+							"name": "list_value",
+							"has_serialize": false,
+							"is_operator": false
+						}
+					}
+				]
+			}
+		],
+		"column_binding_names_by_index": ["id", "name"]
+	}`)
+
+	fp, err := Parse(json)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	rec := evalBatch(t, memory.DefaultAllocator)
+	defer rec.Release()
+
+	ev := NewEvaluator(fp, nil)
+	out, err := ev.Filter(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	defer out.Release()
+
+	ids := out.Column(0).(*array.Int64).Int64Values()
+	if !slices.Equal(ids, []int64{2, 5, 6}) {
+		t.Errorf("expected ids [2 5 6], got %v", ids)
+	}
+}
+
+func TestEvaluatorColumnMapping(t *testing.T) {
+	fp := &FilterPushdown{
+		Filters:        []Expression{evalCmp(TypeCompareEqual, evalCol(0), evalConst(TypeIDBigInt, int64(4)))},
+		ColumnBindings: []string{"user_id"},
+	}
+
+	rec := evalBatch(t, memory.DefaultAllocator)
+	defer rec.Release()
+
+	ev := NewEvaluator(fp, &EvaluatorOptions{ColumnMapping: map[string]string{"user_id": "id"}})
+	mask, err := ev.Evaluate(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	defer mask.Release()
+	if got := selectedIDs(rec, mask); !slices.Equal(got, []int64{4}) {
+		t.Errorf("expected ids [4], got %v", got)
+	}
+
+	// Without the mapping the column does not exist in the batch.
+	_, err = NewEvaluator(fp, nil).Evaluate(context.Background(), rec)
+	if err == nil || !strings.Contains(err.Error(), "user_id") {
+		t.Errorf("expected missing column error, got %v", err)
+	}
+}
+
+func TestFilterRecordReader(t *testing.T) {
+	alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer alloc.AssertSize(t, 0)
+
+	first := evalBatch(t, alloc)
+	defer first.Release()
+	second := first.NewSlice(4, 6)
+	defer second.Release()
+	third := first.NewSlice(0, 3)
+	defer third.Release()
+
+	src, err := array.NewRecordReader(first.Schema(), []arrow.RecordBatch{first, second, third})
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+
+	fp := &FilterPushdown{
+		Filters:        []Expression{evalCmp(TypeCompareGreaterThan, evalCol(0), evalConst(TypeIDBigInt, int64(4)))},
+		ColumnBindings: []string{"id"},
+	}
+	rdr := FilterRecordReader(context.Background(), src, NewEvaluator(fp, &EvaluatorOptions{Allocator: alloc}))
+	defer rdr.Release()
+
+	var batches [][]int64
+	for rdr.Next() {
+		ids := rdr.RecordBatch().Column(0).(*array.Int64).Int64Values()
+		batches = append(batches, slices.Clone(ids))
+	}
+	if err := rdr.Err(); err != nil {
+		t.Fatalf("reader failed: %v", err)
+	}
+
+	// The third batch has no matching rows and is dropped.
+	expected := [][]int64{{5, 6}, {5, 6}}
+	if !slices.EqualFunc(batches, expected, slices.Equal[[]int64]) {
+		t.Errorf("expected batches %v, got %v", expected, batches)
+	}
+}
@@ -0,0 +1,228 @@
+package filter
+
+import (
+	"math"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/arrow/scalar"
+)
+
+// Default DECIMAL precision and scale used by DuckDB when type info is missing.
+const (
+	defaultDecimalWidth = 18
+	defaultDecimalScale = 3
+)
+
+// arrowType maps a DuckDB logical type to the Arrow type used for evaluation.
+// Returns false for types the evaluator does not handle.
+func arrowType(lt LogicalType) (arrow.DataType, bool) {
+	switch lt.ID.Normalize() {
+	case TypeIDSQLNull:
+		return arrow.Null, true
+	case TypeIDBoolean:
+		return arrow.FixedWidthTypes.Boolean, true
+	case TypeIDTinyInt:
+		return arrow.PrimitiveTypes.Int8, true
+	case TypeIDSmallInt:
+		return arrow.PrimitiveTypes.Int16, true
+	case TypeIDInteger:
+		return arrow.PrimitiveTypes.Int32, true
+	case TypeIDBigInt:
+		return arrow.PrimitiveTypes.Int64, true
+	case TypeIDUTinyInt:
+		return arrow.PrimitiveTypes.Uint8, true
+	case TypeIDUSmallInt:
+		return arrow.PrimitiveTypes.Uint16, true
+	case TypeIDUInteger:
+		return arrow.PrimitiveTypes.Uint32, true
+	case TypeIDUBigInt:
+		return arrow.PrimitiveTypes.Uint64, true
+	case TypeIDFloat:
+		return arrow.PrimitiveTypes.Float32, true
+	case TypeIDDouble:
+		return arrow.PrimitiveTypes.Float64, true
+	case TypeIDDecimal:
+		width, scale := decimalParams(lt)
+		if width > decimal128.MaxPrecision {
+			return nil, false
+		}
+		return &arrow.Decimal128Type{Precision: width, Scale: scale}, true
+	case TypeIDVarchar, TypeIDChar:
+		return arrow.BinaryTypes.String, true
+	case TypeIDBlob:
+		return arrow.BinaryTypes.Binary, true
+	case TypeIDDate:
+		return arrow.FixedWidthTypes.Date32, true
+	case TypeIDTime:
+		return arrow.FixedWidthTypes.Time64us, true
+	case TypeIDTimestampSec:
+		return &arrow.TimestampType{Unit: arrow.Second}, true
+	case TypeIDTimestampMs:
+		return &arrow.TimestampType{Unit: arrow.Millisecond}, true
+	case TypeIDTimestamp:
+		return &arrow.TimestampType{Unit: arrow.Microsecond}, true
+	case TypeIDTimestampNs:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}, true
+	case TypeIDTimestampTZ:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, true
+	case TypeIDInterval:
+		return arrow.FixedWidthTypes.MonthDayNanoInterval, true
+	default:
+		return nil, false
+	}
+}
+
+// decimalParams returns the precision and scale of a DECIMAL type.
+func decimalParams(lt LogicalType) (int32, int32) {
+	if info, ok := lt.TypeInfo.(*DecimalTypeInfo); ok && info.Width > 0 {
+		return int32(info.Width), int32(info.Scale)
+	}
+	return defaultDecimalWidth, defaultDecimalScale
+}
+
+// makeScalar converts a constant value into an Arrow scalar.
+// Values of unsupported types return errUnsupported.
+func makeScalar(v Value) (scalar.Scalar, error) {
+	id := v.Type.ID.Normalize()
+
+	if v.IsNull {
+		dt, ok := arrowType(v.Type)
+		if !ok {
+			dt = arrow.Null
+		}
+		return scalar.MakeNullScalar(dt), nil
+	}
+
+	switch id {
+	case TypeIDBoolean:
+		if b, ok := v.Data.(bool); ok {
+			return scalar.NewBooleanScalar(b), nil
+		}
+
+	case TypeIDTinyInt, TypeIDSmallInt, TypeIDInteger, TypeIDBigInt:
+		n, ok := v.Data.(int64)
+		if !ok {
+			break
+		}
+		switch id {
+		case TypeIDTinyInt:
+			return scalar.NewInt8Scalar(int8(n)), nil
+		case TypeIDSmallInt:
+			return scalar.NewInt16Scalar(int16(n)), nil
+		case TypeIDInteger:
+			return scalar.NewInt32Scalar(int32(n)), nil
+		default:
+			return scalar.NewInt64Scalar(n), nil
+		}
+
+	case TypeIDUTinyInt, TypeIDUSmallInt, TypeIDUInteger, TypeIDUBigInt:
+		n, ok := v.Data.(uint64)
+		if !ok {
+			break
+		}
+		switch id {
+		case TypeIDUTinyInt:
+			return scalar.NewUint8Scalar(uint8(n)), nil
+		case TypeIDUSmallInt:
+			return scalar.NewUint16Scalar(uint16(n)), nil
+		case TypeIDUInteger:
+			return scalar.NewUint32Scalar(uint32(n)), nil
+		default:
+			return scalar.NewUint64Scalar(n), nil
+		}
+
+	case TypeIDHugeInt:
+		// Only values that fit in BIGINT are supported.
+		if h, ok := v.Data.(HugeInt); ok {
+			if (h.Upper == 0 && h.Lower <= math.MaxInt64) || (h.Upper == -1 && h.Lower > math.MaxInt64) {
+				return scalar.NewInt64Scalar(int64(h.Lower)), nil
+			}
+		}
+
+	case TypeIDUHugeInt:
+		// Only values that fit in UBIGINT are supported.
+		if h, ok := v.Data.(UHugeInt); ok && h.Upper == 0 {
+			return scalar.NewUint64Scalar(h.Lower), nil
+		}
+
+	case TypeIDFloat:
+		if f, ok := v.Data.(float64); ok {
+			return scalar.NewFloat32Scalar(float32(f)), nil
+		}
+
+	case TypeIDDouble:
+		if f, ok := v.Data.(float64); ok {
+			return scalar.NewFloat64Scalar(f), nil
+		}
+
+	case TypeIDDecimal:
+		return makeDecimalScalar(v)
+
+	case TypeIDVarchar, TypeIDChar, TypeIDUUID:
+		// UUID constants are compared as strings; comparisons against
+		// other column types fall back to casting the constant.
+		if s, ok := v.Data.(string); ok {
+			return scalar.NewStringScalar(s), nil
+		}
+
+	case TypeIDBlob:
+		if b, ok := v.Data.([]byte); ok {
+			return scalar.NewBinaryScalar(memory.NewBufferBytes(b), arrow.BinaryTypes.Binary), nil
+		}
+
+	case TypeIDDate:
+		if days, ok := v.Data.(int64); ok {
+			return scalar.NewDate32Scalar(arrow.Date32(days)), nil
+		}
+
+	case TypeIDTime:
+		if micros, ok := v.Data.(int64); ok {
+			return scalar.NewTime64Scalar(arrow.Time64(micros), arrow.FixedWidthTypes.Time64us), nil
+		}
+
+	case TypeIDTimestampSec, TypeIDTimestampMs, TypeIDTimestamp, TypeIDTimestampNs, TypeIDTimestampTZ:
+		if ts, ok := v.Data.(int64); ok {
+			dt, _ := arrowType(v.Type)
+			return scalar.NewTimestampScalar(arrow.Timestamp(ts), dt), nil
+		}
+
+	case TypeIDInterval:
+		if iv, ok := v.Data.(Interval); ok {
+			return scalar.NewMonthDayNanoIntervalScalar(arrow.MonthDayNanoInterval{
+				Months:      iv.Months,
+				Days:        iv.Days,
+				Nanoseconds: iv.Micros * 1000,
+			}), nil
+		}
+	}
+
+	return nil, errUnsupported
+}
+
+// makeDecimalScalar converts a DECIMAL constant, sent as a string or a number.
+func makeDecimalScalar(v Value) (scalar.Scalar, error) {
+	dt, ok := arrowType(v.Type)
+	if !ok {
+		return nil, errUnsupported
+	}
+	dec := dt.(*arrow.Decimal128Type)
+
+	var (
+		n   decimal128.Num
+		err error
+	)
+	switch data := v.Data.(type) {
+	case string:
+		n, err = decimal128.FromString(data, dec.Precision, dec.Scale)
+	case float64:
+		n, err = decimal128.FromFloat64(data, dec.Precision, dec.Scale)
+	default:
+		return nil, errUnsupported
+	}
+	if err != nil {
+		return nil, errUnsupported
+	}
+	return scalar.NewDecimal128Scalar(n, dec), nil
+}
@@ -0,0 +1,254 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MySQLEncoder encodes filter expressions to MySQL 8 SQL syntax.
+//
+// String comparisons and LIKE follow the collation of the compared columns,
+// so with the default case-insensitive collations "=" and LIKE can match more
+// rows than in DuckDB and "<>" and NOT LIKE fewer. Use binary or "_bin"
+// collations for exact results. String literals escape backslashes, which
+// requires the NO_BACKSLASH_ESCAPES SQL mode to be disabled (the default).
+//
+// INTERVAL, LIST, STRUCT and MAP literals and TRY_CAST are not supported.
+type MySQLEncoder struct {
+	sqlEncoder
+}
+
+// NewMySQLEncoder creates a new MySQL SQL encoder.
+// If opts is nil, default options are used.
+func NewMySQLEncoder(opts *EncoderOptions) *MySQLEncoder {
+	return &MySQLEncoder{newSQLEncoder(mysqlDialect{}, opts)}
+}
+
+// EncodeFilters converts all filters to a WHERE clause body.
+// Returns the condition portion without "WHERE" keyword.
+// Returns empty string if no filters can be encoded.
+func (e *MySQLEncoder) EncodeFilters(fp *FilterPushdown) string {
+	return e.encodeFilters(fp)
+}
+
+// EncodeFiltersWithArgs converts all filters to a WHERE clause body
+// with ? placeholders and returns the bound arguments in order.
+func (e *MySQLEncoder) EncodeFiltersWithArgs(fp *FilterPushdown) (string, []any) {
+	return e.encodeFiltersWithArgs(fp)
+}
+
+// Encode converts a single expression to SQL.
+// Returns empty string if expression is unsupported.
+func (e *MySQLEncoder) Encode(expr Expression) string {
+	return e.encode(expr)
+}
+
+// mysqlDialect implements the MySQL SQL dialect.
+type mysqlDialect struct{}
+
+// mysqlDateUnits lists the date_part units supported by EXTRACT.
+var mysqlDateUnits = map[string]string{
+	"year":        "YEAR",
+	"quarter":     "QUARTER",
+	"month":       "MONTH",
+	"week":        "WEEK",
+	"day":         "DAY",
+	"hour":        "HOUR",
+	"minute":      "MINUTE",
+	"second":      "SECOND",
+	"microsecond": "MICROSECOND",
+}
+
+func (mysqlDialect) quoteIdentifier(name string) string {
+	if needsQuoting(name) {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return name
+}
+
+func (mysqlDialect) quoteString(s string) string {
+	return "'" + strings.ReplaceAll(escapeString(s), `\`, `\\`) + "'"
+}
+
+func (mysqlDialect) formatBlob(b []byte) string { return "X'" + hexString(b) + "'" }
+
+func (mysqlDialect) formatDate(date string) string { return "DATE '" + date + "'" }
+
+func (mysqlDialect) formatTime(clock string) string { return "TIME '" + clock + "'" }
+
+func (mysqlDialect) formatTimestamp(ts string, typeID LogicalTypeID) string {
+	if typeID == TypeIDTimestampTZ {
+		return "TIMESTAMP '" + ts + "+00:00'"
+	}
+	return "TIMESTAMP '" + ts + "'"
+}
+
+func (mysqlDialect) formatInterval(Interval) string { return "" }
+
+func (mysqlDialect) formatUUID(s string) string { return quoteLiteral(s) }
+
+func (mysqlDialect) formatList([]string) string { return "" }
+
+func (mysqlDialect) formatStruct([]string) string { return "" }
+
+func (mysqlDialect) formatMap(_, _ []string) string { return "" }
+
+func (d mysqlDialect) cast(child string, lt LogicalType, try bool) string {
+	typeName := d.formatTypeName(lt)
+	if typeName == "" || try {
+		return ""
+	}
+	return "CAST(" + child + " AS " + typeName + ")"
+}
+
+func (mysqlDialect) function(name string, args []string) string {
+	switch name {
+	case "lower", "lcase":
+		return callFunction("LOWER", args, 1)
+	case "upper", "ucase":
+		return callFunction("UPPER", args, 1)
+	case "length", "len", "char_length", "character_length":
+		return callFunction("CHAR_LENGTH", args, 1)
+	case "strlen", "octet_length":
+		return callFunction("LENGTH", args, 1)
+	case "trim", "ltrim", "rtrim":
+		return callFunction(strings.ToUpper(name), args, 1)
+	case "substring", "substr":
+		return callFunction("SUBSTRING", args, 2, 3)
+	case "concat":
+		// DuckDB concat skips NULL arguments like CONCAT_WS does.
+		if len(args) > 0 {
+			return "CONCAT_WS('', " + strings.Join(args, ", ") + ")"
+		}
+	case "prefix", "starts_with":
+		if len(args) == 2 {
+			return "(LEFT(" + args[0] + ", CHAR_LENGTH(" + args[1] + ")) = " + args[1] + ")"
+		}
+	case "suffix", "ends_with":
+		if len(args) == 2 {
+			return "(RIGHT(" + args[0] + ", CHAR_LENGTH(" + args[1] + ")) = " + args[1] + ")"
+		}
+	case "contains":
+		if len(args) == 2 {
+			return "(LOCATE(" + args[1] + ", " + args[0] + ") > 0)"
+		}
+	case "regexp_matches":
+		if len(args) == 2 {
+			return "REGEXP_LIKE(" + args[0] + ", " + args[1] + ", 'c')"
+		}
+	case "abs", "floor", "ceil":
+		return callFunction(strings.ToUpper(name), args, 1)
+	case "round":
+		return callFunction("ROUND", args, 1, 2)
+	case "ceiling":
+		return callFunction("CEIL", args, 1)
+	case "year", "month", "day", "hour", "minute":
+		return callFunction(strings.ToUpper(name), args, 1)
+	case "date_part":
+		if len(args) == 2 {
+			if unit, ok := unquoteLiteral(args[0]); ok {
+				if u, ok := mysqlDateUnits[strings.ToLower(unit)]; ok {
+					return "EXTRACT(" + u + " FROM " + args[1] + ")"
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (mysqlDialect) operator(name string, args []string) string {
+	switch name {
+	case "+", "-", "*", "/", "%":
+		if len(args) == 2 {
+			return "(" + args[0] + " " + name + " " + args[1] + ")"
+		}
+		if len(args) == 1 && (name == "-" || name == "+") {
+			return name + args[0]
+		}
+	case "~~", "!~~":
+		if len(args) == 2 {
+			return args[0] + likeOperators[name] + args[1] + likeEscape(args[1])
+		}
+	case "~~*", "!~~*":
+		if len(args) == 2 {
+			op := " LIKE "
+			if name == "!~~*" {
+				op = " NOT LIKE "
+			}
+			return "LOWER(" + args[0] + ")" + op + "LOWER(" + args[1] + ")" + likeEscape(args[1])
+		}
+	case "~", "!~", "~*", "!~*":
+		if len(args) == 2 {
+			matchType := "'c'"
+			if strings.HasSuffix(name, "*") {
+				matchType = "'i'"
+			}
+			expr := "REGEXP_LIKE(" + args[0] + ", " + anchorPattern(args[1], mysqlConcat) + ", " + matchType + ")"
+			if strings.HasPrefix(name, "!") {
+				return "NOT " + expr
+			}
+			return expr
+		}
+	case "||":
+		if len(args) >= 2 {
+			return "CONCAT(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	return ""
+}
+
+func (mysqlDialect) distinctFrom(left, right string, negate bool) string {
+	if negate {
+		return "(" + left + " <=> " + right + ")"
+	}
+	return "NOT (" + left + " <=> " + right + ")"
+}
+
+func (mysqlDialect) placeholder(int, LogicalType) string { return "?" }
+
+// formatTypeName formats a LogicalType as a MySQL CAST target type.
+// Returns empty string for types MySQL cannot cast to.
+func (mysqlDialect) formatTypeName(lt LogicalType) string {
+	switch lt.ID {
+	case TypeIDTinyInt, TypeIDSmallInt, TypeIDInteger, TypeIDBigInt:
+		return "SIGNED"
+	case TypeIDUTinyInt, TypeIDUSmallInt, TypeIDUInteger, TypeIDUBigInt:
+		return "UNSIGNED"
+	case TypeIDHugeInt:
+		return "DECIMAL(38, 0)"
+	case TypeIDUHugeInt:
+		return "DECIMAL(39, 0)"
+	case TypeIDFloat:
+		return "FLOAT"
+	case TypeIDDouble:
+		return "DOUBLE"
+	case TypeIDDecimal:
+		if info, ok := lt.TypeInfo.(*DecimalTypeInfo); ok {
+			return fmt.Sprintf("DECIMAL(%d, %d)", info.Width, info.Scale)
+		}
+		return fmt.Sprintf("DECIMAL(%d, %d)", defaultDecimalWidth, defaultDecimalScale)
+	case TypeIDVarchar, TypeIDChar:
+		return "CHAR"
+	case TypeIDUUID:
+		return "CHAR(36)"
+	case TypeIDBlob:
+		return "BINARY"
+	case TypeIDDate:
+		return "DATE"
+	case TypeIDTime:
+		return "TIME(6)"
+	case TypeIDTimestamp, TypeIDTimestampNs, TypeIDTimestampTZ:
+		return "DATETIME(6)"
+	case TypeIDTimestampMs:
+		return "DATETIME(3)"
+	case TypeIDTimestampSec:
+		return "DATETIME"
+	default:
+		return ""
+	}
+}
+
+// mysqlConcat concatenates string expressions with CONCAT, since || is OR in MySQL.
+func mysqlConcat(parts ...string) string {
+	return "CONCAT(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,169 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// paramMarker delimits a bound constant in SQL generated with placeholders.
+// Markers are replaced by dialect placeholders once the whole WHERE clause is
+// known, so that constants dropped with unsupported expressions or repeated by
+// a dialect rewrite are bound correctly.
+const paramMarker = "\x00"
+
+// sqlParam is a constant bound to a placeholder.
+type sqlParam struct {
+	value any
+	typ   LogicalType
+}
+
+// encodeFiltersWithArgs converts all filters to a WHERE clause body with
+// placeholders and returns the arguments bound to them in order.
+func (e *sqlEncoder) encodeFiltersWithArgs(fp *FilterPushdown) (string, []any) {
+	e.parameterized = true
+	e.params = nil
+	defer func() {
+		e.parameterized = false
+		e.params = nil
+	}()
+
+	return e.bindParams(e.encodeFilters(fp))
+}
+
+// encodeParam registers a constant as a bound argument and returns its marker.
+// Returns false for values that are encoded inline.
+func (e *sqlEncoder) encodeParam(v Value) (string, bool) {
+	if !e.parameterized || e.inline > 0 || v.IsNull {
+		return "", false
+	}
+	arg, ok := paramValue(v)
+	if !ok {
+		return "", false
+	}
+	e.params = append(e.params, sqlParam{value: arg, typ: v.Type})
+	return paramMarker + strconv.Itoa(len(e.params)-1) + paramMarker, true
+}
+
+// bindParams replaces parameter markers in sql with dialect placeholders,
+// numbered in order of appearance, and returns the matching arguments.
+func (e *sqlEncoder) bindParams(sql string) (string, []any) {
+	if !strings.Contains(sql, paramMarker) {
+		return sql, nil
+	}
+
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	for {
+		start := strings.Index(sql, paramMarker)
+		if start < 0 {
+			sb.WriteString(sql)
+			break
+		}
+		end := strings.Index(sql[start+1:], paramMarker)
+		if end < 0 {
+			sb.WriteString(sql)
+			break
+		}
+		end += start + 1
+
+		idx, err := strconv.Atoi(sql[start+1 : end])
+		if err != nil || idx < 0 || idx >= len(e.params) {
+			sb.WriteString(sql[:end])
+			sql = sql[end:]
+			continue
+		}
+
+		p := e.params[idx]
+		args = append(args, p.value)
+		sb.WriteString(sql[:start])
+		sb.WriteString(e.dialect.placeholder(len(args), p.typ))
+		sql = sql[end+1:]
+	}
+
+	return sb.String(), args
+}
+
+// paramValue converts a constant to a Go value accepted by database/sql drivers:
+//
+//   - BOOLEAN: bool
+//   - signed integers: int64; unsigned integers: uint64
+//   - HUGEINT, UHUGEINT: int64 or uint64 if the value fits, decimal string otherwise
+//   - FLOAT, DOUBLE: float64
+//   - DECIMAL: decimal string, to keep the exact value
+//   - VARCHAR, CHAR, UUID: string
+//   - BLOB: []byte
+//   - DATE and TIMESTAMP variants: time.Time in UTC
+//   - TIME: "15:04:05[.000000]" string
+//   - INTERVAL: "1 years 2 months 3 days" string
+//
+// Returns false for LIST, STRUCT, MAP and other values that are encoded inline.
+func paramValue(v Value) (any, bool) {
+	switch v.Type.ID {
+	case TypeIDBoolean:
+		b, ok := v.Data.(bool)
+		return b, ok
+	case TypeIDTinyInt, TypeIDSmallInt, TypeIDInteger, TypeIDBigInt:
+		return int64Data(v.Data)
+	case TypeIDUTinyInt, TypeIDUSmallInt, TypeIDUInteger, TypeIDUBigInt:
+		switch n := v.Data.(type) {
+		case uint64:
+			return n, true
+		case float64:
+			return uint64(n), true
+		}
+	case TypeIDHugeInt:
+		if h, ok := v.Data.(HugeInt); ok {
+			if (h.Upper == 0 && h.Lower <= 1<<63-1) || (h.Upper == -1 && h.Lower >= 1<<63) {
+				return int64(h.Lower), true
+			}
+			return formatHugeIntValue(h), true
+		}
+	case TypeIDUHugeInt:
+		if h, ok := v.Data.(UHugeInt); ok {
+			if h.Upper == 0 {
+				return h.Lower, true
+			}
+			return formatUHugeIntValue(h), true
+		}
+	case TypeIDFloat, TypeIDDouble:
+		switch f := v.Data.(type) {
+		case float64:
+			return f, true
+		case float32:
+			return float64(f), true
+		}
+	case TypeIDDecimal:
+		if s := formatDecimalValue(v.Data); s != "" {
+			return s, true
+		}
+	case TypeIDVarchar, TypeIDChar, TypeIDUUID:
+		s, ok := v.Data.(string)
+		return s, ok
+	case TypeIDBlob:
+		switch b := v.Data.(type) {
+		case []byte:
+			return b, true
+		case string:
+			return []byte(b), true
+		}
+	case TypeIDDate:
+		if days, ok := int64Data(v.Data); ok {
+			return dateTime(days), true
+		}
+	case TypeIDTime, TypeIDTimeTZ:
+		if micros, ok := int64Data(v.Data); ok {
+			return clockString(micros), true
+		}
+	case TypeIDTimestamp, TypeIDTimestampTZ, TypeIDTimestampMs, TypeIDTimestampNs, TypeIDTimestampSec:
+		if ts, ok := int64Data(v.Data); ok {
+			return timestampTime(ts, v.Type.ID), true
+		}
+	case TypeIDInterval:
+		if iv, ok := v.Data.(Interval); ok {
+			return intervalText(iv), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,256 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PostgresEncoder encodes filter expressions to PostgreSQL SQL syntax.
+//
+// DuckDB functions without a PostgreSQL equivalent make the enclosing
+// expression unsupported, following the same AND/OR rules as DuckDBEncoder.
+// TRY_CAST and MAP literals are not supported.
+type PostgresEncoder struct {
+	sqlEncoder
+}
+
+// NewPostgresEncoder creates a new PostgreSQL SQL encoder.
+// If opts is nil, default options are used.
+func NewPostgresEncoder(opts *EncoderOptions) *PostgresEncoder {
+	return &PostgresEncoder{newSQLEncoder(postgresDialect{}, opts)}
+}
+
+// EncodeFilters converts all filters to a WHERE clause body.
+// Returns the condition portion without "WHERE" keyword.
+// Returns empty string if no filters can be encoded.
+func (e *PostgresEncoder) EncodeFilters(fp *FilterPushdown) string {
+	return e.encodeFilters(fp)
+}
+
+// EncodeFiltersWithArgs converts all filters to a WHERE clause body
+// with $1, $2, ... placeholders and returns the bound arguments in order.
+func (e *PostgresEncoder) EncodeFiltersWithArgs(fp *FilterPushdown) (string, []any) {
+	return e.encodeFiltersWithArgs(fp)
+}
+
+// Encode converts a single expression to SQL.
+// Returns empty string if expression is unsupported.
+func (e *PostgresEncoder) Encode(expr Expression) string {
+	return e.encode(expr)
+}
+
+// postgresDialect implements the PostgreSQL SQL dialect.
+type postgresDialect struct{}
+
+// quoteIdentifier also quotes names with upper case letters,
+// since PostgreSQL folds unquoted identifiers to lower case.
+func (postgresDialect) quoteIdentifier(name string) string {
+	if needsQuoting(name) || strings.ToLower(name) != name {
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+	return name
+}
+
+func (postgresDialect) quoteString(s string) string { return quoteLiteral(s) }
+
+func (postgresDialect) formatBlob(b []byte) string { return `'\x` + hexString(b) + "'::bytea" }
+
+func (postgresDialect) formatDate(date string) string { return "DATE '" + date + "'" }
+
+func (postgresDialect) formatTime(clock string) string { return "TIME '" + clock + "'" }
+
+func (postgresDialect) formatTimestamp(ts string, typeID LogicalTypeID) string {
+	if typeID == TypeIDTimestampTZ {
+		return "TIMESTAMPTZ '" + ts + "+00'"
+	}
+	return "TIMESTAMP '" + ts + "'"
+}
+
+func (postgresDialect) formatInterval(iv Interval) string {
+	return "INTERVAL '" + intervalText(iv) + "'"
+}
+
+func (postgresDialect) formatUUID(s string) string { return quoteLiteral(s) + "::uuid" }
+
+func (postgresDialect) formatList(items []string) string {
+	// An empty ARRAY[] has no element type in PostgreSQL.
+	if len(items) == 0 {
+		return ""
+	}
+	return "ARRAY[" + strings.Join(items, ", ") + "]"
+}
+
+func (postgresDialect) formatStruct(fields []string) string {
+	return "ROW(" + strings.Join(fields, ", ") + ")"
+}
+
+func (postgresDialect) formatMap(_, _ []string) string { return "" }
+
+func (d postgresDialect) cast(child string, lt LogicalType, try bool) string {
+	typeName := d.formatTypeName(lt)
+	if typeName == "" || try {
+		return ""
+	}
+	return "CAST(" + child + " AS " + typeName + ")"
+}
+
+func (postgresDialect) function(name string, args []string) string {
+	switch name {
+	case "lower", "lcase":
+		return callFunction("lower", args, 1)
+	case "upper", "ucase":
+		return callFunction("upper", args, 1)
+	case "length", "len", "char_length", "character_length":
+		return callFunction("length", args, 1)
+	case "strlen", "octet_length":
+		return callFunction("octet_length", args, 1)
+	case "trim", "ltrim", "rtrim":
+		return callFunction(name, args, 1, 2)
+	case "substring", "substr":
+		return callFunction("substr", args, 2, 3)
+	case "concat":
+		return callFunction("concat", args, -1)
+	case "prefix", "starts_with":
+		return callFunction("starts_with", args, 2)
+	case "suffix", "ends_with":
+		if len(args) == 2 {
+			return "(right(" + args[0] + ", length(" + args[1] + ")) = " + args[1] + ")"
+		}
+	case "contains":
+		if len(args) == 2 {
+			return "(strpos(" + args[0] + ", " + args[1] + ") > 0)"
+		}
+	case "regexp_matches":
+		if len(args) == 2 {
+			return "(" + args[0] + " ~ " + args[1] + ")"
+		}
+	case "abs", "floor", "ceil":
+		return callFunction(name, args, 1)
+	case "ceiling":
+		return callFunction("ceil", args, 1)
+	case "round":
+		// round(double precision, integer) does not exist in PostgreSQL.
+		return callFunction("round", args, 1)
+	case "year", "month", "day", "hour", "minute":
+		if len(args) == 1 {
+			return "EXTRACT(" + strings.ToUpper(name) + " FROM " + args[0] + ")"
+		}
+	case "date_part", "date_trunc":
+		return callFunction(name, args, 2)
+	}
+	return ""
+}
+
+func (postgresDialect) operator(name string, args []string) string {
+	switch name {
+	case "+", "-", "*", "%":
+		if len(args) == 2 {
+			return "(" + args[0] + " " + name + " " + args[1] + ")"
+		}
+		if len(args) == 1 && (name == "-" || name == "+") {
+			return name + args[0]
+		}
+	case "/":
+		// DuckDB "/" always divides as floating point.
+		if len(args) == 2 {
+			return "(CAST(" + args[0] + " AS DOUBLE PRECISION) / " + args[1] + ")"
+		}
+	case "~~", "!~~", "~~*", "!~~*":
+		if len(args) == 2 {
+			return args[0] + likeOperators[name] + args[1] + likeEscape(args[1])
+		}
+	case "~", "!~", "~*", "!~*":
+		// DuckDB "~" is a full match, PostgreSQL "~" matches anywhere.
+		if len(args) == 2 {
+			return args[0] + " " + name + " " + anchorPattern(args[1], postgresConcat)
+		}
+	case "||":
+		if len(args) >= 2 {
+			return "(" + strings.Join(args, " || ") + ")"
+		}
+	}
+	return ""
+}
+
+func (postgresDialect) distinctFrom(left, right string, negate bool) string {
+	if negate {
+		return left + " IS NOT DISTINCT FROM " + right
+	}
+	return left + " IS DISTINCT FROM " + right
+}
+
+// placeholder returns $n. Text arguments are typed, since PostgreSQL cannot
+// resolve overloaded functions such as length() for untyped parameters.
+func (postgresDialect) placeholder(n int, lt LogicalType) string {
+	if lt.ID == TypeIDVarchar || lt.ID == TypeIDChar {
+		return "$" + strconv.Itoa(n) + "::text"
+	}
+	return "$" + strconv.Itoa(n)
+}
+
+// formatTypeName formats a LogicalType as a PostgreSQL type name.
+// Returns empty string for types without a PostgreSQL equivalent.
+func (d postgresDialect) formatTypeName(lt LogicalType) string {
+	switch lt.ID {
+	case TypeIDBoolean:
+		return "BOOLEAN"
+	case TypeIDTinyInt, TypeIDSmallInt, TypeIDUTinyInt:
+		return "SMALLINT"
+	case TypeIDInteger, TypeIDUSmallInt:
+		return "INTEGER"
+	case TypeIDBigInt, TypeIDUInteger:
+		return "BIGINT"
+	case TypeIDUBigInt:
+		return "NUMERIC(20, 0)"
+	case TypeIDHugeInt:
+		return "NUMERIC(38, 0)"
+	case TypeIDUHugeInt:
+		return "NUMERIC(39, 0)"
+	case TypeIDFloat:
+		return "REAL"
+	case TypeIDDouble:
+		return "DOUBLE PRECISION"
+	case TypeIDDecimal:
+		if info, ok := lt.TypeInfo.(*DecimalTypeInfo); ok {
+			return fmt.Sprintf("NUMERIC(%d, %d)", info.Width, info.Scale)
+		}
+		return "NUMERIC"
+	case TypeIDVarchar, TypeIDChar:
+		return "TEXT"
+	case TypeIDBlob:
+		return "BYTEA"
+	case TypeIDDate:
+		return "DATE"
+	case TypeIDTime:
+		return "TIME"
+	case TypeIDTimeTZ:
+		return "TIME WITH TIME ZONE"
+	case TypeIDTimestamp, TypeIDTimestampNs:
+		return "TIMESTAMP"
+	case TypeIDTimestampMs:
+		return "TIMESTAMP(3)"
+	case TypeIDTimestampSec:
+		return "TIMESTAMP(0)"
+	case TypeIDTimestampTZ:
+		return "TIMESTAMP WITH TIME ZONE"
+	case TypeIDInterval:
+		return "INTERVAL"
+	case TypeIDUUID:
+		return "UUID"
+	case TypeIDList:
+		if info, ok := lt.TypeInfo.(*ListTypeInfo); ok {
+			if childType := d.formatTypeName(info.ChildType); childType != "" {
+				return childType + "[]"
+			}
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
+// postgresConcat concatenates string expressions with ||.
+func postgresConcat(parts ...string) string {
+	return "(" + strings.Join(parts, " || ") + ")"
+}
@@ -0,0 +1,961 @@
+package filter
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dialect provides the database-specific parts of SQL generation.
+// Methods return an empty string for constructs the database cannot express,
+// which makes the enclosing expression unsupported.
+type dialect interface {
+	// quoteIdentifier returns a quoted identifier if needed.
+	quoteIdentifier(name string) string
+
+	// quoteString returns a string literal with proper escaping.
+	quoteString(s string) string
+
+	// formatBlob formats a binary literal.
+	formatBlob(b []byte) string
+
+	// formatDate formats a date literal from its "2006-01-02" form.
+	formatDate(date string) string
+
+	// formatTime formats a time literal from its "15:04:05[.000000]" form.
+	formatTime(clock string) string
+
+	// formatTimestamp formats a timestamp literal from its "2006-01-02 15:04:05[.000000]" form.
+	// Values of TIMESTAMP_TZ are in UTC.
+	formatTimestamp(ts string, typeID LogicalTypeID) string
+
+	// formatInterval formats an interval literal.
+	formatInterval(iv Interval) string
+
+	// formatUUID formats a UUID literal from its canonical string form.
+	formatUUID(s string) string
+
+	// formatList formats a list literal from formatted elements.
+	formatList(items []string) string
+
+	// formatStruct formats a struct literal from formatted field values.
+	formatStruct(fields []string) string
+
+	// formatMap formats a map literal from formatted keys and values.
+	formatMap(keys, values []string) string
+
+	// cast encodes CAST or TRY_CAST of an encoded expression.
+	cast(child string, lt LogicalType, try bool) string
+
+	// function encodes a function call with encoded arguments.
+	function(name string, args []string) string
+
+	// operator encodes an operator represented as a function (LIKE, ||, arithmetic).
+	operator(name string, args []string) string
+
+	// distinctFrom encodes IS DISTINCT FROM, or IS NOT DISTINCT FROM if negate is set.
+	distinctFrom(left, right string, negate bool) string
+
+	// placeholder returns the n-th (1-based) placeholder for a bound argument of type lt.
+	placeholder(n int, lt LogicalType) string
+}
+
+// sqlEncoder walks filter expressions and produces SQL for a dialect.
+// The dialect encoders embed it and expose it through the Encoder interface.
+type sqlEncoder struct {
+	dialect        dialect
+	opts           *EncoderOptions
+	columnBindings []string
+
+	// parameterized is set while encoding with placeholders.
+	parameterized bool
+	params        []sqlParam
+	inline        int // > 0 while encoding constants that must stay literals
+}
+
+// newSQLEncoder creates an encoder for d.
+// If opts is nil, default options are used.
+func newSQLEncoder(d dialect, opts *EncoderOptions) sqlEncoder {
+	if opts == nil {
+		opts = &EncoderOptions{}
+	}
+	return sqlEncoder{dialect: d, opts: opts}
+}
+
+// encodeFilters converts all filters to a WHERE clause body.
+func (e *sqlEncoder) encodeFilters(fp *FilterPushdown) string {
+	if fp == nil || len(fp.Filters) == 0 {
+		return ""
+	}
+
+	e.columnBindings = fp.ColumnBindings
+
+	var parts []string
+	for _, filter := range fp.Filters {
+		encoded := e.encode(filter)
+		if encoded != "" {
+			parts = append(parts, encoded)
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	return "(" + strings.Join(parts, ") AND (") + ")"
+}
+
+// encode converts a single expression to SQL.
+// Returns empty string if expression is unsupported.
+func (e *sqlEncoder) encode(expr Expression) string {
+	if expr == nil {
+		return ""
+	}
+
+	switch ex := expr.(type) {
+	case *ComparisonExpression:
+		return e.encodeComparison(ex)
+	case *ConjunctionExpression:
+		return e.encodeConjunction(ex)
+	case *ConstantExpression:
+		return e.encodeConstant(ex)
+	case *ColumnRefExpression:
+		return e.encodeColumnRef(ex)
+	case *FunctionExpression:
+		return e.encodeFunction(ex)
+	case *CastExpression:
+		return e.encodeCast(ex)
+	case *BetweenExpression:
+		return e.encodeBetween(ex)
+	case *OperatorExpression:
+		return e.encodeOperator(ex)
+	case *CaseExpression:
+		return e.encodeCase(ex)
+	case *ParameterExpression:
+		return e.encodeParameter(ex)
+	case *AggregateExpression, *WindowExpression, *UnsupportedExpression:
+		// These expression types are not supported for filter pushdown
+		return ""
+	default:
+		return ""
+	}
+}
+
+// encodeComparison encodes a comparison expression.
+func (e *sqlEncoder) encodeComparison(c *ComparisonExpression) string {
+	// The right side of IN is a list_value function, which is not
+	// a function in every dialect.
+	switch c.Type() {
+	case TypeCompareIn:
+		return e.encodeIn(c, false)
+	case TypeCompareNotIn:
+		return e.encodeIn(c, true)
+	}
+
+	left := e.encode(c.Left)
+	right := e.encode(c.Right)
+
+	if left == "" || right == "" {
+		return ""
+	}
+
+	switch c.Type() {
+	case TypeCompareEqual:
+		return left + " = " + right
+	case TypeCompareNotEqual:
+		return left + " <> " + right
+	case TypeCompareLessThan:
+		return left + " < " + right
+	case TypeCompareGreaterThan:
+		return left + " > " + right
+	case TypeCompareLessThanOrEqual:
+		return left + " <= " + right
+	case TypeCompareGreaterThanOrEqual:
+		return left + " >= " + right
+	case TypeCompareDistinctFrom:
+		return e.dialect.distinctFrom(left, right, false)
+	case TypeCompareNotDistinctFrom:
+		return e.dialect.distinctFrom(left, right, true)
+	case TypeCompareBetween, TypeCompareNotBetween:
+		// These are handled by BetweenExpression
+		return ""
+	default:
+		return ""
+	}
+}
+
+// encodeIn encodes IN/NOT IN expressions.
+func (e *sqlEncoder) encodeIn(c *ComparisonExpression, notIn bool) string {
+	left := e.encode(c.Left)
+	if left == "" {
+		return ""
+	}
+
+	// The right side should be a function expression with list_value
+	funcExpr, ok := c.Right.(*FunctionExpression)
+	if !ok {
+		return ""
+	}
+
+	// Encode all children as the IN list
+	var values []string
+	for _, child := range funcExpr.Children {
+		encoded := e.encode(child)
+		if encoded == "" {
+			return ""
+		}
+		values = append(values, encoded)
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	op := " IN "
+	if notIn {
+		op = " NOT IN "
+	}
+
+	return left + op + "(" + strings.Join(values, ", ") + ")"
+}
+
+// encodeConjunction encodes AND/OR conjunctions.
+func (e *sqlEncoder) encodeConjunction(c *ConjunctionExpression) string {
+	var parts []string
+	for _, child := range c.Children {
+		encoded := e.encode(child)
+		if encoded != "" {
+			parts = append(parts, encoded)
+		}
+	}
+
+	// Handle unsupported expression rules:
+	// - For OR: if any child is unsupported, skip entire OR
+	// - For AND: skip unsupported children, keep others
+	if c.Type() == TypeConjunctionOr {
+		if len(parts) != len(c.Children) {
+			// Some child was unsupported, skip entire OR
+			return ""
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	op := " AND "
+	if c.Type() == TypeConjunctionOr {
+		op = " OR "
+	}
+
+	return "(" + strings.Join(parts, op) + ")"
+}
+
+// encodeConstant encodes a constant value.
+func (e *sqlEncoder) encodeConstant(c *ConstantExpression) string {
+	literal := e.formatValue(c.Value)
+	if literal == "" {
+		return ""
+	}
+	if marker, ok := e.encodeParam(c.Value); ok {
+		return marker
+	}
+	return literal
+}
+
+// encodeColumnRef encodes a column reference.
+func (e *sqlEncoder) encodeColumnRef(c *ColumnRefExpression) string {
+	// Get the column name from bindings
+	if c.Binding.ColumnIndex < 0 || c.Binding.ColumnIndex >= len(e.columnBindings) {
+		return ""
+	}
+
+	colName := e.columnBindings[c.Binding.ColumnIndex]
+
+	// Check for expression mapping first (takes precedence)
+	if e.opts.ColumnExpressions != nil {
+		if expr, ok := e.opts.ColumnExpressions[colName]; ok {
+			return expr
+		}
+	}
+
+	// Check for name mapping
+	if e.opts.ColumnMapping != nil {
+		if mapped, ok := e.opts.ColumnMapping[colName]; ok {
+			colName = mapped
+		}
+	}
+
+	return e.dialect.quoteIdentifier(colName)
+}
+
+// encodeFunction encodes a function expression.
+func (e *sqlEncoder) encodeFunction(f *FunctionExpression) string {
+	// Encode all arguments
+	var args []string
+	for i, child := range f.Children {
+		encoded := e.encodeArg(f.Name, i, child)
+		if encoded == "" {
+			return ""
+		}
+		args = append(args, encoded)
+	}
+
+	// Handle operators represented as functions
+	if f.IsOperator {
+		return e.dialect.operator(f.Name, args)
+	}
+
+	return e.dialect.function(f.Name, args)
+}
+
+// encodeArg encodes a function argument.
+// Date part names stay literals when encoding with placeholders,
+// since some dialects translate them to keywords.
+func (e *sqlEncoder) encodeArg(name string, i int, arg Expression) string {
+	if i == 0 && (name == "date_part" || name == "date_trunc" || name == "datepart" || name == "datetrunc") {
+		e.inline++
+		defer func() { e.inline-- }()
+	}
+	return e.encode(arg)
+}
+
+// encodeCast encodes a CAST expression.
+func (e *sqlEncoder) encodeCast(c *CastExpression) string {
+	child := e.encode(c.Child)
+	if child == "" {
+		return ""
+	}
+	return e.dialect.cast(child, c.ReturnType, c.TryCast)
+}
+
+// encodeBetween encodes a BETWEEN expression.
+func (e *sqlEncoder) encodeBetween(b *BetweenExpression) string {
+	input := e.encode(b.Input)
+	lower := e.encode(b.Lower)
+	upper := e.encode(b.Upper)
+
+	if input == "" || lower == "" || upper == "" {
+		return ""
+	}
+
+	notBetween := b.Type() == TypeCompareNotBetween
+
+	// Standard BETWEEN is always inclusive
+	if b.LowerInclusive && b.UpperInclusive {
+		if notBetween {
+			return input + " NOT BETWEEN " + lower + " AND " + upper
+		}
+		return input + " BETWEEN " + lower + " AND " + upper
+	}
+
+	// For non-standard bounds, use comparison operators
+	var conditions []string
+	if b.LowerInclusive {
+		conditions = append(conditions, input+" >= "+lower)
+	} else {
+		conditions = append(conditions, input+" > "+lower)
+	}
+	if b.UpperInclusive {
+		conditions = append(conditions, input+" <= "+upper)
+	} else {
+		conditions = append(conditions, input+" < "+upper)
+	}
+
+	result := "(" + strings.Join(conditions, " AND ") + ")"
+	if notBetween {
+		return "NOT " + result
+	}
+	return result
+}
+
+// encodeOperator encodes operator expressions (IS NULL, IS NOT NULL, NOT, IN, NOT IN, etc.).
+func (e *sqlEncoder) encodeOperator(o *OperatorExpression) string {
+	if len(o.Children) == 0 {
+		return ""
+	}
+
+	switch o.Type() {
+	case TypeOperatorIsNull:
+		child := e.encode(o.Children[0])
+		if child == "" {
+			return ""
+		}
+		return child + " IS NULL"
+
+	case TypeOperatorIsNotNull:
+		child := e.encode(o.Children[0])
+		if child == "" {
+			return ""
+		}
+		return child + " IS NOT NULL"
+
+	case TypeOperatorNot:
+		child := e.encode(o.Children[0])
+		if child == "" {
+			return ""
+		}
+		return "NOT (" + child + ")"
+
+	case TypeOperatorCoalesce:
+		var args []string
+		for _, child := range o.Children {
+			encoded := e.encode(child)
+			if encoded == "" {
+				return ""
+			}
+			args = append(args, encoded)
+		}
+		return "COALESCE(" + strings.Join(args, ", ") + ")"
+
+	case TypeOperatorNullIf:
+		if len(o.Children) != 2 {
+			return ""
+		}
+		left := e.encode(o.Children[0])
+		right := e.encode(o.Children[1])
+		if left == "" || right == "" {
+			return ""
+		}
+		return "NULLIF(" + left + ", " + right + ")"
+
+	case TypeCompareIn:
+		return e.encodeInOperator(o, false)
+
+	case TypeCompareNotIn:
+		return e.encodeInOperator(o, true)
+
+	default:
+		return ""
+	}
+}
+
+// encodeInOperator encodes IN/NOT IN operator expressions.
+// Format: children[0] = column, children[1...n] = values
+func (e *sqlEncoder) encodeInOperator(o *OperatorExpression, notIn bool) string {
+	if len(o.Children) < 2 {
+		return ""
+	}
+
+	// First child is the column/expression being tested
+	left := e.encode(o.Children[0])
+	if left == "" {
+		return ""
+	}
+
+	// Remaining children are the values in the IN list
+	var values []string
+	for i := 1; i < len(o.Children); i++ {
+		encoded := e.encode(o.Children[i])
+		if encoded == "" {
+			return ""
+		}
+		values = append(values, encoded)
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	op := " IN "
+	if notIn {
+		op = " NOT IN "
+	}
+
+	return left + op + "(" + strings.Join(values, ", ") + ")"
+}
+
+// encodeCase encodes a CASE expression.
+func (e *sqlEncoder) encodeCase(c *CaseExpression) string {
+	var sb strings.Builder
+	sb.WriteString("CASE")
+
+	for _, check := range c.CaseChecks {
+		whenExpr := e.encode(check.WhenExpr)
+		thenExpr := e.encode(check.ThenExpr)
+		if whenExpr == "" || thenExpr == "" {
+			return ""
+		}
+		sb.WriteString(" WHEN ")
+		sb.WriteString(whenExpr)
+		sb.WriteString(" THEN ")
+		sb.WriteString(thenExpr)
+	}
+
+	if c.ElseExpr != nil {
+		elseExpr := e.encode(c.ElseExpr)
+		if elseExpr == "" {
+			return ""
+		}
+		sb.WriteString(" ELSE ")
+		sb.WriteString(elseExpr)
+	}
+
+	sb.WriteString(" END")
+	return sb.String()
+}
+
+// encodeParameter encodes a parameter expression.
+func (e *sqlEncoder) encodeParameter(p *ParameterExpression) string {
+	return p.Identifier
+}
+
+// formatValue formats a Value as a SQL literal.
+func (e *sqlEncoder) formatValue(v Value) string {
+	if v.IsNull {
+		return "NULL"
+	}
+
+	switch v.Type.ID {
+	case TypeIDBoolean:
+		return formatBoolValue(v.Data)
+	case TypeIDTinyInt, TypeIDSmallInt, TypeIDInteger, TypeIDBigInt:
+		return formatIntValue(v.Data)
+	case TypeIDUTinyInt, TypeIDUSmallInt, TypeIDUInteger, TypeIDUBigInt:
+		return formatUIntValue(v.Data)
+	case TypeIDHugeInt:
+		return formatHugeIntValue(v.Data)
+	case TypeIDUHugeInt:
+		return formatUHugeIntValue(v.Data)
+	case TypeIDFloat, TypeIDDouble:
+		return formatFloatValue(v.Data)
+	case TypeIDDecimal:
+		return formatDecimalValue(v.Data)
+	case TypeIDVarchar, TypeIDChar:
+		return e.formatStringValue(v.Data)
+	case TypeIDBlob:
+		return e.formatBlobValue(v.Data)
+	case TypeIDDate:
+		return e.formatDateValue(v.Data)
+	case TypeIDTime, TypeIDTimeTZ:
+		return e.formatTimeValue(v.Data)
+	case TypeIDTimestamp, TypeIDTimestampTZ, TypeIDTimestampMs, TypeIDTimestampNs, TypeIDTimestampSec:
+		return e.formatTimestampValue(v.Data, v.Type.ID)
+	case TypeIDInterval:
+		if iv, ok := v.Data.(Interval); ok {
+			return e.dialect.formatInterval(iv)
+		}
+		return ""
+	case TypeIDUUID:
+		if s, ok := v.Data.(string); ok {
+			return e.dialect.formatUUID(s)
+		}
+		return ""
+	case TypeIDList, TypeIDArray:
+		return e.formatListValue(v.Data)
+	case TypeIDStruct:
+		return e.formatStructValue(v.Data)
+	case TypeIDMap:
+		return e.formatMapValue(v.Data)
+	default:
+		// For unknown types, try to format as generic
+		return e.formatGenericValue(v.Data)
+	}
+}
+
+// formatBoolValue formats a boolean value.
+func formatBoolValue(data any) string {
+	if b, ok := data.(bool); ok {
+		if b {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	return ""
+}
+
+// formatIntValue formats a signed integer value.
+func formatIntValue(data any) string {
+	switch v := data.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	default:
+		return ""
+	}
+}
+
+// formatUIntValue formats an unsigned integer value.
+func formatUIntValue(data any) string {
+	switch v := data.(type) {
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatUint(uint64(v), 10)
+	default:
+		return ""
+	}
+}
+
+// formatHugeIntValue formats a 128-bit signed integer value.
+func formatHugeIntValue(data any) string {
+	switch v := data.(type) {
+	case HugeInt:
+		// Convert upper/lower to big.Int
+		bi := new(big.Int)
+		bi.SetInt64(v.Upper)
+		bi.Lsh(bi, 64)
+		lower := new(big.Int).SetUint64(v.Lower)
+		bi.Or(bi, lower)
+		return bi.String()
+	default:
+		return ""
+	}
+}
+
+// formatUHugeIntValue formats a 128-bit unsigned integer value.
+func formatUHugeIntValue(data any) string {
+	switch v := data.(type) {
+	case UHugeInt:
+		bi := new(big.Int)
+		bi.SetUint64(v.Upper)
+		bi.Lsh(bi, 64)
+		lower := new(big.Int).SetUint64(v.Lower)
+		bi.Or(bi, lower)
+		return bi.String()
+	default:
+		return ""
+	}
+}
+
+// formatFloatValue formats a floating-point value.
+func formatFloatValue(data any) string {
+	switch v := data.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	default:
+		return ""
+	}
+}
+
+// formatDecimalValue formats a decimal value.
+func formatDecimalValue(data any) string {
+	switch v := data.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// formatStringValue formats a string value with proper escaping.
+func (e *sqlEncoder) formatStringValue(data any) string {
+	switch v := data.(type) {
+	case string:
+		return e.dialect.quoteString(v)
+	default:
+		return ""
+	}
+}
+
+// formatBlobValue formats a blob value.
+func (e *sqlEncoder) formatBlobValue(data any) string {
+	switch v := data.(type) {
+	case []byte:
+		return e.dialect.formatBlob(v)
+	case string:
+		// Already a string, escape it
+		return e.dialect.quoteString(v)
+	default:
+		return ""
+	}
+}
+
+// formatDateValue formats a date value.
+// The value is days since Unix epoch (1970-01-01).
+func (e *sqlEncoder) formatDateValue(data any) string {
+	days, ok := int64Data(data)
+	if !ok {
+		return ""
+	}
+	return e.dialect.formatDate(dateTime(days).Format("2006-01-02"))
+}
+
+// formatTimeValue formats a time value.
+// The value is microseconds since midnight.
+func (e *sqlEncoder) formatTimeValue(data any) string {
+	micros, ok := int64Data(data)
+	if !ok {
+		return ""
+	}
+	return e.dialect.formatTime(clockString(micros))
+}
+
+// formatTimestampValue formats a timestamp value.
+func (e *sqlEncoder) formatTimestampValue(data any, typeID LogicalTypeID) string {
+	value, ok := int64Data(data)
+	if !ok {
+		return ""
+	}
+	t := timestampTime(value, typeID)
+
+	// Add fractional seconds only when present
+	formatted := t.Format("2006-01-02 15:04:05")
+	switch nanos := t.Nanosecond(); {
+	case nanos == 0:
+	case nanos%1000 != 0:
+		formatted += fmt.Sprintf(".%09d", nanos)
+	default:
+		formatted += fmt.Sprintf(".%06d", nanos/1000)
+	}
+
+	return e.dialect.formatTimestamp(formatted, typeID)
+}
+
+// formatListValue formats a list/array value.
+func (e *sqlEncoder) formatListValue(data any) string {
+	switch v := data.(type) {
+	case ListValue:
+		parts, ok := e.formatValues(v.Children)
+		if !ok {
+			return ""
+		}
+		return e.dialect.formatList(parts)
+	default:
+		return ""
+	}
+}
+
+// formatStructValue formats a struct value.
+func (e *sqlEncoder) formatStructValue(data any) string {
+	switch v := data.(type) {
+	case StructValue:
+		parts, ok := e.formatValues(v.Children)
+		if !ok {
+			return ""
+		}
+		return e.dialect.formatStruct(parts)
+	default:
+		return ""
+	}
+}
+
+// formatMapValue formats a map value.
+func (e *sqlEncoder) formatMapValue(data any) string {
+	switch v := data.(type) {
+	case MapValue:
+		n := min(len(v.Keys), len(v.Values))
+		keys, ok := e.formatValues(v.Keys[:n])
+		if !ok {
+			return ""
+		}
+		values, ok := e.formatValues(v.Values[:n])
+		if !ok {
+			return ""
+		}
+		return e.dialect.formatMap(keys, values)
+	default:
+		return ""
+	}
+}
+
+// formatValues formats each value, returning false if any is unsupported.
+func (e *sqlEncoder) formatValues(values []Value) ([]string, bool) {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		formatted := e.formatValue(v)
+		if formatted == "" {
+			return nil, false
+		}
+		parts = append(parts, formatted)
+	}
+	return parts, true
+}
+
+// formatGenericValue formats a generic value.
+func (e *sqlEncoder) formatGenericValue(data any) string {
+	switch v := data.(type) {
+	case string:
+		return e.dialect.quoteString(v)
+	case bool:
+		return formatBoolValue(v)
+	case int, int8, int16, int32, int64:
+		return fmt.Sprintf("%d", v)
+	case uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32, float64:
+		return fmt.Sprintf("%g", v)
+	case nil:
+		return "NULL"
+	default:
+		return ""
+	}
+}
+
+// intervalText formats an interval in the "1 years 2 months 3 days 04:05:06"
+// style accepted by DuckDB and PostgreSQL interval literals.
+func intervalText(v Interval) string {
+	var parts []string
+
+	if v.Months != 0 {
+		years := v.Months / 12
+		months := v.Months % 12
+		if years != 0 {
+			parts = append(parts, fmt.Sprintf("%d years", years))
+		}
+		if months != 0 {
+			parts = append(parts, fmt.Sprintf("%d months", months))
+		}
+	}
+
+	if v.Days != 0 {
+		parts = append(parts, fmt.Sprintf("%d days", v.Days))
+	}
+
+	if v.Micros != 0 {
+		// Convert to hours, minutes, seconds, microseconds
+		micros := v.Micros
+		hours := micros / 3600000000
+		micros %= 3600000000
+		mins := micros / 60000000
+		micros %= 60000000
+		secs := micros / 1000000
+		micros %= 1000000
+
+		if hours != 0 {
+			parts = append(parts, fmt.Sprintf("%d hours", hours))
+		}
+		if mins != 0 {
+			parts = append(parts, fmt.Sprintf("%d minutes", mins))
+		}
+		if secs != 0 || micros != 0 {
+			if micros != 0 {
+				parts = append(parts, fmt.Sprintf("%d.%06d seconds", secs, micros))
+			} else {
+				parts = append(parts, fmt.Sprintf("%d seconds", secs))
+			}
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// hexString formats b as lowercase hexadecimal digits.
+func hexString(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		fmt.Fprintf(&sb, "%02x", c)
+	}
+	return sb.String()
+}
+
+// unquoteLiteral returns the content of an encoded single-quoted string literal.
+// Returns false if s is not a plain literal.
+func unquoteLiteral(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", false
+	}
+	inner := s[1 : len(s)-1]
+	if strings.Count(inner, "'")%2 != 0 {
+		return "", false
+	}
+	return strings.ReplaceAll(inner, "''", "'"), true
+}
+
+// likeOperators maps DuckDB LIKE operator names to SQL.
+var likeOperators = map[string]string{
+	"~~":   " LIKE ",
+	"!~~":  " NOT LIKE ",
+	"~~*":  " ILIKE ",
+	"!~~*": " NOT ILIKE ",
+}
+
+// anchorPattern wraps an encoded regular expression so that it matches the whole string.
+// Patterns that are not literals are wrapped with concat.
+func anchorPattern(pattern string, concat func(parts ...string) string) string {
+	if lit, ok := unquoteLiteral(pattern); ok {
+		return quoteLiteral("^(?:" + lit + ")$")
+	}
+	return concat("'^(?:'", pattern, "')$'")
+}
+
+// likeEscape disables the default backslash escape of LIKE for patterns
+// that may contain a backslash, since DuckDB LIKE has no escape character.
+func likeEscape(pattern string) string {
+	if lit, ok := unquoteLiteral(pattern); ok && !strings.Contains(lit, `\`) {
+		return ""
+	}
+	return ` ESCAPE ''`
+}
+
+// callFunction encodes name(args...) if the argument count is one of counts.
+// A count of -1 accepts any non-zero number of arguments.
+// Returns empty string otherwise.
+func callFunction(name string, args []string, counts ...int) string {
+	for _, n := range counts {
+		if n == len(args) || (n == -1 && len(args) > 0) {
+			return name + "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	return ""
+}
+
+// int64Data returns integer value data, which is float64 when decoded from JSON numbers.
+func int64Data(data any) (int64, bool) {
+	switch v := data.(type) {
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// dateTime converts days since Unix epoch to a UTC time.
+func dateTime(days int64) time.Time {
+	return time.Unix(days*86400, 0).UTC()
+}
+
+// clockString formats microseconds since midnight as "15:04:05[.000000]".
+func clockString(micros int64) string {
+	// Convert microseconds to time components
+	hours := micros / 3600000000
+	micros %= 3600000000
+	mins := micros / 60000000
+	micros %= 60000000
+	secs := micros / 1000000
+	micros %= 1000000
+
+	if micros > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d.%06d", hours, mins, secs, micros)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", hours, mins, secs)
+}
+
+// timestampTime converts a timestamp value to a UTC time.
+// The value unit depends on the timestamp type: seconds, milliseconds,
+// microseconds (TIMESTAMP, TIMESTAMP_TZ) or nanoseconds.
+func timestampTime(value int64, typeID LogicalTypeID) time.Time {
+	switch typeID {
+	case TypeIDTimestampSec:
+		return time.Unix(value, 0).UTC()
+	case TypeIDTimestampMs:
+		return time.UnixMilli(value).UTC()
+	case TypeIDTimestampNs:
+		return time.Unix(0, value).UTC()
+	default: // TypeIDTimestamp, TypeIDTimestampTZ (microseconds)
+		return time.UnixMicro(value).UTC()
+	}
+}
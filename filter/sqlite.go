@@ -0,0 +1,191 @@
+package filter
+
+import "strings"
+
+// SQLiteEncoder encodes filter expressions to SQLite SQL syntax.
+//
+// Dates and timestamps are encoded as ISO-8601 text literals, matching the
+// default SQLite storage format. SQLite LIKE is case-insensitive for ASCII
+// letters, so LIKE and ILIKE are both encoded as LIKE and NOT LIKE is not
+// supported. Regular expressions, INTERVAL, LIST, STRUCT and MAP literals
+// and TRY_CAST are not supported.
+type SQLiteEncoder struct {
+	sqlEncoder
+}
+
+// NewSQLiteEncoder creates a new SQLite SQL encoder.
+// If opts is nil, default options are used.
+func NewSQLiteEncoder(opts *EncoderOptions) *SQLiteEncoder {
+	return &SQLiteEncoder{newSQLEncoder(sqliteDialect{}, opts)}
+}
+
+// EncodeFilters converts all filters to a WHERE clause body.
+// Returns the condition portion without "WHERE" keyword.
+// Returns empty string if no filters can be encoded.
+func (e *SQLiteEncoder) EncodeFilters(fp *FilterPushdown) string {
+	return e.encodeFilters(fp)
+}
+
+// EncodeFiltersWithArgs converts all filters to a WHERE clause body
+// with ? placeholders and returns the bound arguments in order.
+func (e *SQLiteEncoder) EncodeFiltersWithArgs(fp *FilterPushdown) (string, []any) {
+	return e.encodeFiltersWithArgs(fp)
+}
+
+// Encode converts a single expression to SQL.
+// Returns empty string if expression is unsupported.
+func (e *SQLiteEncoder) Encode(expr Expression) string {
+	return e.encode(expr)
+}
+
+// sqliteDialect implements the SQLite SQL dialect.
+type sqliteDialect struct{}
+
+// sqliteDateParts maps DuckDB date part functions to strftime formats.
+var sqliteDateParts = map[string]string{
+	"year":   "%Y",
+	"month":  "%m",
+	"day":    "%d",
+	"hour":   "%H",
+	"minute": "%M",
+}
+
+func (sqliteDialect) quoteIdentifier(name string) string { return quoteIdentifier(name) }
+
+func (sqliteDialect) quoteString(s string) string { return quoteLiteral(s) }
+
+func (sqliteDialect) formatBlob(b []byte) string { return "X'" + hexString(b) + "'" }
+
+func (sqliteDialect) formatDate(date string) string { return quoteLiteral(date) }
+
+func (sqliteDialect) formatTime(clock string) string { return quoteLiteral(clock) }
+
+func (sqliteDialect) formatTimestamp(ts string, _ LogicalTypeID) string { return quoteLiteral(ts) }
+
+func (sqliteDialect) formatInterval(Interval) string { return "" }
+
+func (sqliteDialect) formatUUID(s string) string { return quoteLiteral(s) }
+
+func (sqliteDialect) formatList([]string) string { return "" }
+
+func (sqliteDialect) formatStruct([]string) string { return "" }
+
+func (sqliteDialect) formatMap(_, _ []string) string { return "" }
+
+func (d sqliteDialect) cast(child string, lt LogicalType, try bool) string {
+	typeName := d.formatTypeName(lt)
+	if typeName == "" || try {
+		return ""
+	}
+	return "CAST(" + child + " AS " + typeName + ")"
+}
+
+func (sqliteDialect) function(name string, args []string) string {
+	switch name {
+	case "lower", "lcase":
+		return callFunction("lower", args, 1)
+	case "upper", "ucase":
+		return callFunction("upper", args, 1)
+	case "length", "len", "char_length", "character_length":
+		return callFunction("length", args, 1)
+	case "strlen", "octet_length":
+		if len(args) == 1 {
+			return "length(CAST(" + args[0] + " AS BLOB))"
+		}
+	case "trim", "ltrim", "rtrim":
+		return callFunction(name, args, 1, 2)
+	case "substring", "substr":
+		return callFunction("substr", args, 2, 3)
+	case "concat":
+		// DuckDB concat treats NULL arguments as empty strings.
+		if len(args) > 0 {
+			parts := make([]string, len(args))
+			for i, arg := range args {
+				parts[i] = "COALESCE(" + arg + ", '')"
+			}
+			return "(" + strings.Join(parts, " || ") + ")"
+		}
+	case "prefix", "starts_with":
+		if len(args) == 2 {
+			return "(substr(" + args[0] + ", 1, length(" + args[1] + ")) = " + args[1] + ")"
+		}
+	case "suffix", "ends_with":
+		if len(args) == 2 {
+			return "(substr(" + args[0] + ", length(" + args[0] + ") - length(" + args[1] + ") + 1) = " + args[1] + ")"
+		}
+	case "contains":
+		if len(args) == 2 {
+			return "(instr(" + args[0] + ", " + args[1] + ") > 0)"
+		}
+	case "abs":
+		return callFunction("abs", args, 1)
+	case "round":
+		return callFunction("round", args, 1, 2)
+	case "year", "month", "day", "hour", "minute":
+		if len(args) == 1 {
+			return "CAST(strftime('" + sqliteDateParts[name] + "', " + args[0] + ") AS INTEGER)"
+		}
+	}
+	return ""
+}
+
+func (sqliteDialect) operator(name string, args []string) string {
+	switch name {
+	case "+", "-", "*", "%":
+		if len(args) == 2 {
+			return "(" + args[0] + " " + name + " " + args[1] + ")"
+		}
+		if len(args) == 1 && (name == "-" || name == "+") {
+			return name + args[0]
+		}
+	case "/":
+		// DuckDB "/" always divides as floating point.
+		if len(args) == 2 {
+			return "(CAST(" + args[0] + " AS REAL) / " + args[1] + ")"
+		}
+	case "~~", "~~*":
+		// LIKE is case-insensitive, which only widens a case-sensitive match.
+		if len(args) == 2 {
+			return args[0] + " LIKE " + args[1]
+		}
+	case "!~~*":
+		if len(args) == 2 {
+			return args[0] + " NOT LIKE " + args[1]
+		}
+	case "||":
+		if len(args) >= 2 {
+			return "(" + strings.Join(args, " || ") + ")"
+		}
+	}
+	return ""
+}
+
+func (sqliteDialect) distinctFrom(left, right string, negate bool) string {
+	if negate {
+		return left + " IS " + right
+	}
+	return left + " IS NOT " + right
+}
+
+func (sqliteDialect) placeholder(int, LogicalType) string { return "?" }
+
+// formatTypeName formats a LogicalType as a SQLite type affinity.
+// Returns empty string for types without a SQLite equivalent.
+func (sqliteDialect) formatTypeName(lt LogicalType) string {
+	switch lt.ID {
+	case TypeIDBoolean, TypeIDTinyInt, TypeIDSmallInt, TypeIDInteger, TypeIDBigInt,
+		TypeIDUTinyInt, TypeIDUSmallInt, TypeIDUInteger:
+		return "INTEGER"
+	case TypeIDUBigInt, TypeIDHugeInt, TypeIDUHugeInt, TypeIDDecimal:
+		return "NUMERIC"
+	case TypeIDFloat, TypeIDDouble:
+		return "REAL"
+	case TypeIDVarchar, TypeIDChar, TypeIDUUID, TypeIDDate, TypeIDTime,
+		TypeIDTimestamp, TypeIDTimestampSec, TypeIDTimestampMs, TypeIDTimestampNs, TypeIDTimestampTZ:
+		return "TEXT"
+	case TypeIDBlob:
+		return "BLOB"
+	default:
+		return ""
+	}
+}
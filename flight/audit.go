@@ -0,0 +1,78 @@
+package flight
+
+import (
+	"context"
+	"time"
+
+	"github.com/hugr-lab/airport-go/audit"
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+// SetAuditSink sets the sink that records DDL actions, DML operations and scans.
+// Nil disables auditing.
+func (s *Server) SetAuditSink(sink audit.Sink) {
+	s.auditSink = sink
+}
+
+// audit completes rec with the caller, catalog, transaction and trace of ctx
+// and the outcome err, and passes it to the audit sink, if set.
+// Sink errors are logged.
+func (s *Server) audit(ctx context.Context, rec *audit.Record, err error) {
+	if s.auditSink == nil {
+		return
+	}
+	rec.Time = time.Now().UTC()
+	rec.Catalog = s.CatalogName()
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		rec.Principal = p.ID
+		rec.Roles = p.Roles
+	}
+	rec.TransactionID, _ = catalog.TransactionIDFromContext(ctx)
+	rec.TraceID = TraceIDFromContext(ctx)
+	rec.SessionID = SessionIDFromContext(ctx)
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	if err := s.auditSink.Audit(ctx, rec); err != nil {
+		s.logger.Error("Failed to write audit record",
+			"operation", rec.Operation,
+			"schema", rec.Schema,
+			"table", rec.Table,
+			"error", err,
+		)
+	}
+}
+
+// auditResource returns an audit record of op on res.
+// Details are given as key, value pairs.
+func auditResource(op auth.Operation, res auth.Resource, details ...string) *audit.Record {
+	rec := &audit.Record{
+		Operation: op,
+		Schema:    res.Schema,
+		Table:     res.Table,
+		Function:  res.Function,
+		Columns:   res.Columns,
+	}
+	for i := 0; i+1 < len(details); i += 2 {
+		if rec.Details == nil {
+			rec.Details = make(map[string]string)
+		}
+		rec.Details[details[i]] = details[i+1]
+	}
+	return rec
+}
+
+// auditFilter returns the DuckDB filter JSON data as a SQL expression for
+// audit records. Filters that cannot be parsed are returned as JSON.
+func auditFilter(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	fp, err := filter.Parse(data)
+	if err != nil {
+		return string(data)
+	}
+	return filter.NewDuckDBEncoder(nil).EncodeFilters(fp)
+}
@@ -0,0 +1,117 @@
+package flight
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hugr-lab/airport-go/audit"
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/internal/msgpack"
+)
+
+// recordingSink collects audit records.
+type recordingSink struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (r *recordingSink) Audit(ctx context.Context, rec *audit.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, *rec)
+	return nil
+}
+
+// take returns and clears the recorded records.
+func (r *recordingSink) take() []audit.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	records := r.records
+	r.records = nil
+	return records
+}
+
+func TestAuditDoGet(t *testing.T) {
+	srv, _ := newAuthorizeTestServer(t, func(op auth.Operation, res auth.Resource) error {
+		if res.Table == "secret" {
+			return auth.ErrPermissionDenied
+		}
+		return nil
+	})
+	sink := &recordingSink{}
+	srv.SetAuditSink(sink)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		HeaderTraceID, "query-42",
+	))
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{ID: "alice", Roles: []string{"analyst"}})
+
+	endpoints := requestEndpoints(t, srv, "main", "numbers", nil)
+	if err := srv.DoGet(endpoints[0].GetTicket(), &contextDoGetStream{ctx: ctx}); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	ticket, _ := srv.issueTicket(ctx, &TicketData{Schema: "main", Table: "secret"})
+	if err := srv.DoGet(&flight.Ticket{Ticket: ticket}, &contextDoGetStream{ctx: ctx}); err == nil {
+		t.Fatal("DoGet of denied table should fail")
+	}
+
+	records := sink.take()
+	if len(records) != 2 {
+		t.Fatalf("got %d audit records, want 2", len(records))
+	}
+	scan := records[0]
+	if scan.Operation != auth.OperationScan || scan.Schema != "main" || scan.Table != "numbers" {
+		t.Errorf("scan record = %+v, want scan of main.numbers", scan)
+	}
+	if scan.Principal != "alice" || !slices.Equal(scan.Roles, []string{"analyst"}) {
+		t.Errorf("scan principal = %q %v, want alice [analyst]", scan.Principal, scan.Roles)
+	}
+	if scan.TraceID != "query-42" || scan.Rows != 5 || !slices.Equal(scan.Columns, []string{"id"}) || scan.Error != "" {
+		t.Errorf("scan record = %+v, want trace query-42, 5 rows of [id], no error", scan)
+	}
+	if scan.Time.IsZero() {
+		t.Error("scan record has no time")
+	}
+	if denied := records[1]; denied.Table != "secret" || denied.Error == "" || denied.Rows != 0 {
+		t.Errorf("denied record = %+v, want error and no rows", denied)
+	}
+}
+
+func TestAuditDDL(t *testing.T) {
+	srv, _ := newAuthorizeTestServer(t, func(op auth.Operation, res auth.Resource) error {
+		return auth.ErrPermissionDenied
+	})
+	sink := &recordingSink{}
+	srv.SetAuditSink(sink)
+
+	renameTable, _ := msgpack.Encode(RenameTableParams{Schema: "main", Name: "numbers", NewTableName: "digits"})
+	if err := srv.DoAction(&flight.Action{Type: "rename_table", Body: renameTable}, &fakeDoActionStream{}); err == nil {
+		t.Fatal("denied rename_table should fail")
+	}
+
+	records := sink.take()
+	if len(records) != 1 {
+		t.Fatalf("got %d audit records, want 1", len(records))
+	}
+	got := records[0]
+	if got.Operation != auth.OperationRenameTable || got.Schema != "main" || got.Table != "numbers" {
+		t.Errorf("record = %+v, want rename_table of main.numbers", got)
+	}
+	if got.Details["new_name"] != "digits" || got.Error == "" {
+		t.Errorf("record details = %v, error = %q, want new_name digits and an error", got.Details, got.Error)
+	}
+}
+
+func TestAuditFilter(t *testing.T) {
+	if got := auditFilter(nil); got != "" {
+		t.Errorf("auditFilter(nil) = %q, want empty", got)
+	}
+	if got := auditFilter([]byte("not json")); got != "not json" {
+		t.Errorf("auditFilter(invalid) = %q, want the raw data", got)
+	}
+}
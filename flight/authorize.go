@@ -0,0 +1,175 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/filter"
+)
+
+// SetAuthorizer sets the authorizer consulted before each catalog operation.
+// Objects the caller may not list are hidden from list_schemas and ListFlights.
+// Nil disables operation-level authorization.
+func (s *Server) SetAuthorizer(a auth.Authorizer) {
+	s.authorizer = a
+}
+
+// authorize checks op on res with the authorizer, if set.
+// Returns a PermissionDenied status error on denial.
+func (s *Server) authorize(ctx context.Context, op auth.Operation, res auth.Resource) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	res.Catalog = s.CatalogName()
+	err := s.authorizer.Authorize(ctx, op, res)
+	if err == nil {
+		return nil
+	}
+	s.logger.Debug("Operation denied",
+		"identity", auth.IdentityFromContext(ctx),
+		"operation", op,
+		"schema", res.Schema,
+		"table", res.Table,
+		"function", res.Function,
+		"error", err,
+	)
+	if st, ok := status.FromError(err); ok && st.Code() == codes.PermissionDenied {
+		return err
+	}
+	if errors.Is(err, auth.ErrPermissionDenied) {
+		return status.Errorf(codes.PermissionDenied, "%s on %s denied", op, resourceName(res))
+	}
+	return status.Errorf(codes.PermissionDenied, "%s on %s denied: %v", op, resourceName(res), err)
+}
+
+// scanColumns returns the projected columns of a scan together with the
+// columns its pushed-down filters read, which may lie outside the projection.
+// Without a projection it returns nil: the scan reads every column.
+func (s *Server) scanColumns(projection []string, filters []byte) ([]string, error) {
+	if len(projection) == 0 || len(filters) == 0 || s.authorizer == nil {
+		return projection, nil
+	}
+	doc, err := filter.ParseDocument(filters)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	columns := slices.Clone(projection)
+	for i := range doc.Filters {
+		refs, err := doc.Columns(i)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		}
+		for _, c := range refs {
+			if !slices.Contains(columns, c) {
+				columns = append(columns, c)
+			}
+		}
+	}
+	return columns, nil
+}
+
+// visible reports whether res may be listed in catalog metadata.
+func (s *Server) visible(ctx context.Context, res auth.Resource) bool {
+	if s.authorizer == nil {
+		return true
+	}
+	res.Catalog = s.CatalogName()
+	return s.authorizer.Authorize(ctx, auth.OperationList, res) == nil
+}
+
+// checkVisible returns a NotFound status error if the caller may not list res
+// or its schema, so that hidden objects cannot be looked up by name.
+func (s *Server) checkVisible(ctx context.Context, res auth.Resource) error {
+	if s.visible(ctx, auth.Resource{Schema: res.Schema}) && s.visible(ctx, res) {
+		return nil
+	}
+	return status.Errorf(codes.NotFound, "%s not found", resourceName(res))
+}
+
+// resourceName formats res for error messages.
+func resourceName(res auth.Resource) string {
+	switch {
+	case res.Function != "":
+		return fmt.Sprintf("function %s.%s", res.Schema, res.Function)
+	case res.Table != "":
+		return fmt.Sprintf("table %s.%s", res.Schema, res.Table)
+	default:
+		return fmt.Sprintf("schema %s", res.Schema)
+	}
+}
+
+// columnSchemaNames returns the field names of an IPC serialized column schema.
+// Returns nil if the schema cannot be decoded; the handler reports that later.
+func (s *Server) columnSchemaNames(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	sc, err := flight.DeserializeSchema(data, s.allocator)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, sc.NumFields())
+	for i, f := range sc.Fields() {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// visibleCatalog is a view of a catalog limited to the schemas and tables
+// the caller may list. Used for ListFlights.
+type visibleCatalog struct {
+	catalog.Catalog
+	server *Server
+}
+
+// Schemas implements catalog.Catalog.
+func (c *visibleCatalog) Schemas(ctx context.Context) ([]catalog.Schema, error) {
+	schemas, err := c.Catalog.Schemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]catalog.Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		if c.server.visible(ctx, auth.Resource{Schema: schema.Name()}) {
+			out = append(out, &visibleSchema{Schema: schema, server: c.server})
+		}
+	}
+	return out, nil
+}
+
+// visibleSchema is a view of a schema limited to the tables the caller may list.
+type visibleSchema struct {
+	catalog.Schema
+	server *Server
+}
+
+// Tables implements catalog.Schema.
+func (sc *visibleSchema) Tables(ctx context.Context) ([]catalog.Table, error) {
+	tables, err := sc.Schema.Tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]catalog.Table, 0, len(tables))
+	for _, table := range tables {
+		if sc.server.visible(ctx, auth.Resource{Schema: sc.Name(), Table: table.Name()}) {
+			out = append(out, table)
+		}
+	}
+	return out, nil
+}
+
+// fieldPathColumn returns the top-level column of a struct field path.
+func fieldPathColumn(path []string) []string {
+	if len(path) == 0 {
+		return nil
+	}
+	return path[:1]
+}
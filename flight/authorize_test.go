@@ -0,0 +1,420 @@
+package flight
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/internal/msgpack"
+	"github.com/hugr-lab/airport-go/internal/serialize"
+)
+
+// fakeListFlightsStream collects FlightInfos sent by ListFlights.
+type fakeListFlightsStream struct {
+	grpc.ServerStream
+	infos []*flight.FlightInfo
+}
+
+func (f *fakeListFlightsStream) Context() context.Context { return context.Background() }
+func (f *fakeListFlightsStream) Send(info *flight.FlightInfo) error {
+	f.infos = append(f.infos, info)
+	return nil
+}
+
+// identityFunction is a scalar function returning its int64 argument.
+type identityFunction struct {
+	name string
+}
+
+func (f *identityFunction) Name() string    { return f.name }
+func (f *identityFunction) Comment() string { return "" }
+func (f *identityFunction) Signature() catalog.FunctionSignature {
+	return catalog.FunctionSignature{
+		Parameters: []arrow.DataType{arrow.PrimitiveTypes.Int64},
+		ReturnType: arrow.PrimitiveTypes.Int64,
+	}
+}
+func (f *identityFunction) Execute(ctx context.Context, input arrow.RecordBatch) (arrow.Array, error) {
+	col := input.Column(0)
+	col.Retain()
+	return col, nil
+}
+
+// decompressContent unwraps AirportSerializedCompressedContent ([length, data]).
+func decompressContent(t *testing.T, body []byte) []byte {
+	t.Helper()
+
+	var content []any
+	if err := msgpack.Decode(body, &content); err != nil || len(content) != 2 {
+		t.Fatalf("failed to decode compressed content: %v", err)
+	}
+	d, _ := serialize.NewDecompressor()
+	defer d.Close()
+	data, err := d.Decompress([]byte(content[1].(string)))
+	if err != nil {
+		t.Fatalf("failed to decompress content: %v", err)
+	}
+	return data
+}
+
+// listedObjects returns "schema" and "schema.object" names from list_schemas.
+func listedObjects(t *testing.T, srv *Server) []string {
+	t.Helper()
+
+	stream := &fakeDoActionStream{}
+	if err := srv.handleListSchemas(context.Background(), &flight.Action{Type: "list_schemas"}, stream); err != nil {
+		t.Fatalf("handleListSchemas failed: %v", err)
+	}
+	var root struct {
+		Schemas []struct {
+			Name     string `msgpack:"name"`
+			Contents struct {
+				Serialized string `msgpack:"serialized"`
+			} `msgpack:"contents"`
+		} `msgpack:"schemas"`
+	}
+	if err := msgpack.Decode(decompressContent(t, stream.results[0].GetBody()), &root); err != nil {
+		t.Fatalf("failed to decode catalog root: %v", err)
+	}
+
+	var names []string
+	for _, schema := range root.Schemas {
+		names = append(names, schema.Name)
+		var infos [][]byte
+		if err := msgpack.Decode(decompressContent(t, []byte(schema.Contents.Serialized)), &infos); err != nil {
+			t.Fatalf("failed to decode schema contents: %v", err)
+		}
+		for _, raw := range infos {
+			var info flight.FlightInfo
+			if err := proto.Unmarshal(raw, &info); err != nil {
+				t.Fatalf("failed to unmarshal FlightInfo: %v", err)
+			}
+			names = append(names, schema.Name+"."+info.GetFlightDescriptor().GetPath()[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// listedFlights returns "schema.table" names from ListFlights.
+func listedFlights(t *testing.T, srv *Server) []string {
+	t.Helper()
+
+	stream := &fakeListFlightsStream{}
+	if err := srv.ListFlights(&flight.Criteria{}, stream); err != nil {
+		t.Fatalf("ListFlights failed: %v", err)
+	}
+	d, _ := serialize.NewDecompressor()
+	defer d.Close()
+	data, err := d.Decompress(stream.infos[0].GetEndpoint()[0].GetTicket().GetTicket())
+	if err != nil {
+		t.Fatalf("failed to decompress catalog: %v", err)
+	}
+	reader, err := ipc.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to read catalog: %v", err)
+	}
+	defer reader.Release()
+
+	var names []string
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		schemas := rec.Column(1).(*array.String)
+		tables := rec.Column(2).(*array.String)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			names = append(names, schemas.Value(i)+"."+tables.Value(i))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newAuthorizeTestServer creates a server with visible and hidden schemas,
+// tables and functions, and an authorizer that records its checks.
+func newAuthorizeTestServer(t *testing.T, authorize func(op auth.Operation, res auth.Resource) error) (*Server, *[]auth.Resource) {
+	t.Helper()
+
+	add := &identityFunction{name: "add"}
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 5, 5, nil),
+		"secret":  newSequenceTable("secret", 5, 5, nil),
+	}, []catalog.ScalarFunction{add}, nil, nil, nil)
+	cat.AddSchema("hidden", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 5, 5, nil),
+	}, nil, nil, nil, nil)
+
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+	var checked []auth.Resource
+	srv.SetAuthorizer(auth.AuthorizerFunc(func(ctx context.Context, op auth.Operation, res auth.Resource) error {
+		checked = append(checked, res)
+		return authorize(op, res)
+	}))
+	return srv, &checked
+}
+
+func TestAuthorizerHidesObjects(t *testing.T) {
+	srv, _ := newAuthorizeTestServer(t, func(op auth.Operation, res auth.Resource) error {
+		if op == auth.OperationList && (res.Schema == "hidden" || res.Table == "secret" || res.Function == "add") {
+			return auth.ErrPermissionDenied
+		}
+		return nil
+	})
+
+	if got, want := listedObjects(t, srv), []string{"main", "main.numbers"}; !slices.Equal(got, want) {
+		t.Errorf("list_schemas objects = %v, want %v", got, want)
+	}
+	if got, want := listedFlights(t, srv), []string{"main.numbers"}; !slices.Equal(got, want) {
+		t.Errorf("ListFlights tables = %v, want %v", got, want)
+	}
+
+	srv.SetAuthorizer(nil)
+	if got := listedFlights(t, srv); len(got) != 3 {
+		t.Errorf("ListFlights without authorizer = %v, want 3 tables", got)
+	}
+}
+
+func TestAuthorizerScan(t *testing.T) {
+	srv, checked := newAuthorizeTestServer(t, func(op auth.Operation, res auth.Resource) error {
+		if op == auth.OperationScan && res.Table == "secret" {
+			return auth.ErrPermissionDenied
+		}
+		return nil
+	})
+
+	endpoints := requestEndpoints(t, srv, "main", "numbers", nil)
+	if err := srv.DoGet(endpoints[0].GetTicket(), &fakeDoGetStream{}); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	want := auth.Resource{Schema: "main", Table: "numbers", Columns: []string{"id"}}
+	last := (*checked)[len(*checked)-1]
+	if last.Schema != want.Schema || last.Table != want.Table || !slices.Equal(last.Columns, want.Columns) {
+		t.Errorf("scan checked %+v, want %+v", last, want)
+	}
+
+	if err := endpointsError(srv, "main", "secret", nil); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("endpoints of denied table error = %v, want PermissionDenied", err)
+	}
+	ticket, _ := srv.issueTicket(context.Background(), &TicketData{Schema: "main", Table: "secret"})
+	if err := srv.DoGet(&flight.Ticket{Ticket: ticket}, &fakeDoGetStream{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DoGet of denied table error = %v, want PermissionDenied", err)
+	}
+}
+
+func TestAuthorizerScanProjection(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "ssn", Type: arrow.BinaryTypes.String},
+	}, nil)
+	people := catalog.NewStaticTable("people", "", schema, func(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+		ids := array.NewInt64Builder(memory.DefaultAllocator)
+		defer ids.Release()
+		ssns := array.NewStringBuilder(memory.DefaultAllocator)
+		defer ssns.Release()
+		ids.AppendValues([]int64{1, 2}, nil)
+		ssns.AppendValues([]string{"123-45-6789", "987-65-4321"}, nil)
+
+		id, ssn := ids.NewArray(), ssns.NewArray()
+		defer id.Release()
+		defer ssn.Release()
+		rec := array.NewRecordBatch(schema, []arrow.Array{id, ssn}, 2)
+		defer rec.Release()
+		return array.NewRecordReader(schema, []arrow.RecordBatch{rec})
+	})
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{"people": people}, nil, nil, nil, nil)
+
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+	srv.SetAuthorizer(auth.AuthorizerFunc(func(ctx context.Context, op auth.Operation, res auth.Resource) error {
+		if slices.Contains(res.Columns, "ssn") {
+			return auth.ErrPermissionDenied
+		}
+		return nil
+	}))
+	sink := &recordingSink{}
+	srv.SetAuditSink(sink)
+
+	// A ticket projecting only "id" passes the check but must not stream "ssn"
+	endpoints := requestEndpoints(t, srv, "main", "people", map[string]any{"column_ids": []uint64{0}})
+	stream := &fakeDoGetStream{}
+	if err := srv.DoGet(endpoints[0].GetTicket(), stream); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	for _, rec := range stream.records(t) {
+		if !rec.Schema().Equal(schema) {
+			t.Errorf("streamed schema = %s, want the full table schema", rec.Schema())
+		}
+		if got := rec.Column(0).NullN(); got != 0 {
+			t.Errorf("id has %d nulls, want 0", got)
+		}
+		if ssn := rec.Column(1); ssn.NullN() != ssn.Len() {
+			t.Errorf("denied column ssn streamed %d values", ssn.Len()-ssn.NullN())
+		}
+	}
+	if records := sink.take(); len(records) != 1 || !slices.Equal(records[0].Columns, []string{"id"}) {
+		t.Errorf("audit records = %+v, want one scan of [id]", records)
+	}
+
+	// A filter pushed down with the projection reads its columns as well:
+	// WHERE ssn = '123-45-6789' must not select rows by the denied column
+	ssnFilter := `{"filters": [{"expression_class": "BOUND_COMPARISON", "type": "COMPARE_EQUAL",
+		"left": {"expression_class": "BOUND_COLUMN_REF", "type": "BOUND_COLUMN_REF", "return_type": {"id": "VARCHAR"}, "binding": {"table_index": 0, "column_index": 0}},
+		"right": {"expression_class": "BOUND_CONSTANT", "type": "VALUE_CONSTANT", "value": {"type": {"id": "VARCHAR"}, "is_null": false, "value": "123-45-6789"}}}],
+		"column_binding_names_by_index": ["ssn"]}`
+	params := map[string]any{"column_ids": []uint64{0}, "json_filters": ssnFilter}
+	if err := endpointsError(srv, "main", "people", params); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("endpoints filtering by ssn error = %v, want PermissionDenied", err)
+	}
+	ticket, _ := srv.issueTicket(context.Background(), &TicketData{Schema: "main", Table: "people", Columns: []string{"id"}, Filters: []byte(ssnFilter)})
+	if err := srv.DoGet(&flight.Ticket{Ticket: ticket}, &fakeDoGetStream{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DoGet filtering by ssn error = %v, want PermissionDenied", err)
+	}
+	idFilter := strings.ReplaceAll(strings.ReplaceAll(ssnFilter, `"ssn"`, `"id"`), `"VARCHAR"`, `"BIGINT"`)
+	ticket, _ = srv.issueTicket(context.Background(), &TicketData{Schema: "main", Table: "people", Columns: []string{"id"}, Filters: []byte(idFilter)})
+	if err := srv.DoGet(&flight.Ticket{Ticket: ticket}, &fakeDoGetStream{}); err != nil {
+		t.Errorf("DoGet filtering by id failed: %v", err)
+	}
+
+	// Without a projection all columns are checked
+	if err := endpointsError(srv, "main", "people", nil); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("endpoints of all columns error = %v, want PermissionDenied", err)
+	}
+	ticket, _ = srv.issueTicket(context.Background(), &TicketData{Schema: "main", Table: "people"})
+	if err := srv.DoGet(&flight.Ticket{Ticket: ticket}, &fakeDoGetStream{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DoGet of all columns error = %v, want PermissionDenied", err)
+	}
+}
+
+// endpointsError calls the endpoints action for schema.table and returns its error.
+func endpointsError(srv *Server, schema, table string, params map[string]any) error {
+	desc, _ := proto.Marshal(&flight.FlightDescriptor{Type: flight.DescriptorPATH, Path: []string{schema, table}})
+	if params == nil {
+		params = map[string]any{}
+	}
+	body, _ := msgpack.Encode(map[string]any{"descriptor": string(desc), "parameters": params})
+	return srv.DoAction(&flight.Action{Type: "endpoints", Body: body}, &fakeDoActionStream{})
+}
+
+// flightInfoError calls the flight_info action for schema.table and returns its error.
+func flightInfoError(srv *Server, schema, table string) error {
+	desc, _ := proto.Marshal(&flight.FlightDescriptor{Type: flight.DescriptorPATH, Path: []string{schema, table}})
+	body, _ := msgpack.Encode(map[string]any{"descriptor": string(desc)})
+	return srv.DoAction(&flight.Action{Type: "flight_info", Body: body}, &fakeDoActionStream{})
+}
+
+func TestAuthorizerHidesMetadata(t *testing.T) {
+	srv, _ := newAuthorizeTestServer(t, func(op auth.Operation, res auth.Resource) error {
+		if op == auth.OperationList && (res.Schema == "hidden" || res.Table == "secret") {
+			return auth.ErrPermissionDenied
+		}
+		return nil
+	})
+
+	for _, path := range [][]string{{"main", "secret"}, {"hidden", "numbers"}} {
+		if err := flightInfoError(srv, path[0], path[1]); status.Code(err) != codes.NotFound {
+			t.Errorf("flight_info of %v error = %v, want NotFound", path, err)
+		}
+		if err := endpointsError(srv, path[0], path[1], nil); status.Code(err) != codes.NotFound {
+			t.Errorf("endpoints of %v error = %v, want NotFound", path, err)
+		}
+		_, err := srv.GetFlightInfo(context.Background(), &flight.FlightDescriptor{Type: flight.DescriptorPATH, Path: path})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("GetFlightInfo of %v error = %v, want NotFound", path, err)
+		}
+	}
+
+	if err := flightInfoError(srv, "main", "numbers"); err != nil {
+		t.Errorf("flight_info of visible table: %v", err)
+	}
+	if err := endpointsError(srv, "main", "numbers", nil); err != nil {
+		t.Errorf("endpoints of visible table: %v", err)
+	}
+}
+
+func TestAuthorizerOperations(t *testing.T) {
+	var denied auth.Operation
+	srv, checked := newAuthorizeTestServer(t, func(op auth.Operation, res auth.Resource) error {
+		if op == denied {
+			return auth.ErrPermissionDenied
+		}
+		return nil
+	})
+	ctx := context.Background()
+
+	dropTable, _ := msgpack.Encode(DropTableParams{Type: "table", SchemaName: "main", Name: "numbers"})
+	setNotNull, _ := msgpack.Encode(SetNotNullParams{Schema: "main", Name: "numbers", ColumnName: "id"})
+
+	tests := []struct {
+		op   auth.Operation
+		want auth.Resource
+		call func() error
+	}{
+		{auth.OperationDropTable, auth.Resource{Schema: "main", Table: "numbers"}, func() error {
+			return srv.DoAction(&flight.Action{Type: "drop_table", Body: dropTable}, &fakeDoActionStream{})
+		}},
+		{auth.OperationSetNotNull, auth.Resource{Schema: "main", Table: "numbers", Columns: []string{"id"}}, func() error {
+			return srv.DoAction(&flight.Action{Type: "set_not_null", Body: setNotNull}, &fakeDoActionStream{})
+		}},
+		{auth.OperationDelete, auth.Resource{Schema: "main", Table: "numbers"}, func() error {
+			return srv.handleDoExchangeDelete(ctx, nil, "main", "numbers", false)
+		}},
+		{auth.OperationExecute, auth.Resource{Schema: "main", Function: "add"}, func() error {
+			return srv.handleScalarFunction(ctx, nil, "main", "add")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.op), func(t *testing.T) {
+			denied = tt.op
+			*checked = nil
+			if err := tt.call(); status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("error = %v, want PermissionDenied", err)
+			}
+			got := (*checked)[len(*checked)-1]
+			if got.Schema != tt.want.Schema || got.Table != tt.want.Table || got.Function != tt.want.Function || !slices.Equal(got.Columns, tt.want.Columns) {
+				t.Errorf("checked %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeErrors(t *testing.T) {
+	srv := NewServer(catalog.NewStaticCatalog(), memory.DefaultAllocator, testLogger(), "")
+	res := auth.Resource{Schema: "main", Table: "t"}
+	if err := srv.authorize(context.Background(), auth.OperationScan, res); err != nil {
+		t.Errorf("authorize without authorizer = %v, want nil", err)
+	}
+
+	for _, denyErr := range []error{auth.ErrPermissionDenied, status.Error(codes.PermissionDenied, "custom")} {
+		srv.SetAuthorizer(auth.AuthorizerFunc(func(context.Context, auth.Operation, auth.Resource) error { return denyErr }))
+		if err := srv.authorize(context.Background(), auth.OperationScan, res); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("authorize with %v = %v, want PermissionDenied", denyErr, err)
+		}
+	}
+}
+
+func TestDMLErrorCodes(t *testing.T) {
+	denied := fmt.Errorf("%w: rowid 1 is not visible", auth.ErrPermissionDenied)
+	if err := dmlError("DELETE", denied); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("dmlError(denied) = %v, want PermissionDenied", err)
+	}
+	if err := dmlError("DELETE", errors.New("disk full")); status.Code(err) != codes.Internal {
+		t.Errorf("dmlError(other) = %v, want Internal", err)
+	}
+}
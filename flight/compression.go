@@ -0,0 +1,48 @@
+package flight
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+)
+
+// Compression selects the Arrow IPC body compression used for result streams.
+type Compression string
+
+const (
+	// CompressionNone sends record batch buffers uncompressed.
+	CompressionNone Compression = ""
+	// CompressionLZ4 compresses record batch buffers with LZ4_FRAME.
+	CompressionLZ4 Compression = "lz4"
+	// CompressionZSTD compresses record batch buffers with ZSTD.
+	CompressionZSTD Compression = "zstd"
+)
+
+// Validate returns an error if c is not a supported compression codec.
+func (c Compression) Validate() error {
+	switch c {
+	case CompressionNone, CompressionLZ4, CompressionZSTD:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression %q (expected %q or %q)", string(c), CompressionLZ4, CompressionZSTD)
+	}
+}
+
+// SetCompression sets the Arrow IPC body compression for DoGet streams,
+// scalar and table function exchanges and DML RETURNING streams.
+// CompressionNone (the default) disables compression.
+func (s *Server) SetCompression(c Compression) {
+	s.compression = c
+}
+
+// writerOptions returns the IPC writer options for result streams.
+func (s *Server) writerOptions() []ipc.Option {
+	switch s.compression {
+	case CompressionLZ4:
+		return []ipc.Option{ipc.WithLZ4()}
+	case CompressionZSTD:
+		return []ipc.Option{ipc.WithZstd()}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package flight
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+func TestCompressionValidate(t *testing.T) {
+	for _, c := range []Compression{CompressionNone, CompressionLZ4, CompressionZSTD} {
+		if err := c.Validate(); err != nil {
+			t.Errorf("Validate(%q) returned error: %v", c, err)
+		}
+	}
+	if err := Compression("snappy").Validate(); err == nil {
+		t.Error("expected error for unsupported compression")
+	}
+}
+
+func TestDoGetCompression(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "text", Type: arrow.BinaryTypes.String}}, nil)
+	value := strings.Repeat("airport ", 64)
+	scan := func(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+		b := array.NewStringBuilder(memory.DefaultAllocator)
+		defer b.Release()
+		for range 1000 {
+			b.Append(value)
+		}
+		col := b.NewArray()
+		defer col.Release()
+		rec := array.NewRecordBatch(schema, []arrow.Array{col}, int64(col.Len()))
+		defer rec.Release()
+		return array.NewRecordReader(schema, []arrow.RecordBatch{rec})
+	}
+
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"docs": catalog.NewStaticTable("docs", "", schema, scan),
+	}, nil, nil, nil, nil)
+
+	bodySize := func(t *testing.T, c Compression) int {
+		t.Helper()
+
+		srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+		srv.SetCompression(c)
+		endpoints := requestEndpoints(t, srv, "main", "docs", nil)
+
+		stream := &fakeDoGetStream{}
+		if err := srv.DoGet(endpoints[0].GetTicket(), stream); err != nil {
+			t.Fatalf("DoGet failed: %v", err)
+		}
+		size := 0
+		for _, d := range stream.data {
+			size += len(d.GetDataBody())
+		}
+
+		records := stream.records(t)
+		if len(records) != 1 || records[0].NumRows() != 1000 {
+			t.Fatalf("expected one batch of 1000 rows, got %d batches", len(records))
+		}
+		if got := records[0].Column(0).(*array.String).Value(999); got != value {
+			t.Fatalf("unexpected value after decompression: %q", got)
+		}
+		return size
+	}
+
+	plain := bodySize(t, CompressionNone)
+	for _, c := range []Compression{CompressionLZ4, CompressionZSTD} {
+		t.Run(string(c), func(t *testing.T) {
+			if size := bodySize(t, c); size >= plain/10 {
+				t.Errorf("expected %s body to be much smaller than %d bytes, got %d", c, plain, size)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/flight"
@@ -12,9 +13,11 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
 	"github.com/hugr-lab/airport-go/internal/msgpack"
 	"github.com/hugr-lab/airport-go/internal/serialize"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
 // DoAction executes server actions including scalar function invocation.
@@ -23,6 +26,7 @@ import (
 //   - Table function schema discovery
 //   - Custom server commands
 func (s *Server) DoAction(action *flight.Action, stream flight.FlightService_DoActionServer) error {
+	start := time.Now()
 	ctx := EnrichContextMetadata(stream.Context())
 
 	s.logger.Debug("DoAction called",
@@ -32,70 +36,89 @@ func (s *Server) DoAction(action *flight.Action, stream flight.FlightService_DoA
 
 	actionType := action.GetType()
 
+	ctx, span := s.startRPCSpan(ctx, "DoAction "+actionType, attrAction.String(actionType))
+	known, err := s.doAction(ctx, actionType, action, stream)
+	endSpan(span, err)
+	if !known {
+		actionType = metrics.UnknownName
+	}
+	s.observeRPC(metrics.MethodDoAction, actionType, start, err)
+	return err
+}
+
+// doAction dispatches an action to its handler.
+// Returns false if the action type is unknown.
+func (s *Server) doAction(ctx context.Context, actionType string, action *flight.Action, stream flight.FlightService_DoActionServer) (bool, error) {
 	switch actionType {
 	case "table_function_flight_info":
-		return s.handleTableFunctionFlightInfo(ctx, action, stream)
+		return true, s.handleTableFunctionFlightInfo(ctx, action, stream)
 
 	// flight_info is used for time travel queries with AT syntax
 	case "flight_info":
-		return s.handleFlightInfo(ctx, action, stream)
+		return true, s.handleFlightInfo(ctx, action, stream)
 
 	// DDL operations (snake_case per Airport protocol)
 	case "create_schema":
-		return s.handleCreateSchemaAction(ctx, action, stream)
+		return true, s.handleCreateSchemaAction(ctx, action, stream)
 	case "drop_schema":
-		return s.handleDropSchemaAction(ctx, action, stream)
+		return true, s.handleDropSchemaAction(ctx, action, stream)
 	case "create_table":
-		return s.handleCreateTableAction(ctx, action, stream)
+		return true, s.handleCreateTableAction(ctx, action, stream)
 	case "drop_table":
-		return s.handleDropTableAction(ctx, action, stream)
+		return true, s.handleDropTableAction(ctx, action, stream)
 	case "add_column":
-		return s.handleAddColumnAction(ctx, action, stream)
+		return true, s.handleAddColumnAction(ctx, action, stream)
 	case "remove_column":
-		return s.handleRemoveColumnAction(ctx, action, stream)
+		return true, s.handleRemoveColumnAction(ctx, action, stream)
 	case "rename_column":
-		return s.handleRenameColumnAction(ctx, action, stream)
+		return true, s.handleRenameColumnAction(ctx, action, stream)
 	case "rename_table":
-		return s.handleRenameTableAction(ctx, action, stream)
+		return true, s.handleRenameTableAction(ctx, action, stream)
 	case "change_column_type":
-		return s.handleChangeColumnTypeAction(ctx, action, stream)
+		return true, s.handleChangeColumnTypeAction(ctx, action, stream)
 	case "set_not_null":
-		return s.handleSetNotNullAction(ctx, action, stream)
+		return true, s.handleSetNotNullAction(ctx, action, stream)
 	case "drop_not_null":
-		return s.handleDropNotNullAction(ctx, action, stream)
+		return true, s.handleDropNotNullAction(ctx, action, stream)
 	case "set_default":
-		return s.handleSetDefaultAction(ctx, action, stream)
+		return true, s.handleSetDefaultAction(ctx, action, stream)
 	case "add_field":
-		return s.handleAddFieldAction(ctx, action, stream)
+		return true, s.handleAddFieldAction(ctx, action, stream)
 	case "rename_field":
-		return s.handleRenameFieldAction(ctx, action, stream)
+		return true, s.handleRenameFieldAction(ctx, action, stream)
 	case "remove_field":
-		return s.handleRemoveFieldAction(ctx, action, stream)
+		return true, s.handleRemoveFieldAction(ctx, action, stream)
 
 	// Catalog version action
 	case "catalog_version":
-		return s.handleCatalogVersionAction(ctx, action, stream)
+		return true, s.handleCatalogVersionAction(ctx, action, stream)
 
 	// Statistics action
 	case "column_statistics":
-		return s.handleColumnStatisticsAction(ctx, action, stream)
+		return true, s.handleColumnStatisticsAction(ctx, action, stream)
 
 	// Required Airport actions
 	case "list_schemas":
-		return s.handleListSchemas(ctx, action, stream)
+		return true, s.handleListSchemas(ctx, action, stream)
 
 	case "endpoints":
-		return s.handleEndpoints(ctx, action, stream)
+		return true, s.handleEndpoints(ctx, action, stream)
 
 	// Optional Airport actions
 	case "create_transaction":
-		return s.handleCreateTransaction(ctx, action, stream)
+		return true, s.handleCreateTransaction(ctx, action, stream)
 
 	case "get_transaction_status":
-		return s.handleGetTransactionStatus(ctx, action, stream)
+		return true, s.handleGetTransactionStatus(ctx, action, stream)
+
+	case "commit_transaction":
+		return true, s.handleEndTransaction(ctx, action, stream, true)
+
+	case "rollback_transaction":
+		return true, s.handleEndTransaction(ctx, action, stream, false)
 
 	default:
-		return status.Errorf(codes.Unimplemented, "unknown action type: %s", actionType)
+		return false, status.Errorf(codes.Unimplemented, "unknown action type: %s", actionType)
 	}
 }
 
@@ -130,6 +153,11 @@ func (s *Server) handleListSchemas(ctx context.Context, action *flight.Action, s
 	// The MSGPACK_DEFINE_MAP in the docs shows "schema" but the actual C++ uses .name
 	schemaObjects := make([]map[string]any, 0, len(catalogSchemas))
 	for _, schema := range catalogSchemas {
+		// Hide schemas the caller may not list
+		if !s.visible(ctx, auth.Resource{Schema: schema.Name()}) {
+			continue
+		}
+
 		// Generate serialized schema contents with FlightInfo for all tables
 		serializedContents, sha256Hash, err := s.serializeSchemaContents(ctx, schema)
 		if err != nil {
@@ -243,7 +271,7 @@ func (s *Server) compressCatalog(data []byte) ([]byte, error) {
 }
 
 // serializeSchemaContents generates serialized Flight IPC stream containing FlightInfo
-// for all tables and functions in the schema. Returns the serialized bytes and SHA256 hash.
+// for all tables and functions in the schema that the caller may list. Returns the serialized bytes and SHA256 hash.
 // Format: ZStandard compressed msgpack array of serialized Arrow FlightInfo structures
 func (s *Server) serializeSchemaContents(ctx context.Context, schema catalog.Schema) (string, string, error) {
 	// Get all tables in the schema
@@ -284,6 +312,9 @@ func (s *Server) serializeSchemaContents(ctx context.Context, schema catalog.Sch
 
 	// Serialize tables
 	for _, table := range tables {
+		if !s.visible(ctx, auth.Resource{Schema: schema.Name(), Table: table.Name()}) {
+			continue
+		}
 		arrowSchema := table.ArrowSchema(nil)
 		if arrowSchema == nil {
 			continue
@@ -356,6 +387,9 @@ func (s *Server) serializeSchemaContents(ctx context.Context, schema catalog.Sch
 
 	// Serialize table references as type "table" (same as regular tables)
 	for _, ref := range tableRefs {
+		if !s.visible(ctx, auth.Resource{Schema: schema.Name(), Table: ref.Name()}) {
+			continue
+		}
 		arrowSchema := ref.ArrowSchema()
 		if arrowSchema == nil {
 			continue
@@ -414,6 +448,10 @@ func (s *Server) serializeSchemaContents(ctx context.Context, schema catalog.Sch
 
 	// Serialize table functions
 	for _, tableFunc := range tableFunctions {
+		if !s.visible(ctx, auth.Resource{Schema: schema.Name(), Function: tableFunc.Name()}) {
+			continue
+		}
+
 		// Get function signature to build input schema
 		signature := tableFunc.Signature()
 
@@ -490,6 +528,10 @@ func (s *Server) serializeSchemaContents(ctx context.Context, schema catalog.Sch
 
 	// Serialize scalar functions
 	for _, scalarFunc := range scalarFunctions {
+		if !s.visible(ctx, auth.Resource{Schema: schema.Name(), Function: scalarFunc.Name()}) {
+			continue
+		}
+
 		// Get function signature
 		signature := scalarFunc.Signature()
 
@@ -569,6 +611,10 @@ func (s *Server) serializeSchemaContents(ctx context.Context, schema catalog.Sch
 
 	// Serialize table functions (in/out)
 	for _, tableFuncInOut := range tableFunctionsInOut {
+		if !s.visible(ctx, auth.Resource{Schema: schema.Name(), Function: tableFuncInOut.Name()}) {
+			continue
+		}
+
 		// Get function signature
 		signature := tableFuncInOut.Signature()
 
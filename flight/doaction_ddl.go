@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
 	"github.com/hugr-lab/airport-go/internal/msgpack"
 )
@@ -174,7 +175,7 @@ type CatalogVersionResponse struct {
 }
 
 // handleCreateSchema implements the create_schema DoAction handler.
-func (s *Server) handleCreateSchemaAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleCreateSchemaAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params CreateSchemaParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -191,6 +192,11 @@ func (s *Server) handleCreateSchemaAction(ctx context.Context, action *flight.Ac
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.CatalogName)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.CatalogName)
 	}
+	res := auth.Resource{Schema: params.Schema}
+	defer func() { s.audit(ctx, auditResource(auth.OperationCreateSchema, res), err) }()
+	if err := s.authorize(ctx, auth.OperationCreateSchema, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -254,7 +260,7 @@ func (s *Server) handleCreateSchemaAction(ctx context.Context, action *flight.Ac
 }
 
 // handleDropSchema implements the drop_schema DoAction handler.
-func (s *Server) handleDropSchemaAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) error {
+func (s *Server) handleDropSchemaAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params DropSchemaParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -278,6 +284,11 @@ func (s *Server) handleDropSchemaAction(ctx context.Context, action *flight.Acti
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.CatalogName)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.CatalogName)
 	}
+	res := auth.Resource{Schema: schemaName}
+	defer func() { s.audit(ctx, auditResource(auth.OperationDropSchema, res), err) }()
+	if err := s.authorize(ctx, auth.OperationDropSchema, res); err != nil {
+		return err
+	}
 
 	// Check if catalog supports dynamic operations
 	dynCat, ok := s.catalog.(catalog.DynamicCatalog)
@@ -291,7 +302,7 @@ func (s *Server) handleDropSchemaAction(ctx context.Context, action *flight.Acti
 	}
 
 	// Drop the schema
-	err := dynCat.DropSchema(ctx, schemaName, opts)
+	err = dynCat.DropSchema(ctx, schemaName, opts)
 	if errors.Is(err, catalog.ErrNotFound) {
 		return status.Errorf(codes.NotFound, "schema %q not found", schemaName)
 	}
@@ -312,7 +323,7 @@ func (s *Server) handleDropSchemaAction(ctx context.Context, action *flight.Acti
 }
 
 // handleCreateTable implements the create_table DoAction handler.
-func (s *Server) handleCreateTableAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleCreateTableAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params CreateTableParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -331,6 +342,11 @@ func (s *Server) handleCreateTableAction(ctx context.Context, action *flight.Act
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.CatalogName)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.CatalogName)
 	}
+	res := auth.Resource{Schema: params.SchemaName, Table: params.TableName, Columns: s.columnSchemaNames(params.ArrowSchema)}
+	defer func() { s.audit(ctx, auditResource(auth.OperationCreateTable, res), err) }()
+	if err := s.authorize(ctx, auth.OperationCreateTable, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.SchemaName == "" {
@@ -344,7 +360,7 @@ func (s *Server) handleCreateTableAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.SchemaName)
+	schema, err := s.lookupSchema(ctx, params.SchemaName)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.SchemaName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -424,7 +440,7 @@ func (s *Server) handleCreateTableAction(ctx context.Context, action *flight.Act
 }
 
 // handleDropTable implements the drop_table DoAction handler.
-func (s *Server) handleDropTableAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) error {
+func (s *Server) handleDropTableAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params DropTableParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -446,6 +462,11 @@ func (s *Server) handleDropTableAction(ctx context.Context, action *flight.Actio
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.CatalogName)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.CatalogName)
 	}
+	res := auth.Resource{Schema: params.SchemaName, Table: tableName}
+	defer func() { s.audit(ctx, auditResource(auth.OperationDropTable, res), err) }()
+	if err := s.authorize(ctx, auth.OperationDropTable, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.SchemaName == "" {
@@ -456,7 +477,7 @@ func (s *Server) handleDropTableAction(ctx context.Context, action *flight.Actio
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.SchemaName)
+	schema, err := s.lookupSchema(ctx, params.SchemaName)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.SchemaName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -495,7 +516,7 @@ func (s *Server) handleDropTableAction(ctx context.Context, action *flight.Actio
 }
 
 // handleAddColumn implements the add_column DoAction handler.
-func (s *Server) handleAddColumnAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleAddColumnAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params AddColumnParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -514,6 +535,11 @@ func (s *Server) handleAddColumnAction(ctx context.Context, action *flight.Actio
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: s.columnSchemaNames(params.ColumnSchema)}
+	defer func() { s.audit(ctx, auditResource(auth.OperationAddColumn, res), err) }()
+	if err := s.authorize(ctx, auth.OperationAddColumn, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -527,7 +553,7 @@ func (s *Server) handleAddColumnAction(ctx context.Context, action *flight.Actio
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -540,7 +566,7 @@ func (s *Server) handleAddColumnAction(ctx context.Context, action *flight.Actio
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -613,7 +639,7 @@ func (s *Server) handleAddColumnAction(ctx context.Context, action *flight.Actio
 }
 
 // handleRemoveColumn implements the remove_column DoAction handler.
-func (s *Server) handleRemoveColumnAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleRemoveColumnAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params RemoveColumnParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -633,6 +659,11 @@ func (s *Server) handleRemoveColumnAction(ctx context.Context, action *flight.Ac
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: []string{params.RemovedColumn}}
+	defer func() { s.audit(ctx, auditResource(auth.OperationRemoveColumn, res), err) }()
+	if err := s.authorize(ctx, auth.OperationRemoveColumn, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -646,7 +677,7 @@ func (s *Server) handleRemoveColumnAction(ctx context.Context, action *flight.Ac
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -659,7 +690,7 @@ func (s *Server) handleRemoveColumnAction(ctx context.Context, action *flight.Ac
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -721,7 +752,7 @@ func (s *Server) handleRemoveColumnAction(ctx context.Context, action *flight.Ac
 }
 
 // handleRenameColumnAction implements the rename_column DoAction handler.
-func (s *Server) handleRenameColumnAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleRenameColumnAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params RenameColumnParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -741,6 +772,11 @@ func (s *Server) handleRenameColumnAction(ctx context.Context, action *flight.Ac
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: []string{params.OldName}}
+	defer func() { s.audit(ctx, auditResource(auth.OperationRenameColumn, res, "new_name", params.NewName), err) }()
+	if err := s.authorize(ctx, auth.OperationRenameColumn, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -757,7 +793,7 @@ func (s *Server) handleRenameColumnAction(ctx context.Context, action *flight.Ac
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -770,7 +806,7 @@ func (s *Server) handleRenameColumnAction(ctx context.Context, action *flight.Ac
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -834,7 +870,7 @@ func (s *Server) handleRenameColumnAction(ctx context.Context, action *flight.Ac
 }
 
 // handleRenameTableAction implements the rename_table DoAction handler.
-func (s *Server) handleRenameTableAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleRenameTableAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params RenameTableParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -853,6 +889,13 @@ func (s *Server) handleRenameTableAction(ctx context.Context, action *flight.Act
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name}
+	defer func() {
+		s.audit(ctx, auditResource(auth.OperationRenameTable, res, "new_name", params.NewTableName), err)
+	}()
+	if err := s.authorize(ctx, auth.OperationRenameTable, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -866,7 +909,7 @@ func (s *Server) handleRenameTableAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -903,7 +946,7 @@ func (s *Server) handleRenameTableAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up renamed table for FlightInfo response
-	table, err := schema.Table(ctx, params.NewTableName)
+	table, err := s.lookupTable(ctx, schema, params.NewTableName)
 	if err != nil {
 		s.logger.Error("Failed to get renamed table", "table", params.NewTableName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get renamed table: %v", err)
@@ -936,7 +979,7 @@ func (s *Server) handleRenameTableAction(ctx context.Context, action *flight.Act
 }
 
 // handleChangeColumnTypeAction implements the change_column_type DoAction handler.
-func (s *Server) handleChangeColumnTypeAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleChangeColumnTypeAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params ChangeColumnTypeParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -955,6 +998,11 @@ func (s *Server) handleChangeColumnTypeAction(ctx context.Context, action *fligh
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: s.columnSchemaNames(params.ColumnSchema)}
+	defer func() { s.audit(ctx, auditResource(auth.OperationChangeColumnType, res), err) }()
+	if err := s.authorize(ctx, auth.OperationChangeColumnType, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -968,7 +1016,7 @@ func (s *Server) handleChangeColumnTypeAction(ctx context.Context, action *fligh
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -981,7 +1029,7 @@ func (s *Server) handleChangeColumnTypeAction(ctx context.Context, action *fligh
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -1045,7 +1093,7 @@ func (s *Server) handleChangeColumnTypeAction(ctx context.Context, action *fligh
 }
 
 // handleSetNotNullAction implements the set_not_null DoAction handler.
-func (s *Server) handleSetNotNullAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) error {
+func (s *Server) handleSetNotNullAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params SetNotNullParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -1064,6 +1112,11 @@ func (s *Server) handleSetNotNullAction(ctx context.Context, action *flight.Acti
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: []string{params.ColumnName}}
+	defer func() { s.audit(ctx, auditResource(auth.OperationSetNotNull, res), err) }()
+	if err := s.authorize(ctx, auth.OperationSetNotNull, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -1077,7 +1130,7 @@ func (s *Server) handleSetNotNullAction(ctx context.Context, action *flight.Acti
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -1090,7 +1143,7 @@ func (s *Server) handleSetNotNullAction(ctx context.Context, action *flight.Acti
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -1132,7 +1185,7 @@ func (s *Server) handleSetNotNullAction(ctx context.Context, action *flight.Acti
 }
 
 // handleDropNotNullAction implements the drop_not_null DoAction handler.
-func (s *Server) handleDropNotNullAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) error {
+func (s *Server) handleDropNotNullAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params DropNotNullParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -1151,6 +1204,11 @@ func (s *Server) handleDropNotNullAction(ctx context.Context, action *flight.Act
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: []string{params.ColumnName}}
+	defer func() { s.audit(ctx, auditResource(auth.OperationDropNotNull, res), err) }()
+	if err := s.authorize(ctx, auth.OperationDropNotNull, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -1164,7 +1222,7 @@ func (s *Server) handleDropNotNullAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -1177,7 +1235,7 @@ func (s *Server) handleDropNotNullAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -1219,7 +1277,7 @@ func (s *Server) handleDropNotNullAction(ctx context.Context, action *flight.Act
 }
 
 // handleSetDefaultAction implements the set_default DoAction handler.
-func (s *Server) handleSetDefaultAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) error {
+func (s *Server) handleSetDefaultAction(ctx context.Context, action *flight.Action, _ flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params SetDefaultParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -1239,6 +1297,11 @@ func (s *Server) handleSetDefaultAction(ctx context.Context, action *flight.Acti
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: []string{params.ColumnName}}
+	defer func() { s.audit(ctx, auditResource(auth.OperationSetDefault, res), err) }()
+	if err := s.authorize(ctx, auth.OperationSetDefault, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -1252,7 +1315,7 @@ func (s *Server) handleSetDefaultAction(ctx context.Context, action *flight.Acti
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -1265,7 +1328,7 @@ func (s *Server) handleSetDefaultAction(ctx context.Context, action *flight.Acti
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -1307,7 +1370,7 @@ func (s *Server) handleSetDefaultAction(ctx context.Context, action *flight.Acti
 }
 
 // handleAddFieldAction implements the add_field DoAction handler.
-func (s *Server) handleAddFieldAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleAddFieldAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params AddFieldParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -1326,6 +1389,11 @@ func (s *Server) handleAddFieldAction(ctx context.Context, action *flight.Action
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: fieldPathColumn(params.ColumnPath)}
+	defer func() { s.audit(ctx, auditResource(auth.OperationAddField, res), err) }()
+	if err := s.authorize(ctx, auth.OperationAddField, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -1339,7 +1407,7 @@ func (s *Server) handleAddFieldAction(ctx context.Context, action *flight.Action
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -1352,7 +1420,7 @@ func (s *Server) handleAddFieldAction(ctx context.Context, action *flight.Action
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -1420,7 +1488,7 @@ func (s *Server) handleAddFieldAction(ctx context.Context, action *flight.Action
 }
 
 // handleRenameFieldAction implements the rename_field DoAction handler.
-func (s *Server) handleRenameFieldAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleRenameFieldAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params RenameFieldParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -1440,6 +1508,11 @@ func (s *Server) handleRenameFieldAction(ctx context.Context, action *flight.Act
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: fieldPathColumn(params.ColumnPath)}
+	defer func() { s.audit(ctx, auditResource(auth.OperationRenameField, res, "new_name", params.NewName), err) }()
+	if err := s.authorize(ctx, auth.OperationRenameField, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -1456,7 +1529,7 @@ func (s *Server) handleRenameFieldAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -1469,7 +1542,7 @@ func (s *Server) handleRenameFieldAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -1530,7 +1603,7 @@ func (s *Server) handleRenameFieldAction(ctx context.Context, action *flight.Act
 }
 
 // handleRemoveFieldAction implements the remove_field DoAction handler.
-func (s *Server) handleRemoveFieldAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+func (s *Server) handleRemoveFieldAction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer) (err error) {
 	// Decode msgpack parameters
 	var params RemoveFieldParams
 	if err := msgpack.Decode(action.GetBody(), &params); err != nil {
@@ -1548,6 +1621,11 @@ func (s *Server) handleRemoveFieldAction(ctx context.Context, action *flight.Act
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", params.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), params.Catalog)
 	}
+	res := auth.Resource{Schema: params.Schema, Table: params.Name, Columns: fieldPathColumn(params.ColumnPath)}
+	defer func() { s.audit(ctx, auditResource(auth.OperationRemoveField, res), err) }()
+	if err := s.authorize(ctx, auth.OperationRemoveField, res); err != nil {
+		return err
+	}
 
 	// Validate required fields
 	if params.Schema == "" {
@@ -1561,7 +1639,7 @@ func (s *Server) handleRemoveFieldAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, params.Schema)
+	schema, err := s.lookupSchema(ctx, params.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", params.Schema, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -1574,7 +1652,7 @@ func (s *Server) handleRemoveFieldAction(ctx context.Context, action *flight.Act
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, params.Name)
+	table, err := s.lookupTable(ctx, schema, params.Name)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", params.Name, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -1704,7 +1782,7 @@ func (s *Server) handleCatalogVersionAction(ctx context.Context, action *flight.
 }
 
 // buildTableFlightInfo creates a FlightInfo for a table.
-func (s *Server) buildTableFlightInfo(_ context.Context, schema catalog.Schema, table catalog.Table) (*flight.FlightInfo, error) {
+func (s *Server) buildTableFlightInfo(ctx context.Context, schema catalog.Schema, table catalog.Table) (*flight.FlightInfo, error) {
 	arrowSchema := table.ArrowSchema(nil)
 	if arrowSchema == nil {
 		return nil, errors.New("table has no schema")
@@ -1735,7 +1813,7 @@ func (s *Server) buildTableFlightInfo(_ context.Context, schema catalog.Schema,
 	}
 
 	// Generate ticket for this table
-	ticket, err := EncodeTableTicket(s.CatalogName(), schema.Name(), table.Name())
+	ticket, err := s.issueTicket(ctx, &TicketData{Schema: schema.Name(), Table: table.Name()})
 	if err != nil {
 		return nil, err
 	}
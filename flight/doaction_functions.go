@@ -3,7 +3,6 @@ package flight
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/apache/arrow-go/v18/arrow"
@@ -24,15 +23,16 @@ import (
 // Request format (MessagePack):
 // buildTableFunctionFlightInfo creates a FlightInfo response for a table function.
 // This is the common response structure for both regular and in/out table functions.
-func (s *Server) buildTableFunctionFlightInfo(schemaName, functionName string, params []byte, funcSchema *arrow.Schema) (*flight.Result, error) {
+func (s *Server) buildTableFunctionFlightInfo(ctx context.Context, schemaName, functionName string, params []byte, funcSchema *arrow.Schema) (*flight.Result, error) {
 	// Create ticket with function call information
 	ticketData := TicketData{
 		Schema:         schemaName,
 		TableFunction:  functionName,
 		FunctionParams: params,
 	}
+	ticketData.TransactionID, _ = catalog.TransactionIDFromContext(ctx)
 
-	ticketBytes, err := json.Marshal(ticketData)
+	ticketBytes, err := s.issueTicket(ctx, &ticketData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode ticket: %w", err)
 	}
@@ -90,7 +90,7 @@ func (s *Server) handleRegularTableFunction(ctx context.Context, schemaName, fun
 		return status.Errorf(codes.Internal, "failed to get function schema: %v", err)
 	}
 
-	result, err := s.buildTableFunctionFlightInfo(schemaName, functionName, paramsRaw, funcSchema)
+	result, err := s.buildTableFunctionFlightInfo(ctx, schemaName, functionName, paramsRaw, funcSchema)
 	if err != nil {
 		return status.Errorf(codes.Internal, "%v", err)
 	}
@@ -138,7 +138,7 @@ func (s *Server) handleInOutTableFunction(ctx context.Context, schemaName, funct
 		return status.Errorf(codes.Internal, "failed to get function schema: %v", err)
 	}
 
-	result, err := s.buildTableFunctionFlightInfo(schemaName, functionName, paramsRaw, funcSchema)
+	result, err := s.buildTableFunctionFlightInfo(ctx, schemaName, functionName, paramsRaw, funcSchema)
 	if err != nil {
 		return status.Errorf(codes.Internal, "%v", err)
 	}
@@ -198,7 +198,7 @@ func (s *Server) handleTableFunctionFlightInfo(ctx context.Context, action *flig
 	)
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
 	}
@@ -3,7 +3,6 @@ package flight
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/apache/arrow-go/v18/arrow"
@@ -14,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
 	"github.com/hugr-lab/airport-go/internal/msgpack"
 )
@@ -62,14 +62,19 @@ func (s *Server) handleFlightInfo(ctx context.Context, action *flight.Action, st
 	schemaName := path[0]
 	tableOrFunctionName := path[1]
 
+	// Tables and table refs hidden from list_schemas are not found
+	if err := s.checkVisible(ctx, auth.Resource{Schema: schemaName, Table: tableOrFunctionName}); err != nil {
+		return err
+	}
+
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		return status.Errorf(codes.NotFound, "schema not found: %s", schemaName)
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, tableOrFunctionName)
+	table, err := s.lookupTable(ctx, schema, tableOrFunctionName)
 	if err != nil {
 		return status.Errorf(codes.NotFound, "table not found: %s.%s", schemaName, tableOrFunctionName)
 	}
@@ -131,7 +136,8 @@ func (s *Server) handleFlightInfo(ctx context.Context, action *flight.Action, st
 		Schema: schemaName,
 		Table:  tableOrFunctionName,
 	}
-	ticket, err := json.Marshal(ticketData)
+	ticketData.TransactionID, _ = catalog.TransactionIDFromContext(ctx)
+	ticket, err := s.issueTicket(ctx, &ticketData)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to encode ticket: %v", err)
 	}
@@ -201,6 +207,7 @@ type endpointsRequest struct {
 		TableFunctionInputSchema string
 		AtUnit                   string
 		AtValue                  string
+		Limit                    int64
 	}
 }
 
@@ -224,6 +231,7 @@ func (s *Server) handleEndpoints(ctx context.Context, action *flight.Action, str
 		"column_count", len(request.Parameters.ColumnIDs),
 		"at_unit", request.Parameters.AtUnit,
 		"at_value", request.Parameters.AtValue,
+		"limit", request.Parameters.Limit,
 	)
 
 	if desc.GetType() != flight.DescriptorPATH || len(desc.GetPath()) != 2 {
@@ -235,15 +243,26 @@ func (s *Server) handleEndpoints(ctx context.Context, action *flight.Action, str
 	tableOrFunctionName := desc.GetPath()[1]
 
 	if request.Parameters.TableFunctionParameters != "" {
+		res := auth.Resource{Schema: schemaName, Function: tableOrFunctionName}
+		if err := s.checkVisible(ctx, res); err != nil {
+			return err
+		}
+		if err := s.authorize(ctx, auth.OperationExecute, res); err != nil {
+			return err
+		}
 		ticket, err := s.createTableFunctionTicket(ctx, schemaName, tableOrFunctionName, request)
 		if err != nil {
 			return err
 		}
-		return s.sendEndpointResponse(schemaName, tableOrFunctionName, ticket, stream)
+		return s.sendEndpointResponse(schemaName, tableOrFunctionName, [][]byte{ticket}, stream)
+	}
+
+	if err := s.checkVisible(ctx, auth.Resource{Schema: schemaName, Table: tableOrFunctionName}); err != nil {
+		return err
 	}
 
 	// Check if this is a table reference
-	schemaObj, err := s.catalog.Schema(ctx, schemaName)
+	schemaObj, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		return status.Errorf(codes.NotFound, "schema not found: %s", schemaName)
 	}
@@ -258,12 +277,12 @@ func (s *Server) handleEndpoints(ctx context.Context, action *flight.Action, str
 	}
 
 	// Regular table path
-	ticket, err := s.createTableTicket(ctx, schemaName, tableOrFunctionName, request)
+	tickets, err := s.createTableTickets(ctx, schemaObj, schemaName, tableOrFunctionName, request)
 	if err != nil {
 		return err
 	}
 
-	return s.sendEndpointResponse(schemaName, tableOrFunctionName, ticket, stream)
+	return s.sendEndpointResponse(schemaName, tableOrFunctionName, tickets, stream)
 }
 
 // decodeEndpointsRequest decodes the msgpack request body.
@@ -277,6 +296,7 @@ func (s *Server) decodeEndpointsRequest(action *flight.Action) (*endpointsReques
 			TableFunctionInputSchema string   `msgpack:"table_function_input_schema"`
 			AtUnit                   string   `msgpack:"at_unit"`
 			AtValue                  string   `msgpack:"at_value"`
+			Limit                    int64    `msgpack:"limit"`
 		} `msgpack:"parameters"`
 	}
 
@@ -296,6 +316,7 @@ func (s *Server) decodeEndpointsRequest(action *flight.Action) (*endpointsReques
 	req.Parameters.TableFunctionInputSchema = raw.Parameters.TableFunctionInputSchema
 	req.Parameters.AtUnit = raw.Parameters.AtUnit
 	req.Parameters.AtValue = raw.Parameters.AtValue
+	req.Parameters.Limit = raw.Parameters.Limit
 	return req, nil
 }
 
@@ -317,7 +338,9 @@ func (s *Server) createTableFunctionTicket(ctx context.Context, schemaName, func
 		Schema:         schemaName,
 		TableFunction:  functionName,
 		FunctionParams: paramBytes,
+		Limit:          request.Parameters.Limit,
 	}
+	ticketData.TransactionID, _ = catalog.TransactionIDFromContext(ctx)
 	s.logger.Debug("Parsing table function parameters",
 		"param_size", len(paramBytes),
 		"first_bytes", fmt.Sprintf("%x", paramBytes[:min(20, len(paramBytes))]),
@@ -329,7 +352,7 @@ func (s *Server) createTableFunctionTicket(ctx context.Context, schemaName, func
 
 	ticketData.Columns = s.resolveTableFunctionColumns(ctx, schemaName, functionName, params, request.Parameters.ColumnIDs)
 
-	ticket, err := json.Marshal(ticketData)
+	ticket, err := s.issueTicket(ctx, &ticketData)
 	if err != nil {
 		s.logger.Error("Failed to encode table function ticket", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to encode ticket: %v", err)
@@ -375,7 +398,7 @@ func (s *Server) resolveTableFunctionColumns(ctx context.Context, schemaName, fu
 		return nil
 	}
 
-	schemaObj, err := s.catalog.Schema(ctx, schemaName)
+	schemaObj, err := s.lookupSchema(ctx, schemaName)
 	if err != nil || schemaObj == nil {
 		return nil
 	}
@@ -407,17 +430,35 @@ func (s *Server) resolveTableFunctionColumns(ctx context.Context, schemaName, fu
 	return nil
 }
 
-// createTableTicket creates a ticket for regular table scan.
-func (s *Server) createTableTicket(ctx context.Context, schemaName, tableName string, request *endpointsRequest) ([]byte, error) {
+// createTableTickets creates the tickets for a regular table scan.
+// Tables implementing catalog.PartitionedTable get one ticket per partition;
+// all other tables get a single ticket.
+func (s *Server) createTableTickets(ctx context.Context, schemaObj catalog.Schema, schemaName, tableName string, request *endpointsRequest) ([][]byte, error) {
 	ticketData := TicketData{
 		Catalog: s.CatalogName(),
 		Schema:  schemaName,
 		Table:   tableName,
 		Filters: []byte(request.Parameters.JsonFilters),
+		Limit:   request.Parameters.Limit,
 	}
+	ticketData.TransactionID, _ = catalog.TransactionIDFromContext(ctx)
 
 	ticketData.Columns = s.resolveTableColumns(ctx, schemaName, tableName, request.Parameters.ColumnIDs)
 
+	// Check read access to the projected columns (all columns if no projection)
+	// and to the columns read by the pushed-down filters before issuing a
+	// ticket; DoGet checks the ticket again
+	columns, err := s.scanColumns(ticketData.Columns, ticketData.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		columns = s.tableColumnNames(ctx, schemaObj, tableName)
+	}
+	if err := s.authorize(ctx, auth.OperationScan, auth.Resource{Schema: schemaName, Table: tableName, Columns: columns}); err != nil {
+		return nil, err
+	}
+
 	if request.Parameters.AtUnit != "" && request.Parameters.AtValue != "" {
 		ticketData.TimePointUnit = normalizeTimeUnit(request.Parameters.AtUnit)
 		ticketData.TimePointValue = request.Parameters.AtValue
@@ -429,12 +470,59 @@ func (s *Server) createTableTicket(ctx context.Context, schemaName, tableName st
 		)
 	}
 
-	ticket, err := json.Marshal(ticketData)
+	partitions, err := s.tablePartitions(ctx, schemaObj, &ticketData)
 	if err != nil {
-		s.logger.Error("Failed to encode ticket", "error", err)
-		return nil, status.Errorf(codes.Internal, "failed to encode ticket: %v", err)
+		return nil, err
 	}
-	return ticket, nil
+	if len(partitions) == 0 {
+		partitions = [][]byte{nil}
+	}
+
+	tickets := make([][]byte, 0, len(partitions))
+	for _, partition := range partitions {
+		ticketData.Partition = partition
+		ticket, err := s.issueTicket(ctx, &ticketData)
+		if err != nil {
+			s.logger.Error("Failed to encode ticket", "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to encode ticket: %v", err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// tablePartitions returns the scan partitions of a table.
+// Returns nil if the table does not implement catalog.PartitionedTable.
+func (s *Server) tablePartitions(ctx context.Context, schemaObj catalog.Schema, ticketData *TicketData) ([][]byte, error) {
+	if schemaObj == nil {
+		return nil, nil
+	}
+	table, err := s.lookupTable(ctx, schemaObj, ticketData.Table)
+	if err != nil || table == nil {
+		// Missing tables are reported by DoGet
+		return nil, nil
+	}
+	partitioned, ok := table.(catalog.PartitionedTable)
+	if !ok {
+		return nil, nil
+	}
+
+	partitions, err := partitioned.Partitions(ctx, ticketData.ToScanOptions())
+	if err != nil {
+		s.logger.Error("Failed to get table partitions",
+			"schema", ticketData.Schema,
+			"table", ticketData.Table,
+			"error", err,
+		)
+		return nil, status.Errorf(codes.Internal, "failed to get table partitions: %v", err)
+	}
+
+	s.logger.Debug("Partitioned table scan",
+		"schema", ticketData.Schema,
+		"table", ticketData.Table,
+		"partitions", len(partitions),
+	)
+	return partitions, nil
 }
 
 // resolveTableColumns maps column IDs to column names for a table.
@@ -443,12 +531,12 @@ func (s *Server) resolveTableColumns(ctx context.Context, schemaName, tableName
 		return nil
 	}
 
-	schemaObj, err := s.catalog.Schema(ctx, schemaName)
+	schemaObj, err := s.lookupSchema(ctx, schemaName)
 	if err != nil || schemaObj == nil {
 		return nil
 	}
 
-	table, err := schemaObj.Table(ctx, tableName)
+	table, err := s.lookupTable(ctx, schemaObj, tableName)
 	if err != nil || table == nil {
 		return nil
 	}
@@ -470,6 +558,18 @@ func (s *Server) resolveTableColumns(ctx context.Context, schemaName, tableName
 	return columns
 }
 
+// tableColumnNames returns the column names of a table, or nil if it is not found.
+func (s *Server) tableColumnNames(ctx context.Context, schemaObj catalog.Schema, tableName string) []string {
+	if schemaObj == nil {
+		return nil
+	}
+	table, err := s.lookupTable(ctx, schemaObj, tableName)
+	if err != nil || table == nil {
+		return nil
+	}
+	return schemaColumnNames(table.ArrowSchema(nil))
+}
+
 // mapColumnIDsToNames converts column IDs to column names using the schema.
 // Skips invalid IDs (e.g., max uint64 used for DML operations).
 func mapColumnIDsToNames(schema *arrow.Schema, columnIDs []uint64) []string {
@@ -506,27 +606,30 @@ func normalizeTimeUnit(unit string) string {
 }
 
 // sendEndpointResponse sends the endpoint response to the client.
-func (s *Server) sendEndpointResponse(schemaName, tableName string, ticket []byte, stream flight.FlightService_DoActionServer) error {
-	// Create FlightEndpoint with location
-	endpoint := &flight.FlightEndpoint{
-		Ticket: &flight.Ticket{
-			Ticket: ticket,
-		},
-	}
+// One FlightEndpoint is returned per ticket.
+func (s *Server) sendEndpointResponse(schemaName, tableName string, tickets [][]byte, stream flight.FlightService_DoActionServer) error {
+	// Return as vector of strings (each string is a serialized FlightEndpoint)
+	endpoints := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		// Create FlightEndpoint with location
+		endpoint := &flight.FlightEndpoint{
+			Ticket: &flight.Ticket{
+				Ticket: ticket,
+			},
+		}
 
-	// Add location if server address is configured
-	endpoint.Location = []*flight.Location{{Uri: s.address}}
+		// Add location if server address is configured
+		endpoint.Location = []*flight.Location{{Uri: s.address}}
 
-	// Serialize FlightEndpoint as protobuf
-	endpointBytes, err := proto.Marshal(endpoint)
-	if err != nil {
-		s.logger.Error("Failed to marshal endpoint", "error", err)
-		return status.Errorf(codes.Internal, "failed to marshal endpoint: %v", err)
+		// Serialize FlightEndpoint as protobuf
+		endpointBytes, err := proto.Marshal(endpoint)
+		if err != nil {
+			s.logger.Error("Failed to marshal endpoint", "error", err)
+			return status.Errorf(codes.Internal, "failed to marshal endpoint: %v", err)
+		}
+		endpoints = append(endpoints, string(endpointBytes))
 	}
 
-	// Return as vector of strings (each string is a serialized FlightEndpoint)
-	endpoints := []string{string(endpointBytes)}
-
 	responseBody, err := msgpack.Encode(endpoints)
 	if err != nil {
 		s.logger.Error("Failed to encode endpoints", "error", err)
@@ -545,7 +648,7 @@ func (s *Server) sendEndpointResponse(schemaName, tableName string, ticket []byt
 	s.logger.Debug("handleEndpoints completed",
 		"schema", schemaName,
 		"table", tableName,
-		"endpoint_count", 1,
+		"endpoint_count", len(endpoints),
 	)
 	return nil
 }
@@ -734,6 +837,7 @@ func (s *Server) handleCreateTransaction(ctx context.Context, action *flight.Act
 		s.logger.Error("Failed to begin transaction", "error", err)
 		return status.Errorf(codes.Internal, "failed to create transaction: %v", err)
 	}
+	s.trackTransaction(txID)
 
 	response := map[string]any{
 		"identifier": txID,
@@ -815,6 +919,69 @@ func (s *Server) handleGetTransactionStatus(ctx context.Context, action *flight.
 	return nil
 }
 
+// handleEndTransaction commits or rolls back a transaction at the client's request.
+// This is an optional Airport action for transaction management; the response
+// carries the final transaction status.
+func (s *Server) handleEndTransaction(ctx context.Context, action *flight.Action, stream flight.FlightService_DoActionServer, commit bool) error {
+	// Decode parameters
+	var params struct {
+		TransactionID string `msgpack:"transaction_id"`
+	}
+
+	if len(action.GetBody()) > 0 {
+		if err := msgpack.Decode(action.GetBody(), &params); err != nil {
+			s.logger.Error("Failed to decode transaction parameters", "action", action.GetType(), "error", err)
+			return status.Errorf(codes.InvalidArgument, "invalid parameters: %v", err)
+		}
+	}
+	if params.TransactionID == "" {
+		return status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+
+	s.logger.Debug("handleEndTransaction called", "tx_id", params.TransactionID, "commit", commit)
+
+	if s.txManager == nil {
+		return status.Error(codes.FailedPrecondition, "transactions are not supported")
+	}
+
+	state, exists := s.txManager.GetTransactionStatus(ctx, params.TransactionID)
+	switch {
+	case !exists:
+		return status.Errorf(codes.NotFound, "transaction %q not found", params.TransactionID)
+	case commit && state == catalog.TransactionAborted:
+		return status.Errorf(codes.FailedPrecondition, "transaction %q was rolled back", params.TransactionID)
+	case !commit && state == catalog.TransactionCommitted:
+		return status.Errorf(codes.FailedPrecondition, "transaction %q is already committed", params.TransactionID)
+	}
+
+	if err := s.endTransaction(ctx, params.TransactionID, commit); err != nil {
+		s.logger.Error("Failed to end transaction", "tx_id", params.TransactionID, "commit", commit, "error", err)
+		if commit {
+			return status.Errorf(codes.Aborted, "failed to commit transaction: %v", err)
+		}
+		return status.Errorf(codes.Internal, "failed to roll back transaction: %v", err)
+	}
+	state, _ = s.txManager.GetTransactionStatus(ctx, params.TransactionID)
+
+	response := map[string]any{
+		"status": string(state),
+	}
+
+	responseBody, err := msgpack.Encode(response)
+	if err != nil {
+		s.logger.Error("Failed to encode transaction status response", "error", err)
+		return status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+
+	if err := stream.Send(&flight.Result{Body: responseBody}); err != nil {
+		s.logger.Error("Failed to send transaction status result", "error", err)
+		return status.Errorf(codes.Internal, "failed to send result: %v", err)
+	}
+
+	s.logger.Debug("handleEndTransaction completed", "tx_id", params.TransactionID, "status", state)
+	return nil
+}
+
 // extractScalarValue extracts a scalar value from an Arrow array at the given index.
 // This is used to convert Arrow array values to Go any values for function parameters.
 func extractScalarValue(arr arrow.Array, idx int) any {
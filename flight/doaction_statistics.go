@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
 	"github.com/hugr-lab/airport-go/internal/msgpack"
 )
@@ -65,9 +66,12 @@ func (s *Server) handleColumnStatisticsAction(ctx context.Context, action *fligh
 
 	schemaName := descriptor.Path[0]
 	tableName := descriptor.Path[1]
+	if err := s.authorize(ctx, auth.OperationScan, auth.Resource{Schema: schemaName, Table: tableName, Columns: []string{params.ColumnName}}); err != nil {
+		return err
+	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", schemaName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -77,7 +81,7 @@ func (s *Server) handleColumnStatisticsAction(ctx context.Context, action *fligh
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, tableName)
+	table, err := s.lookupTable(ctx, schema, tableName)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", tableName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
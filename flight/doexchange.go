@@ -6,17 +6,21 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/flight"
 	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
 // DoExchange implements bidirectional streaming for function execution and DML operations.
@@ -53,7 +57,24 @@ import (
 // - UPDATE: https://airport.query.farm/table_update.html
 // - DELETE: https://airport.query.farm/table_delete.html
 func (s *Server) DoExchange(stream flight.FlightService_DoExchangeServer) error {
+	start := time.Now()
+	defer s.trackStream(metrics.MethodDoExchange)()
+
 	ctx := EnrichContextMetadata(stream.Context())
+	ctx, span := s.startRPCSpan(ctx, "DoExchange")
+	err := s.doExchange(ctx, span, stream)
+	endSpan(span, err)
+
+	var op string
+	if values := metadata.ValueFromIncomingContext(ctx, "airport-operation"); len(values) > 0 {
+		op = values[0]
+	}
+	s.observeRPC(metrics.MethodDoExchange, exchangeOperationName(op), start, err)
+	return err
+}
+
+// doExchange routes a DoExchange call to the handler of its operation.
+func (s *Server) doExchange(ctx context.Context, span trace.Span, stream flight.FlightService_DoExchangeServer) error {
 
 	// Extract metadata from gRPC headers
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -68,6 +89,8 @@ func (s *Server) DoExchange(stream flight.FlightService_DoExchangeServer) error
 	}
 
 	opType := operation[0]
+	span.SetName("DoExchange " + opType)
+	span.SetAttributes(attrOperation.String(opType))
 
 	// Get return-chunks header (required for functions, optional for DML)
 	returnChunks := md.Get("return-chunks")
@@ -88,6 +111,14 @@ func (s *Server) DoExchange(stream flight.FlightService_DoExchangeServer) error
 	schemaName := pathParts[0]
 	targetName := pathParts[1] // table name or function name
 
+	span.SetAttributes(attrSchema.String(schemaName))
+	switch opType {
+	case "insert", "update", "delete":
+		span.SetAttributes(attrTable.String(targetName))
+	default:
+		span.SetAttributes(attrFunction.String(targetName))
+	}
+
 	s.logger.Debug("DoExchange requested",
 		"operation", opType,
 		"return_chunks", returnData,
@@ -122,8 +153,12 @@ func (s *Server) DoExchange(stream flight.FlightService_DoExchangeServer) error
 
 // handleScalarFunction processes scalar function execution via DoExchange.
 func (s *Server) handleScalarFunction(ctx context.Context, stream flight.FlightService_DoExchangeServer, schemaName, functionName string) error {
+	if err := s.authorize(ctx, auth.OperationExecute, auth.Resource{Schema: schemaName, Function: functionName}); err != nil {
+		return err
+	}
+
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
 	}
@@ -185,7 +220,7 @@ func (s *Server) handleScalarFunction(ctx context.Context, stream flight.FlightS
 	)
 
 	// Create a record writer for output data
-	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false)
+	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false, s.writerOptions()...)
 	defer writer.Close()
 
 	if err := writer.Begin(); err != nil {
@@ -246,7 +281,9 @@ func (s *Server) handleScalarFunction(ctx context.Context, stream flight.FlightS
 			inLen := in.NumRows()
 
 			// Execute the scalar function (returns arrow.Array)
-			res, err := targetFunc.Execute(ctx, in)
+			execCtx, span := s.startSpan(ctx, "catalog.Execute", attrSchema.String(schemaName), attrFunction.String(functionName), attrRows.Int64(inLen))
+			res, err := targetFunc.Execute(execCtx, in)
+			endSpan(span, err)
 			in.Release()
 
 			if err != nil {
@@ -342,9 +379,12 @@ func (s *Server) handleTableFunction(ctx context.Context, stream flight.FlightSe
 		"schema", schemaName,
 		"function", functionName,
 	)
+	if err := s.authorize(ctx, auth.OperationExecute, auth.Resource{Schema: schemaName, Function: functionName}); err != nil {
+		return err
+	}
 
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
 	}
@@ -430,7 +470,7 @@ func (s *Server) handleTableFunction(ctx context.Context, stream flight.FlightSe
 	)
 	// Create writer for output data and send schema BEFORE executing function
 	// This allows the client to start sending input data
-	writer := NewSchemaWriter(stream, outputSchema, s.allocator, true)
+	writer := NewSchemaWriter(stream, outputSchema, s.allocator, true, s.writerOptions()...)
 	defer writer.Close()
 
 	if err := writer.Begin(); err != nil {
@@ -491,7 +531,9 @@ func (s *Server) handleTableFunction(ctx context.Context, stream flight.FlightSe
 				batch.Release()
 
 				// Execute the table function
-				outputReader, err := targetFunc.Execute(egCtx, params, reader, &catalog.ScanOptions{})
+				execCtx, span := s.startSpan(egCtx, "catalog.Execute", attrSchema.String(schemaName), attrFunction.String(functionName), attrRows.Int64(inLen))
+				outputReader, err := targetFunc.Execute(execCtx, params, reader, &catalog.ScanOptions{})
+				endSpan(span, err)
 				reader.Release()
 				if err != nil {
 					s.logger.Error("Table function execution failed",
@@ -11,10 +11,12 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/flight"
 	"github.com/apache/arrow-go/v18/arrow/ipc"
 	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
 	"github.com/hugr-lab/airport-go/internal/msgpack"
 )
@@ -38,15 +40,18 @@ type AirportChangedFinalMetadata struct {
 // 1. Reader goroutine: Reads input records from client stream, inserts rows if not RETURNING data requested
 // 2. Processor goroutine: Inserts data and produces RETURNING data
 // 3. Writer goroutine: Sends RETURNING data back to client (if requested)
-func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.FlightService_DoExchangeServer, schemaName, tableName string, returnData bool) error {
+func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.FlightService_DoExchangeServer, schemaName, tableName string, returnData bool) (err error) {
 	s.logger.Debug("DoExchange INSERT requested",
 		"schema", schemaName,
 		"table", tableName,
 		"return_data", returnData,
 	)
 
+	rec := auditResource(auth.OperationInsert, auth.Resource{Schema: schemaName, Table: tableName})
+	defer func() { s.audit(ctx, rec, err) }()
+
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", schemaName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -56,7 +61,7 @@ func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.Fligh
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, tableName)
+	table, err := s.lookupTable(ctx, schema, tableName)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", tableName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -82,6 +87,12 @@ func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.Fligh
 	}
 
 	inputSchema := inputReader.Schema()
+	res := auth.Resource{Schema: schemaName, Table: tableName, Columns: schemaColumnNames(inputSchema)}
+	rec.Columns = res.Columns
+	if err := s.authorize(ctx, auth.OperationInsert, res); err != nil {
+		inputReader.Release()
+		return err
+	}
 	s.logger.Debug("Created record reader for INSERT",
 		"input_schema", inputSchema,
 	)
@@ -105,7 +116,7 @@ func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.Fligh
 	}
 
 	// Create a writer to send output schema (required for bidirectional exchange)
-	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false)
+	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false, s.writerOptions()...)
 	defer writer.Close()
 
 	// Send schema to client to acknowledge and enable bidirectional data flow
@@ -137,7 +148,9 @@ func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.Fligh
 			var dmlResult *catalog.DMLResult
 			insertErr := s.withTransaction(egCtx, func(txCtx context.Context) error {
 				var err error
-				dmlResult, err = insertableTable.Insert(txCtx, inputReader, opts)
+				dmlResult, err = s.traceDML(txCtx, "catalog.Insert", table, func(ctx context.Context) (*catalog.DMLResult, error) {
+					return insertableTable.Insert(ctx, inputReader, opts)
+				})
 				return err
 			})
 			if insertErr != nil {
@@ -192,7 +205,9 @@ func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.Fligh
 			var dmlResult *catalog.DMLResult
 			insertErr := s.withTransaction(egCtx, func(txCtx context.Context) error {
 				var err error
-				dmlResult, err = insertableTable.Insert(txCtx, batchReader, opts)
+				dmlResult, err = s.traceDML(txCtx, "catalog.Insert", table, func(ctx context.Context) (*catalog.DMLResult, error) {
+					return insertableTable.Insert(ctx, batchReader, opts)
+				})
 				return err
 			})
 			batch.Release()
@@ -231,9 +246,12 @@ func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.Fligh
 			err = readerErr
 		}
 		s.logger.Error("INSERT pipeline failed", "schema", schemaName, "table", tableName, "error", err)
-		return status.Errorf(codes.Internal, "INSERT failed: %v", err)
+		return dmlError("INSERT", err)
 	}
 
+	trace.SpanFromContext(ctx).SetAttributes(attrRows.Int64(totalRows))
+	s.observeChanged(schemaName, tableName, "insert", totalRows)
+	rec.Rows = totalRows
 	s.logger.Debug("INSERT completed",
 		"schema", schemaName,
 		"table", tableName,
@@ -253,15 +271,18 @@ func (s *Server) handleDoExchangeInsert(ctx context.Context, stream flight.Fligh
 // - Server sends final metadata with total_changed count
 //
 // Implementation uses a bidirectional pipeline with concurrent goroutines.
-func (s *Server) handleDoExchangeUpdate(ctx context.Context, stream flight.FlightService_DoExchangeServer, schemaName, tableName string, returnData bool) error {
+func (s *Server) handleDoExchangeUpdate(ctx context.Context, stream flight.FlightService_DoExchangeServer, schemaName, tableName string, returnData bool) (err error) {
 	s.logger.Debug("DoExchange UPDATE requested",
 		"schema", schemaName,
 		"table", tableName,
 		"return_data", returnData,
 	)
 
+	rec := auditResource(auth.OperationUpdate, auth.Resource{Schema: schemaName, Table: tableName})
+	defer func() { s.audit(ctx, rec, err) }()
+
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", schemaName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -271,7 +292,7 @@ func (s *Server) handleDoExchangeUpdate(ctx context.Context, stream flight.Fligh
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, tableName)
+	table, err := s.lookupTable(ctx, schema, tableName)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", tableName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -290,6 +311,12 @@ func (s *Server) handleDoExchangeUpdate(ctx context.Context, stream flight.Fligh
 	}
 
 	inputSchema := inputReader.Schema()
+	res := auth.Resource{Schema: schemaName, Table: tableName, Columns: schemaColumnNames(inputSchema)}
+	rec.Columns = res.Columns
+	if err := s.authorize(ctx, auth.OperationUpdate, res); err != nil {
+		inputReader.Release()
+		return err
+	}
 
 	// Find the rowid column in the input schema using catalog helper
 	rowidColIdx := catalog.FindRowIDColumn(inputSchema)
@@ -329,7 +356,7 @@ func (s *Server) handleDoExchangeUpdate(ctx context.Context, stream flight.Fligh
 	}
 
 	// Create a writer to send output schema (required for bidirectional exchange)
-	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false)
+	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false, s.writerOptions()...)
 	defer writer.Close()
 
 	// Send schema to client to acknowledge and enable bidirectional data flow
@@ -431,9 +458,12 @@ func (s *Server) handleDoExchangeUpdate(ctx context.Context, stream flight.Fligh
 	// Wait for pipeline to complete
 	if err := eg.Wait(); err != nil {
 		s.logger.Error("UPDATE pipeline failed", "schema", schemaName, "table", tableName, "error", err)
-		return status.Errorf(codes.Internal, "UPDATE failed: %v", err)
+		return dmlError("UPDATE", err)
 	}
 
+	trace.SpanFromContext(ctx).SetAttributes(attrRows.Int64(totalRows))
+	s.observeChanged(schemaName, tableName, "update", totalRows)
+	rec.Rows = totalRows
 	s.logger.Debug("UPDATE completed",
 		"schema", schemaName,
 		"table", tableName,
@@ -453,15 +483,18 @@ func (s *Server) handleDoExchangeUpdate(ctx context.Context, stream flight.Fligh
 // - Server sends final metadata with total_changed count
 //
 // Implementation uses a bidirectional pipeline with concurrent goroutines.
-func (s *Server) handleDoExchangeDelete(ctx context.Context, stream flight.FlightService_DoExchangeServer, schemaName, tableName string, returnData bool) error {
+func (s *Server) handleDoExchangeDelete(ctx context.Context, stream flight.FlightService_DoExchangeServer, schemaName, tableName string, returnData bool) (err error) {
 	s.logger.Debug("DoExchange DELETE requested",
 		"schema", schemaName,
 		"table", tableName,
 		"return_data", returnData,
 	)
 
+	rec := auditResource(auth.OperationDelete, auth.Resource{Schema: schemaName, Table: tableName})
+	defer func() { s.audit(ctx, rec, err) }()
+
 	// Look up schema
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		s.logger.Error("Failed to get schema", "schema", schemaName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get schema: %v", err)
@@ -471,7 +504,7 @@ func (s *Server) handleDoExchangeDelete(ctx context.Context, stream flight.Fligh
 	}
 
 	// Look up table
-	table, err := schema.Table(ctx, tableName)
+	table, err := s.lookupTable(ctx, schema, tableName)
 	if err != nil {
 		s.logger.Error("Failed to get table", "table", tableName, "error", err)
 		return status.Errorf(codes.Internal, "failed to get table: %v", err)
@@ -479,6 +512,11 @@ func (s *Server) handleDoExchangeDelete(ctx context.Context, stream flight.Fligh
 	if table == nil {
 		return status.Errorf(codes.NotFound, "table '%s.%s' not found", schemaName, tableName)
 	}
+	res := auth.Resource{Schema: schemaName, Table: tableName}
+	rec.Columns = res.Columns
+	if err := s.authorize(ctx, auth.OperationDelete, res); err != nil {
+		return err
+	}
 
 	// Create record reader from stream directly
 	inputReader, err := flight.NewRecordReader(stream, ipc.WithAllocator(s.allocator))
@@ -519,7 +557,7 @@ func (s *Server) handleDoExchangeDelete(ctx context.Context, stream flight.Fligh
 	}
 
 	// Create a writer to send output schema (required for bidirectional exchange)
-	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false)
+	writer := NewSchemaWriter(stream, outputSchema, s.allocator, false, s.writerOptions()...)
 	defer writer.Close()
 
 	// Send schema to client to acknowledge and enable bidirectional data flow
@@ -621,9 +659,12 @@ func (s *Server) handleDoExchangeDelete(ctx context.Context, stream flight.Fligh
 	// Wait for pipeline to complete
 	if err := eg.Wait(); err != nil {
 		s.logger.Error("DELETE pipeline failed", "schema", schemaName, "table", tableName, "error", err)
-		return status.Errorf(codes.Internal, "DELETE failed: %v", err)
+		return dmlError("DELETE", err)
 	}
 
+	trace.SpanFromContext(ctx).SetAttributes(attrRows.Int64(totalRows))
+	s.observeChanged(schemaName, tableName, "delete", totalRows)
+	rec.Rows = totalRows
 	s.logger.Debug("DELETE completed",
 		"schema", schemaName,
 		"table", tableName,
@@ -688,11 +729,25 @@ func extractRowIDs(arr arrow.Array) ([]int64, error) {
 	return rowIDs, nil
 }
 
+// dmlError converts a failed DML pipeline error to a status error.
+// Permission errors of tables (such as row-level security) map to PermissionDenied.
+func dmlError(op string, err error) error {
+	if errors.Is(err, auth.ErrPermissionDenied) {
+		return status.Errorf(codes.PermissionDenied, "%s failed: %v", op, err)
+	}
+	return status.Errorf(codes.Internal, "%s failed: %v", op, err)
+}
+
 // getTableColumnNames returns all column names from the table schema,
 // excluding pseudo-columns like rowid (identified by is_rowid metadata).
 // This is used to populate ReturningColumns for DML operations.
 func getTableColumnNames(table catalog.Table) []string {
-	schema := table.ArrowSchema(nil) // Get full schema
+	return schemaColumnNames(table.ArrowSchema(nil)) // Get full schema
+}
+
+// schemaColumnNames returns the column names of schema, excluding pseudo-columns
+// like rowid (identified by is_rowid metadata).
+func schemaColumnNames(schema *arrow.Schema) []string {
 	if schema == nil {
 		return nil
 	}
@@ -876,7 +931,9 @@ func (s *Server) newUpdateProcessor(table catalog.Table, rowidColIdx int) (batch
 			var result *catalog.DMLResult
 			txErr := s.withTransaction(ctx, func(txCtx context.Context) error {
 				var err error
-				result, err = batchTable.Update(txCtx, batch, opts)
+				result, err = s.traceDML(txCtx, "catalog.Update", table, func(ctx context.Context) (*catalog.DMLResult, error) {
+					return batchTable.Update(ctx, batch, opts)
+				})
 				return err
 			})
 			return result, txErr
@@ -911,7 +968,9 @@ func (s *Server) newUpdateProcessor(table catalog.Table, rowidColIdx int) (batch
 			var result *catalog.DMLResult
 			txErr := s.withTransaction(ctx, func(txCtx context.Context) error {
 				var err error
-				result, err = legacyTable.Update(txCtx, rowIDs, recordReader, opts)
+				result, err = s.traceDML(txCtx, "catalog.Update", table, func(ctx context.Context) (*catalog.DMLResult, error) {
+					return legacyTable.Update(ctx, rowIDs, recordReader, opts)
+				})
 				return err
 			})
 			return result, txErr
@@ -930,7 +989,9 @@ func (s *Server) newDeleteProcessor(table catalog.Table) (batchProcessor, bool)
 			var result *catalog.DMLResult
 			txErr := s.withTransaction(ctx, func(txCtx context.Context) error {
 				var err error
-				result, err = batchTable.Delete(txCtx, batch, opts)
+				result, err = s.traceDML(txCtx, "catalog.Delete", table, func(ctx context.Context) (*catalog.DMLResult, error) {
+					return batchTable.Delete(ctx, batch, opts)
+				})
 				return err
 			})
 			return result, txErr
@@ -953,7 +1014,9 @@ func (s *Server) newDeleteProcessor(table catalog.Table) (batchProcessor, bool)
 			var result *catalog.DMLResult
 			txErr := s.withTransaction(ctx, func(txCtx context.Context) error {
 				var err error
-				result, err = legacyTable.Delete(txCtx, rowIDs, opts)
+				result, err = s.traceDML(txCtx, "catalog.Delete", table, func(ctx context.Context) (*catalog.DMLResult, error) {
+					return legacyTable.Delete(ctx, rowIDs, opts)
+				})
 				return err
 			})
 			return result, txErr
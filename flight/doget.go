@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
@@ -12,7 +13,9 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
 // DoGet streams Arrow record batches for a table query.
@@ -27,16 +30,25 @@ import (
 //  5. Streams record batches using Arrow IPC format
 //  6. Respects context cancellation
 //  7. Propagates errors from scan function
-func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetServer) error {
-	ctx := EnrichContextMetadata(stream.Context())
+//
+// If a TicketSigner is set, the ticket must be signed for the calling identity.
+// If the ticket was issued inside a transaction, the scan runs with the
+// transaction ID in its context; the transaction must still be active.
+func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetServer) (err error) {
+	start := time.Now()
+	defer s.trackStream(metrics.MethodDoGet)()
+	defer func() { s.observeRPC(metrics.MethodDoGet, "", start, err) }()
+
+	ctx, span := s.startRPCSpan(EnrichContextMetadata(stream.Context()), "DoGet")
+	defer func() { endSpan(span, err) }()
 
 	s.logger.Debug("DoGet called", "ticket_size", len(ticket.GetTicket()))
 
-	// Decode ticket to get schema/table names
-	ticketData, err := DecodeTicket(ticket.GetTicket())
+	// Decode ticket to get schema/table names (verifying its signature if enabled)
+	ticketData, err := s.decodeTicket(ctx, ticket.GetTicket())
 	if err != nil {
 		s.logger.Error("Failed to decode ticket", "error", err)
-		return status.Errorf(codes.InvalidArgument, "invalid ticket: %v", err)
+		return err
 	}
 
 	s.logger.Debug("DoGet request",
@@ -44,15 +56,40 @@ func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetS
 		"schema", ticketData.Schema,
 		"table", ticketData.Table,
 		"table_function", ticketData.TableFunction,
+		"limit", ticketData.Limit,
 	)
+	span.SetAttributes(attrSchema.String(ticketData.Schema))
+	rec := auditResource(auth.OperationScan, auth.Resource{Schema: ticketData.Schema, Table: ticketData.Table})
+	if ticketData.TableFunction != "" {
+		span.SetAttributes(attrFunction.String(ticketData.TableFunction))
+		rec.Operation = auth.OperationExecute
+		rec.Function = ticketData.TableFunction
+	} else {
+		span.SetAttributes(attrTable.String(ticketData.Table))
+	}
+	rec.Filter = auditFilter(ticketData.Filters)
+	if ticketData.TimePointUnit != "" {
+		rec.Details = map[string]string{"time_point": ticketData.TimePointUnit + " " + ticketData.TimePointValue}
+	}
+	defer func() { s.audit(ctx, rec, err) }()
 
 	if ticketData.Catalog != s.CatalogName() {
 		s.logger.Error("Catalog name mismatch", "expected", s.CatalogName(), "got", ticketData.Catalog)
 		return status.Errorf(codes.InvalidArgument, "catalog name mismatch: expected %q, got %q", s.CatalogName(), ticketData.Catalog)
 	}
 
+	// Run the scan inside the transaction the ticket was issued in
+	if ticketData.TransactionID != "" {
+		ctx = catalog.WithTransactionID(ctx, ticketData.TransactionID)
+	}
+	release, err := s.holdTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Look up schema in catalog
-	schema, err := s.catalog.Schema(ctx, ticketData.Schema)
+	schema, err := s.lookupSchema(ctx, ticketData.Schema)
 	if err != nil {
 		s.logger.Error("Failed to get schema from catalog",
 			"schema", ticketData.Schema,
@@ -78,6 +115,15 @@ func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetS
 	if err != nil {
 		return err // Error already formatted
 	}
+	// Audit the columns streamed with data
+	if projected, ok := reader.(*projectionReader); ok {
+		rec.Columns = projected.columns
+	} else {
+		rec.Columns = schemaColumnNames(readerSchema)
+	}
+	if s.batchTargetRows > 0 || s.batchTargetBytes > 0 {
+		reader = newRebatchReader(reader, s.allocator, s.batchTargetRows, s.batchTargetBytes)
+	}
 	defer reader.Release()
 
 	s.logger.Debug("Starting record streaming",
@@ -87,11 +133,17 @@ func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetS
 	)
 
 	// Stream record batches using Arrow IPC format (T028)
-	writer := flight.NewRecordWriter(stream, ipc.WithSchema(readerSchema))
+	writer := flight.NewRecordWriter(stream, append(s.writerOptions(), ipc.WithSchema(readerSchema))...)
 	defer writer.Close()
 
 	batchCount := 0
 	totalRows := int64(0)
+	totalBytes := int64(0)
+	defer func() {
+		span.SetAttributes(attrRows.Int64(totalRows), attrBatches.Int(batchCount))
+		s.observeStreamed(ticketData.Schema, ticketData.Table+ticketData.TableFunction, totalRows, totalBytes)
+		rec.Rows = totalRows
+	}()
 
 	// Stream batches (T029: context cancellation handled by reader.Next())
 	for reader.Next() {
@@ -109,8 +161,20 @@ func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetS
 		}
 
 		record := reader.RecordBatch()
+
+		// Enforce the row limit even if the scan ignored ScanOptions.Limit
+		limitReached := false
+		if ticketData.Limit > 0 && totalRows+record.NumRows() >= ticketData.Limit {
+			limitReached = true
+			if remaining := ticketData.Limit - totalRows; remaining < record.NumRows() {
+				record = record.NewSlice(0, remaining)
+				defer record.Release()
+			}
+		}
+
 		batchCount++
 		totalRows += record.NumRows()
+		totalBytes += recordSize(record)
 
 		// Write batch to stream
 		if err := writer.Write(record); err != nil {
@@ -130,6 +194,15 @@ func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetS
 			"rows_in_batch", record.NumRows(),
 			"total_rows", totalRows,
 		)
+
+		if limitReached {
+			s.logger.Debug("DoGet row limit reached",
+				"schema", ticketData.Schema,
+				"table", ticketData.Table,
+				"limit", ticketData.Limit,
+			)
+			break
+		}
 	}
 
 	// Check for errors during iteration (T030: error propagation)
@@ -166,7 +239,7 @@ func (s *Server) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetS
 // executeTableScan handles regular table scan operations.
 func (s *Server) executeTableScan(ctx context.Context, schema catalog.Schema, ticketData *TicketData) (array.RecordReader, *arrow.Schema, error) {
 	// Look up table in schema
-	table, err := schema.Table(ctx, ticketData.Table)
+	table, err := s.lookupTable(ctx, schema, ticketData.Table)
 	if err != nil {
 		s.logger.Error("Failed to get table from schema",
 			"schema", ticketData.Schema,
@@ -181,6 +254,7 @@ func (s *Server) executeTableScan(ctx context.Context, schema catalog.Schema, ti
 
 	// Convert ticket data to scan options (includes time-travel parameters and columns)
 	scanOpts := ticketData.ToScanOptions()
+	scanOpts.BatchSize = s.batchTargetRows
 
 	// Get table's full Arrow schema (nil = no projection, DuckDB expects full schema in DoGet)
 	fullSchema := table.ArrowSchema(nil)
@@ -192,6 +266,19 @@ func (s *Server) executeTableScan(ctx context.Context, schema catalog.Schema, ti
 		return nil, nil, status.Errorf(codes.Internal, "table %s.%s has nil Arrow schema", ticketData.Schema, ticketData.Table)
 	}
 
+	// Check read access to the projected columns (all columns if no projection)
+	// and to the columns read by the pushed-down filters
+	columns, err := s.scanColumns(scanOpts.Columns, scanOpts.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(columns) == 0 {
+		columns = schemaColumnNames(fullSchema)
+	}
+	if err := s.authorize(ctx, auth.OperationScan, auth.Resource{Schema: ticketData.Schema, Table: ticketData.Table, Columns: columns}); err != nil {
+		return nil, nil, err
+	}
+
 	// Log column projection hint (passed to table for optimization)
 	if len(scanOpts.Columns) > 0 {
 		s.logger.Debug("Column projection hint",
@@ -211,10 +298,21 @@ func (s *Server) executeTableScan(ctx context.Context, schema catalog.Schema, ti
 		)
 	}
 
+	// Log partition if this ticket came from a partitioned endpoint
+	if scanOpts.Partition != nil {
+		s.logger.Debug("Partitioned scan",
+			"schema", ticketData.Schema,
+			"table", ticketData.Table,
+			"partition_size", len(scanOpts.Partition),
+		)
+	}
+
 	// Call table's Scan function to get RecordReader
 	// Table can use scanOpts.Columns to optimize (e.g., only fetch needed columns from DB)
 	// but must return full schema - DuckDB handles projection client-side
-	reader, err := table.Scan(ctx, scanOpts)
+	scanCtx, span := s.startSpan(ctx, "catalog.Scan", attrSchema.String(ticketData.Schema), attrTable.String(ticketData.Table))
+	reader, err := table.Scan(scanCtx, scanOpts)
+	endSpan(span, err)
 	if err != nil {
 		s.logger.Error("Table scan failed",
 			"schema", ticketData.Schema,
@@ -240,6 +338,12 @@ func (s *Server) executeTableScan(ctx context.Context, schema catalog.Schema, ti
 			fullSchema.NumFields(), readerSchema.NumFields())
 	}
 
+	// Only the authorized projection may be streamed: tables return the full
+	// schema, so null out every column the client did not project
+	if s.authorizer != nil && len(scanOpts.Columns) > 0 {
+		reader = newProjectionReader(reader, s.allocator, scanOpts.Columns)
+	}
+
 	// For time-travel queries, use the reader's schema (which reflects historical state)
 	if scanOpts.TimePoint != nil {
 		s.logger.Debug("Time-travel query: using reader schema",
@@ -256,6 +360,10 @@ func (s *Server) executeTableScan(ctx context.Context, schema catalog.Schema, ti
 
 // executeTableFunction handles table function execution with dynamic schemas.
 func (s *Server) executeTableFunction(ctx context.Context, schema catalog.Schema, ticketData *TicketData) (array.RecordReader, *arrow.Schema, error) {
+	if err := s.authorize(ctx, auth.OperationExecute, auth.Resource{Schema: ticketData.Schema, Function: ticketData.TableFunction}); err != nil {
+		return nil, nil, err
+	}
+
 	// Get table functions from schema
 	functions, err := schema.TableFunctions(ctx)
 	if err != nil {
@@ -305,6 +413,7 @@ func (s *Server) executeTableFunction(ctx context.Context, schema catalog.Schema
 
 	// Convert ticket data to scan options (includes column projection hint)
 	scanOpts := ticketData.ToScanOptions()
+	scanOpts.BatchSize = s.batchTargetRows
 
 	// Log column projection hint (passed to function for optimization)
 	if len(scanOpts.Columns) > 0 {
@@ -324,7 +433,9 @@ func (s *Server) executeTableFunction(ctx context.Context, schema catalog.Schema
 	// Execute the table function
 	// Function can use scanOpts.Columns to optimize (e.g., skip computing unused columns)
 	// but must return full schema - DuckDB handles projection client-side
-	reader, err := targetFunc.Execute(ctx, params, scanOpts)
+	execCtx, span := s.startSpan(ctx, "catalog.Execute", attrSchema.String(ticketData.Schema), attrFunction.String(ticketData.TableFunction))
+	reader, err := targetFunc.Execute(execCtx, params, scanOpts)
+	endSpan(span, err)
 	if err != nil {
 		s.logger.Error("Table function execution failed",
 			"schema", ticketData.Schema,
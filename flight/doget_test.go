@@ -0,0 +1,214 @@
+package flight
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/internal/msgpack"
+)
+
+// fakeDoActionStream collects results sent by DoAction handlers.
+type fakeDoActionStream struct {
+	grpc.ServerStream
+	results []*flight.Result
+}
+
+func (f *fakeDoActionStream) Context() context.Context { return context.Background() }
+func (f *fakeDoActionStream) Send(r *flight.Result) error {
+	f.results = append(f.results, r)
+	return nil
+}
+
+// fakeDoGetStream collects the Flight data messages sent by DoGet.
+type fakeDoGetStream struct {
+	grpc.ServerStream
+	data []*flight.FlightData
+}
+
+func (f *fakeDoGetStream) Context() context.Context { return context.Background() }
+func (f *fakeDoGetStream) Send(d *flight.FlightData) error {
+	// The writer reuses d between calls
+	f.data = append(f.data, proto.Clone(d).(*flight.FlightData))
+	return nil
+}
+
+// Recv replays the collected messages so the stream can be read back.
+func (f *fakeDoGetStream) Recv() (*flight.FlightData, error) {
+	if len(f.data) == 0 {
+		return nil, io.EOF
+	}
+	d := f.data[0]
+	f.data = f.data[1:]
+	return d, nil
+}
+
+// records decodes the collected messages into record batches.
+func (f *fakeDoGetStream) records(t *testing.T) []arrow.RecordBatch {
+	t.Helper()
+
+	reader, err := flight.NewRecordReader(f)
+	if err != nil {
+		t.Fatalf("failed to read DoGet stream: %v", err)
+	}
+	defer reader.Release()
+
+	var records []arrow.RecordBatch
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		rec.Retain()
+		records = append(records, rec)
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("failed to read DoGet stream: %v", err)
+	}
+	t.Cleanup(func() {
+		for _, rec := range records {
+			rec.Release()
+		}
+	})
+	return records
+}
+
+// rows returns the total number of rows in the collected messages.
+func (f *fakeDoGetStream) rows(t *testing.T) int64 {
+	t.Helper()
+
+	var n int64
+	for _, rec := range f.records(t) {
+		n += rec.NumRows()
+	}
+	return n
+}
+
+// requestEndpoints calls the endpoints action for schema.table with the given
+// request parameters and returns the decoded endpoints.
+func requestEndpoints(t *testing.T, srv *Server, schema, table string, params map[string]any) []*flight.FlightEndpoint {
+	t.Helper()
+	return requestEndpointsContext(t, context.Background(), srv, schema, table, params)
+}
+
+// requestEndpointsContext is requestEndpoints with the request context ctx.
+func requestEndpointsContext(t *testing.T, ctx context.Context, srv *Server, schema, table string, params map[string]any) []*flight.FlightEndpoint {
+	t.Helper()
+
+	desc, err := proto.Marshal(&flight.FlightDescriptor{
+		Type: flight.DescriptorPATH,
+		Path: []string{schema, table},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal descriptor: %v", err)
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+	body, err := msgpack.Encode(map[string]any{
+		"descriptor": string(desc),
+		"parameters": params,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode request: %v", err)
+	}
+
+	stream := &fakeDoActionStream{}
+	if err := srv.handleEndpoints(ctx, &flight.Action{Type: "endpoints", Body: body}, stream); err != nil {
+		t.Fatalf("handleEndpoints failed: %v", err)
+	}
+	if len(stream.results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(stream.results))
+	}
+
+	var raw []string
+	if err := msgpack.Decode(stream.results[0].GetBody(), &raw); err != nil {
+		t.Fatalf("failed to decode endpoints: %v", err)
+	}
+	endpoints := make([]*flight.FlightEndpoint, len(raw))
+	for i, r := range raw {
+		endpoints[i] = &flight.FlightEndpoint{}
+		if err := proto.Unmarshal([]byte(r), endpoints[i]); err != nil {
+			t.Fatalf("failed to unmarshal endpoint: %v", err)
+		}
+	}
+	return endpoints
+}
+
+// newSequenceTable creates a table with a single int64 column "id" whose scan
+// returns the values 0..rows-1 in batches of batchSize rows.
+// The last ScanOptions passed to Scan is stored in lastOpts.
+func newSequenceTable(name string, rows, batchSize int, lastOpts **catalog.ScanOptions) *catalog.StaticTable {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	return catalog.NewStaticTable(name, "", schema, func(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+		if lastOpts != nil {
+			*lastOpts = opts
+		}
+		b := array.NewInt64Builder(memory.DefaultAllocator)
+		defer b.Release()
+
+		var records []arrow.RecordBatch
+		for start := 0; start < rows; start += batchSize {
+			for i := start; i < min(start+batchSize, rows); i++ {
+				b.Append(int64(i))
+			}
+			col := b.NewArray()
+			records = append(records, array.NewRecordBatch(schema, []arrow.Array{col}, int64(col.Len())))
+			col.Release()
+		}
+		defer func() {
+			for _, rec := range records {
+				rec.Release()
+			}
+		}()
+		return array.NewRecordReader(schema, records)
+	})
+}
+
+// TestDoGetLimit tests that the endpoints limit reaches the scan and that DoGet
+// stops streaming at the limit when the scan ignores it.
+func TestDoGetLimit(t *testing.T) {
+	var lastOpts *catalog.ScanOptions
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 100, 30, &lastOpts),
+	}, nil, nil, nil, nil)
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+
+	tests := []struct {
+		name     string
+		limit    int64
+		wantRows int64
+	}{
+		{name: "no limit", limit: 0, wantRows: 100},
+		{name: "within first batch", limit: 10, wantRows: 10},
+		{name: "batch boundary", limit: 60, wantRows: 60},
+		{name: "across batches", limit: 45, wantRows: 45},
+		{name: "above row count", limit: 500, wantRows: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := requestEndpoints(t, srv, "main", "numbers", map[string]any{"limit": tt.limit})
+			if len(endpoints) != 1 {
+				t.Fatalf("expected 1 endpoint, got %d", len(endpoints))
+			}
+
+			stream := &fakeDoGetStream{}
+			if err := srv.DoGet(endpoints[0].GetTicket(), stream); err != nil {
+				t.Fatalf("DoGet failed: %v", err)
+			}
+			if lastOpts == nil || lastOpts.Limit != tt.limit {
+				t.Errorf("expected ScanOptions.Limit %d, got %+v", tt.limit, lastOpts)
+			}
+			if rows := stream.rows(t); rows != tt.wantRows {
+				t.Errorf("expected %d rows, got %d", tt.wantRows, rows)
+			}
+		})
+	}
+}
@@ -2,10 +2,14 @@ package flight
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow/flight"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/hugr-lab/airport-go/auth"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
 // GetFlightInfo returns schema metadata and ticket for table queries.
@@ -16,8 +20,12 @@ import (
 //   - Schema: Arrow schema for the table
 //   - Ticket: Opaque byte slice encoding schema/table names
 //   - Endpoints: Single endpoint with the ticket
-func (s *Server) GetFlightInfo(ctx context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
-	ctx = EnrichContextMetadata(ctx)
+func (s *Server) GetFlightInfo(ctx context.Context, desc *flight.FlightDescriptor) (_ *flight.FlightInfo, err error) {
+	start := time.Now()
+	defer func() { s.observeRPC(metrics.MethodGetFlightInfo, "", start, err) }()
+
+	ctx, span := s.startRPCSpan(EnrichContextMetadata(ctx), "GetFlightInfo")
+	defer func() { endSpan(span, err) }()
 	s.logger.Debug("GetFlightInfo called",
 		"type", desc.GetType(),
 		"path_length", len(desc.GetPath()),
@@ -40,9 +48,15 @@ func (s *Server) GetFlightInfo(ctx context.Context, desc *flight.FlightDescripto
 		"schema", schemaName,
 		"table", tableName,
 	)
+	span.SetAttributes(attrSchema.String(schemaName), attrTable.String(tableName))
+
+	// Tables hidden from ListFlights are not found
+	if err := s.checkVisible(ctx, auth.Resource{Schema: schemaName, Table: tableName}); err != nil {
+		return nil, err
+	}
 
 	// Look up schema in catalog
-	schema, err := s.catalog.Schema(ctx, schemaName)
+	schema, err := s.lookupSchema(ctx, schemaName)
 	if err != nil {
 		s.logger.Error("Failed to get schema from catalog",
 			"schema", schemaName,
@@ -55,7 +69,7 @@ func (s *Server) GetFlightInfo(ctx context.Context, desc *flight.FlightDescripto
 	}
 
 	// Look up table in schema
-	table, err := schema.Table(ctx, tableName)
+	table, err := s.lookupTable(ctx, schema, tableName)
 	if err != nil {
 		s.logger.Error("Failed to get table from schema",
 			"schema", schemaName,
@@ -79,7 +93,7 @@ func (s *Server) GetFlightInfo(ctx context.Context, desc *flight.FlightDescripto
 	}
 
 	// Generate ticket
-	ticket, err := EncodeTableTicket(s.CatalogName(), schemaName, tableName)
+	ticket, err := s.issueTicket(ctx, &TicketData{Schema: schemaName, Table: tableName})
 	if err != nil {
 		s.logger.Error("Failed to encode ticket",
 			"schema", schemaName,
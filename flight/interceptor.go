@@ -2,12 +2,15 @@ package flight
 
 import (
 	"context"
+	"path"
+	"strconv"
 
 	"github.com/hugr-lab/airport-go/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // UnaryServerInterceptor creates a gRPC unary interceptor for authentication.
@@ -101,6 +104,116 @@ func StreamServerInterceptor(authenticator auth.Authenticator) grpc.StreamServer
 	}
 }
 
+// RateLimitUnaryInterceptor creates a gRPC unary interceptor that enforces the
+// request rate of limiter. Chain it after UnaryServerInterceptor so that limits
+// apply to the authenticated identity.
+// If limiter is nil, requests pass through without limits.
+func RateLimitUnaryInterceptor(limiter *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if limiter == nil {
+			return handler(ctx, req)
+		}
+		if err := limiter.allowRequest(rateLimitKeyFromContext(ctx)); err != nil {
+			_ = grpc.SetTrailer(ctx, retryAfterTrailer(err))
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor creates a gRPC stream interceptor that enforces the
+// request rate, concurrent DoGet and DoExchange streams and streamed bytes of
+// limiter. Chain it after StreamServerInterceptor so that limits apply to the
+// authenticated identity.
+// If limiter is nil, requests pass through without limits.
+func RateLimitStreamInterceptor(limiter *RateLimiter) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if limiter == nil {
+			return handler(srv, ss)
+		}
+
+		key := rateLimitKeyFromContext(ss.Context())
+		err := limiter.allowRequest(key)
+		if err == nil && isDataStream(info.FullMethod) {
+			// Reject before the handler does any work if the byte quota is used up.
+			err = limiter.takeBytes(key, 0)
+			if err == nil {
+				var release func()
+				release, err = limiter.acquireStream(key)
+				if err == nil {
+					defer release()
+				}
+			}
+		}
+		if err != nil {
+			ss.SetTrailer(retryAfterTrailer(err))
+			return err
+		}
+
+		limited := &rateLimitedServerStream{ServerStream: ss, limiter: limiter, key: key}
+		err = handler(srv, limited)
+		// Handlers wrap send errors; return the quota violation itself.
+		if limited.err != nil {
+			ss.SetTrailer(retryAfterTrailer(limited.err))
+			return limited.err
+		}
+		return err
+	}
+}
+
+// rateLimitedServerStream counts the bytes of sent messages against the byte quota.
+type rateLimitedServerStream struct {
+	grpc.ServerStream
+	limiter *RateLimiter
+	key     rateLimitKey
+	err     error // first quota violation
+}
+
+// SendMsg sends m if the byte quota of the stream's principal is not used up.
+func (r *rateLimitedServerStream) SendMsg(m any) error {
+	if msg, ok := m.(proto.Message); ok {
+		if err := r.limiter.takeBytes(r.key, proto.Size(msg)); err != nil {
+			if r.err == nil {
+				r.err = err
+			}
+			return err
+		}
+	}
+	return r.ServerStream.SendMsg(m)
+}
+
+// rateLimitKeyFromContext returns the identity and catalog whose limits apply to ctx.
+func rateLimitKeyFromContext(ctx context.Context) rateLimitKey {
+	return rateLimitKey{
+		principal: auth.IdentityFromContext(ctx),
+		catalog:   extractCatalogFromMetadata(ctx),
+	}
+}
+
+// isDataStream reports whether fullMethod is DoGet or DoExchange.
+func isDataStream(fullMethod string) bool {
+	switch path.Base(fullMethod) {
+	case "DoGet", "DoExchange":
+		return true
+	}
+	return false
+}
+
+// retryAfterTrailer returns the HeaderRetryAfter trailer of a rate limit error.
+func retryAfterTrailer(err error) metadata.MD {
+	return metadata.Pairs(HeaderRetryAfter, strconv.FormatInt(retryAfterSeconds(err), 10))
+}
+
 // wrappedServerStream wraps grpc.ServerStream with a custom context.
 type wrappedServerStream struct {
 	grpc.ServerStream
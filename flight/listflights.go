@@ -1,11 +1,15 @@
 package flight
 
 import (
+	"time"
+
 	"github.com/apache/arrow-go/v18/arrow/flight"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/hugr-lab/airport-go/catalog"
 	"github.com/hugr-lab/airport-go/internal/serialize"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
 // ListFlights returns available catalog metadata.
@@ -16,14 +20,22 @@ import (
 //   - ZStandard-compressed payload for efficient network transfer
 //   - Flight SQL standard schema format (GetTables)
 //
-// Criteria parameter is currently ignored (returns all tables).
-func (s *Server) ListFlights(criteria *flight.Criteria, stream flight.FlightService_ListFlightsServer) error {
+// Criteria parameter is currently ignored (returns all tables the caller may list).
+func (s *Server) ListFlights(criteria *flight.Criteria, stream flight.FlightService_ListFlightsServer) (err error) {
+	start := time.Now()
+	defer func() { s.observeRPC(metrics.MethodListFlights, "", start, err) }()
+
 	ctx := EnrichContextMetadata(stream.Context())
 
 	s.logger.Debug("ListFlights called")
 
 	// Serialize catalog to Arrow IPC format following Flight SQL schema
-	catalogData, err := serialize.SerializeCatalog(ctx, s.catalog, s.allocator)
+	// Only tables the caller may list are included
+	var cat catalog.Catalog = s.catalog
+	if s.authorizer != nil {
+		cat = &visibleCatalog{Catalog: s.catalog, server: s}
+	}
+	catalogData, err := serialize.SerializeCatalog(ctx, cat, s.allocator)
 	if err != nil {
 		s.logger.Error("Failed to serialize catalog", "error", err)
 		return status.Errorf(codes.Internal, "failed to serialize catalog: %v", err)
@@ -0,0 +1,68 @@
+package flight
+
+import (
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/hugr-lab/airport-go/metrics"
+)
+
+// SetMetrics sets the recorder of request, stream, row and transaction metrics.
+// Nil disables metrics.
+func (s *Server) SetMetrics(r metrics.Recorder) {
+	s.metrics = r
+}
+
+// observeRPC records an RPC started at start that returned err.
+func (s *Server) observeRPC(method, name string, start time.Time, err error) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.RPC(s.CatalogName(), method, name, status.Code(err), time.Since(start))
+}
+
+// trackStream records the start of a stream and returns the function that
+// records its end.
+func (s *Server) trackStream(method string) func() {
+	if s.metrics == nil {
+		return func() {}
+	}
+	catalogName := s.CatalogName()
+	s.metrics.StreamStarted(catalogName, method)
+	return func() { s.metrics.StreamFinished(catalogName, method) }
+}
+
+// observeStreamed records the rows and bytes a DoGet sent.
+func (s *Server) observeStreamed(schema, table string, rows, bytes int64) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.RowsStreamed(s.CatalogName(), schema, table, rows, bytes)
+}
+
+// observeChanged records the rows affected by a DML operation.
+func (s *Server) observeChanged(schema, table, operation string, rows int64) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.RowsChanged(s.CatalogName(), schema, table, operation, rows)
+}
+
+// observeTransaction records the outcome of a transaction.
+func (s *Server) observeTransaction(outcome metrics.TransactionOutcome) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.TransactionEnded(s.CatalogName(), outcome)
+}
+
+// exchangeOperationName returns the metrics name of a DoExchange operation.
+func exchangeOperationName(op string) string {
+	switch op {
+	case "scalar_function", "table_function", "table_function_in_out", "insert", "update", "delete":
+		return op
+	default:
+		return metrics.UnknownName
+	}
+}
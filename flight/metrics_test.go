@@ -0,0 +1,160 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/metrics"
+)
+
+// recordingRecorder records metrics as formatted strings.
+type recordingRecorder struct {
+	mu      sync.Mutex
+	events  []string
+	streams map[string]int
+}
+
+func (r *recordingRecorder) record(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingRecorder) RPC(catalog, method, name string, code codes.Code, duration time.Duration) {
+	r.record("rpc %s %s %s", method, name, code)
+}
+
+func (r *recordingRecorder) StreamStarted(catalog, method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.streams == nil {
+		r.streams = make(map[string]int)
+	}
+	r.streams[method]++
+}
+
+func (r *recordingRecorder) StreamFinished(catalog, method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.streams[method]--
+}
+
+func (r *recordingRecorder) RowsStreamed(catalog, schema, table string, rows, bytes int64) {
+	r.record("streamed %s.%s %d rows, bytes > 0: %t", schema, table, rows, bytes > 0)
+}
+
+func (r *recordingRecorder) RowsChanged(catalog, schema, table, operation string, rows int64) {
+	r.record("changed %s.%s %s %d rows", schema, table, operation, rows)
+}
+
+func (r *recordingRecorder) TransactionEnded(catalog string, outcome metrics.TransactionOutcome) {
+	r.record("transaction %s", outcome)
+}
+
+// take returns and clears the recorded events.
+func (r *recordingRecorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := r.events
+	r.events = nil
+	return events
+}
+
+// contextDoExchangeStream is a DoExchange stream with a request context and no data.
+type contextDoExchangeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (c *contextDoExchangeStream) Context() context.Context          { return c.ctx }
+func (c *contextDoExchangeStream) Send(*flight.FlightData) error     { return nil }
+func (c *contextDoExchangeStream) Recv() (*flight.FlightData, error) { return nil, io.EOF }
+
+func TestMetricsRPCs(t *testing.T) {
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 5, 2, nil),
+	}, nil, nil, nil, nil)
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+	rec := &recordingRecorder{}
+	srv.SetMetrics(rec)
+
+	endpoints := requestEndpoints(t, srv, "main", "numbers", nil)
+	if err := srv.DoGet(endpoints[0].GetTicket(), &fakeDoGetStream{}); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	if err := srv.DoAction(&flight.Action{Type: "no_such_action"}, &fakeDoActionStream{}); err == nil {
+		t.Fatal("unknown action should fail")
+	}
+	_, _ = srv.GetFlightInfo(context.Background(), &flight.FlightDescriptor{
+		Type: flight.DescriptorPATH,
+		Path: []string{"main", "missing"},
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"airport-operation", "merge",
+		"airport-flight-path", "main/numbers",
+	))
+	if err := srv.DoExchange(&contextDoExchangeStream{ctx: ctx}); err == nil {
+		t.Fatal("unknown DoExchange operation should fail")
+	}
+
+	want := []string{
+		"streamed main.numbers 5 rows, bytes > 0: true",
+		"rpc DoGet  OK",
+		"rpc DoAction unknown Unimplemented",
+		"rpc GetFlightInfo  NotFound",
+		"rpc DoExchange unknown InvalidArgument",
+	}
+	if got := rec.take(); !slices.Equal(got, want) {
+		t.Errorf("events =\n%q\nwant\n%q", got, want)
+	}
+	if want := map[string]int{metrics.MethodDoGet: 0, metrics.MethodDoExchange: 0}; !maps.Equal(rec.streams, want) {
+		t.Errorf("active streams after return = %v, want %v", rec.streams, want)
+	}
+}
+
+func TestMetricsTransactions(t *testing.T) {
+	txm := newTestTxManager()
+	s := NewServerWithTxManager(nil, memory.DefaultAllocator, testLogger(), "", txm)
+	rec := &recordingRecorder{}
+	s.SetMetrics(rec)
+
+	committed, _ := txm.BeginTransaction(context.Background())
+	if _, err := doTxAction(s, "commit_transaction", map[string]any{"transaction_id": committed}); err != nil {
+		t.Fatalf("commit_transaction failed: %v", err)
+	}
+	rolledBack, _ := txm.BeginTransaction(context.Background())
+	if _, err := doTxAction(s, "rollback_transaction", map[string]any{"transaction_id": rolledBack}); err != nil {
+		t.Fatalf("rollback_transaction failed: %v", err)
+	}
+	failed, _ := txm.BeginTransaction(context.Background())
+	_ = s.withTransaction(catalog.WithTransactionID(context.Background(), failed), func(context.Context) error {
+		return errors.New("insert failed")
+	})
+
+	var got []string
+	for _, event := range rec.take() {
+		if strings.HasPrefix(event, "transaction ") {
+			got = append(got, event)
+		}
+	}
+	want := []string{"transaction committed", "transaction rolled_back", "transaction aborted"}
+	if !slices.Equal(got, want) {
+		t.Errorf("transaction events = %q, want %q", got, want)
+	}
+}
@@ -196,9 +196,13 @@ func (m *MultiCatalogServer) GetSchema(ctx context.Context, descriptor *flight.F
 }
 
 // DoGet implements flight.FlightServer by delegating to the appropriate catalog server.
+// Ticket handles (see TicketStore) are routed to the catalog they were issued by.
 func (m *MultiCatalogServer) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetServer) error {
 	ctx := EnrichContextMetadata(stream.Context())
 	catalog := CatalogNameFromContext(ctx)
+	if name, ok := ticketHandleCatalog(ticket.GetTicket()); ok {
+		catalog = name
+	}
 
 	srv, err := m.catalogServer(catalog)
 	if err != nil {
@@ -0,0 +1,110 @@
+package flight
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// partitionedTable reports a fixed number of partitions and records which ones were scanned.
+type partitionedTable struct {
+	*catalog.StaticTable
+	partitions int
+
+	mu      sync.Mutex
+	scanned []string
+}
+
+func (t *partitionedTable) Partitions(ctx context.Context, opts *catalog.ScanOptions) ([][]byte, error) {
+	parts := make([][]byte, t.partitions)
+	for i := range parts {
+		parts[i] = fmt.Appendf(nil, "part-%d", i)
+	}
+	return parts, nil
+}
+
+func (t *partitionedTable) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	t.mu.Lock()
+	t.scanned = append(t.scanned, string(opts.Partition))
+	t.mu.Unlock()
+	return t.StaticTable.Scan(ctx, opts)
+}
+
+// TestPartitionedTableEndpoints tests that partitioned tables return one endpoint per partition
+// and that DoGet scans only the partition named in each ticket.
+func TestPartitionedTableEndpoints(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	scan := func(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+		b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		defer b.Release()
+		b.Field(0).(*array.Int64Builder).Append(1)
+		rec := b.NewRecordBatch()
+		defer rec.Release()
+		return array.NewRecordReader(schema, []arrow.RecordBatch{rec})
+	}
+
+	table := &partitionedTable{
+		StaticTable: catalog.NewStaticTable("events", "", schema, scan),
+		partitions:  3,
+	}
+	plain := catalog.NewStaticTable("users", "", schema, scan)
+
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{"events": table, "users": plain}, nil, nil, nil, nil)
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "localhost:0")
+
+	endpoints := requestEndpoints(t, srv, "main", "events", map[string]any{"json_filters": `{"filters":[]}`})
+	if len(endpoints) != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", len(endpoints))
+	}
+
+	for i, ep := range endpoints {
+		td, err := DecodeTicket(ep.GetTicket().GetTicket())
+		if err != nil {
+			t.Fatalf("failed to decode ticket %d: %v", i, err)
+		}
+		if want := fmt.Sprintf("part-%d", i); string(td.Partition) != want {
+			t.Errorf("ticket %d: expected partition %q, got %q", i, want, td.Partition)
+		}
+		if string(td.Filters) != `{"filters":[]}` {
+			t.Errorf("ticket %d: expected filters to be kept, got %q", i, td.Filters)
+		}
+
+		stream := &fakeDoGetStream{}
+		if err := srv.DoGet(ep.GetTicket(), stream); err != nil {
+			t.Fatalf("DoGet for partition %d failed: %v", i, err)
+		}
+		if rows := stream.rows(t); rows != 1 {
+			t.Errorf("DoGet for partition %d: expected 1 row, got %d", i, rows)
+		}
+	}
+
+	if len(table.scanned) != 3 {
+		t.Fatalf("expected 3 partition scans, got %v", table.scanned)
+	}
+	for i, p := range table.scanned {
+		if want := fmt.Sprintf("part-%d", i); p != want {
+			t.Errorf("scan %d: expected partition %q, got %q", i, want, p)
+		}
+	}
+
+	// Tables without partitions keep the single endpoint.
+	endpoints = requestEndpoints(t, srv, "main", "users", nil)
+	if len(endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint for unpartitioned table, got %d", len(endpoints))
+	}
+	td, err := DecodeTicket(endpoints[0].GetTicket().GetTicket())
+	if err != nil {
+		t.Fatalf("failed to decode ticket: %v", err)
+	}
+	if td.Partition != nil {
+		t.Errorf("expected no partition for unpartitioned table, got %q", td.Partition)
+	}
+}
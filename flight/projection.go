@@ -1,7 +1,13 @@
 package flight
 
 import (
+	"sync/atomic"
+
 	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
 )
 
 // ProjectSchema returns a projected schema containing only the specified columns.
@@ -36,3 +42,100 @@ func ProjectSchema(schema *arrow.Schema, columns []string) *arrow.Schema {
 	meta := schema.Metadata()
 	return arrow.NewSchema(fields, &meta)
 }
+
+// projectionReader replaces the columns of a scan that are outside its
+// projection with nulls, so that only authorized columns are streamed while
+// the full table schema is kept. Rowid columns are always kept.
+type projectionReader struct {
+	refCount atomic.Int64
+
+	src     array.RecordReader
+	alloc   memory.Allocator
+	keep    []bool   // by field index
+	columns []string // projected columns of the schema
+	out     arrow.RecordBatch
+}
+
+// newProjectionReader wraps src and takes ownership of it.
+// src is released when the returned reader is released.
+func newProjectionReader(src array.RecordReader, alloc memory.Allocator, columns []string) *projectionReader {
+	schema := src.Schema()
+	projected := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		projected[col] = true
+	}
+	r := &projectionReader{
+		src:   src,
+		alloc: alloc,
+		keep:  make([]bool, schema.NumFields()),
+	}
+	rowID := catalog.FindRowIDColumn(schema)
+	for i, field := range schema.Fields() {
+		switch {
+		case i == rowID:
+			r.keep[i] = true
+		case projected[field.Name]:
+			r.keep[i] = true
+			r.columns = append(r.columns, field.Name)
+		}
+	}
+	r.refCount.Add(1)
+	return r
+}
+
+func (r *projectionReader) Retain() {
+	r.refCount.Add(1)
+}
+
+func (r *projectionReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		if r.out != nil {
+			r.out.Release()
+			r.out = nil
+		}
+		r.src.Release()
+	}
+}
+
+func (r *projectionReader) Schema() *arrow.Schema {
+	return r.src.Schema()
+}
+
+func (r *projectionReader) RecordBatch() arrow.RecordBatch {
+	return r.out
+}
+
+// Deprecated: Use [projectionReader.RecordBatch] instead.
+func (r *projectionReader) Record() arrow.RecordBatch {
+	return r.out
+}
+
+func (r *projectionReader) Err() error {
+	return r.src.Err()
+}
+
+func (r *projectionReader) Next() bool {
+	if r.out != nil {
+		r.out.Release()
+		r.out = nil
+	}
+	if !r.src.Next() {
+		return false
+	}
+
+	rec := r.src.RecordBatch()
+	cols := make([]arrow.Array, rec.NumCols())
+	for i, col := range rec.Columns() {
+		if i < len(r.keep) && r.keep[i] {
+			col.Retain()
+			cols[i] = col
+		} else {
+			cols[i] = array.MakeArrayOfNull(r.alloc, col.DataType(), int(rec.NumRows()))
+		}
+	}
+	r.out = array.NewRecordBatch(rec.Schema(), cols, rec.NumRows())
+	for _, col := range cols {
+		col.Release()
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package flight
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// HeaderRetryAfter is the trailer that carries the number of seconds after
+// which a request rejected by a RateLimiter may be retried.
+const HeaderRetryAfter = "retry-after"
+
+// streamRetryDelay is the retry delay of requests rejected for too many
+// concurrent streams, which end at a time the limiter cannot predict.
+const streamRetryDelay = time.Second
+
+// RateLimit configures the limits of one principal in one catalog.
+// Zero fields are unlimited.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of RPCs of any kind.
+	RequestsPerSecond float64
+
+	// Burst is the number of RPCs allowed at once above RequestsPerSecond.
+	// If 0, it is RequestsPerSecond rounded up.
+	Burst int
+
+	// MaxConcurrentStreams is the maximum number of open DoGet and DoExchange streams.
+	MaxConcurrentStreams int
+
+	// MaxBytesPerMinute is the maximum number of bytes sent in stream messages per minute.
+	// A stream that exceeds it fails with the next message it sends.
+	MaxBytesPerMinute int64
+}
+
+// RateLimits configures the limits of a RateLimiter. Limits apply to each
+// principal in each catalog separately; unauthenticated requests share the
+// limits of the empty principal.
+type RateLimits struct {
+	// Default applies to principals and catalogs without an entry below.
+	Default RateLimit
+
+	// Catalogs overrides Default for catalogs, keyed by catalog name
+	// (the airport-catalog header, empty for the default catalog).
+	Catalogs map[string]RateLimit
+
+	// Principals overrides Default and Catalogs for principals, keyed by identity.
+	Principals map[string]RateLimit
+}
+
+// Validate checks that no limit is negative.
+func (l RateLimits) Validate() error {
+	check := func(name string, rl RateLimit) error {
+		if rl.RequestsPerSecond < 0 || rl.Burst < 0 || rl.MaxConcurrentStreams < 0 || rl.MaxBytesPerMinute < 0 {
+			return fmt.Errorf("rate limits of %s must not be negative", name)
+		}
+		return nil
+	}
+	if err := check("default", l.Default); err != nil {
+		return err
+	}
+	for name, rl := range l.Catalogs {
+		if err := check(fmt.Sprintf("catalog %q", name), rl); err != nil {
+			return err
+		}
+	}
+	for name, rl := range l.Principals {
+		if err := check(fmt.Sprintf("principal %q", name), rl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limit returns the limits of principal in catalogName.
+func (l RateLimits) limit(principal, catalogName string) RateLimit {
+	if rl, ok := l.Principals[principal]; ok {
+		return rl
+	}
+	if rl, ok := l.Catalogs[catalogName]; ok {
+		return rl
+	}
+	return l.Default
+}
+
+// RateLimiter enforces RateLimits in the interceptors returned by
+// RateLimitUnaryInterceptor and RateLimitStreamInterceptor.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	limits RateLimits
+	now    func() time.Time
+
+	mu        sync.Mutex
+	states    map[rateLimitKey]*rateLimitState
+	lastSweep time.Time
+}
+
+// rateLimitKey identifies the principal and catalog that share limits.
+type rateLimitKey struct {
+	principal string
+	catalog   string
+}
+
+// rateLimitState is the usage of one principal in one catalog.
+type rateLimitState struct {
+	limit    RateLimit
+	requests tokenBucket
+	bytes    tokenBucket
+	streams  int
+}
+
+// tokenBucket holds tokens that refill at a constant rate up to a capacity.
+// Tokens may go negative when a message is larger than the remaining tokens.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time, rate, capacity float64) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+	}
+	b.last = now
+}
+
+// NewRateLimiter creates a RateLimiter that enforces limits.
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	return &RateLimiter{
+		limits: limits,
+		now:    time.Now,
+		states: make(map[rateLimitKey]*rateLimitState),
+	}
+}
+
+// state returns the usage of key, creating it with full buckets.
+// Idle states are swept at most once per minute. Callers hold l.mu.
+func (l *RateLimiter) state(key rateLimitKey, now time.Time) *rateLimitState {
+	if now.Sub(l.lastSweep) >= time.Minute {
+		for k, st := range l.states {
+			if st.idle(now) {
+				delete(l.states, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	st, ok := l.states[key]
+	if !ok {
+		limit := l.limits.limit(key.principal, key.catalog)
+		st = &rateLimitState{
+			limit:    limit,
+			requests: tokenBucket{tokens: float64(burst(limit)), last: now},
+			bytes:    tokenBucket{tokens: float64(limit.MaxBytesPerMinute), last: now},
+		}
+		l.states[key] = st
+	}
+	return st
+}
+
+// idle reports whether st has no open streams and full buckets, so that
+// dropping it does not change any limit.
+func (st *rateLimitState) idle(now time.Time) bool {
+	if st.streams > 0 {
+		return false
+	}
+	if st.limit.RequestsPerSecond > 0 {
+		st.requests.refill(now, st.limit.RequestsPerSecond, float64(burst(st.limit)))
+		if st.requests.tokens < float64(burst(st.limit)) {
+			return false
+		}
+	}
+	if st.limit.MaxBytesPerMinute > 0 {
+		capacity := float64(st.limit.MaxBytesPerMinute)
+		st.bytes.refill(now, capacity/60, capacity)
+		if st.bytes.tokens < capacity {
+			return false
+		}
+	}
+	return true
+}
+
+// burst returns the request bucket capacity of limit.
+func burst(limit RateLimit) int {
+	if limit.Burst > 0 {
+		return limit.Burst
+	}
+	return int(math.Ceil(limit.RequestsPerSecond))
+}
+
+// allowRequest takes a request token of key.
+func (l *RateLimiter) allowRequest(key rateLimitKey) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	st := l.state(key, now)
+	rate := st.limit.RequestsPerSecond
+	if rate <= 0 {
+		return nil
+	}
+	st.requests.refill(now, rate, float64(burst(st.limit)))
+	if st.requests.tokens < 1 {
+		wait := time.Duration((1 - st.requests.tokens) / rate * float64(time.Second))
+		return resourceExhausted(wait, "rate limit of %g requests per second exceeded", rate)
+	}
+	st.requests.tokens--
+	return nil
+}
+
+// acquireStream counts an open stream of key.
+// The returned function releases it and must be called when the stream ends.
+func (l *RateLimiter) acquireStream(key rateLimitKey) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	st := l.state(key, l.now())
+	if limit := st.limit.MaxConcurrentStreams; limit > 0 && st.streams >= limit {
+		return nil, resourceExhausted(streamRetryDelay, "limit of %d concurrent streams reached", limit)
+	}
+	st.streams++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		st.streams--
+	}, nil
+}
+
+// takeBytes counts n bytes sent by key. It fails without counting them if
+// the byte quota is already used up.
+func (l *RateLimiter) takeBytes(key rateLimitKey, n int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	st := l.state(key, now)
+	limit := st.limit.MaxBytesPerMinute
+	if limit <= 0 {
+		return nil
+	}
+	rate := float64(limit) / 60
+	st.bytes.refill(now, rate, float64(limit))
+	if st.bytes.tokens <= 0 {
+		wait := time.Duration((1 - st.bytes.tokens) / rate * float64(time.Second))
+		return resourceExhausted(wait, "quota of %d bytes per minute exceeded", limit)
+	}
+	st.bytes.tokens -= float64(n)
+	return nil
+}
+
+// resourceExhausted returns a ResourceExhausted status with a RetryInfo detail.
+func resourceExhausted(retryAfter time.Duration, format string, args ...any) error {
+	st := status.Newf(codes.ResourceExhausted, format, args...)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// retryAfterSeconds returns the retry delay of a ResourceExhausted error in
+// whole seconds, rounded up, or 0 if err has no RetryInfo.
+func retryAfterSeconds(err error) int64 {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			return int64(math.Ceil(info.GetRetryDelay().AsDuration().Seconds()))
+		}
+	}
+	return 0
+}
@@ -0,0 +1,219 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/hugr-lab/airport-go/auth"
+)
+
+// newTestRateLimiter creates a RateLimiter with a clock that only advances
+// through the returned function.
+func newTestRateLimiter(limits RateLimits) (*RateLimiter, func(time.Duration)) {
+	now := time.Unix(1_700_000_000, 0)
+	l := NewRateLimiter(limits)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRateLimiterRequests(t *testing.T) {
+	l, advance := newTestRateLimiter(RateLimits{Default: RateLimit{RequestsPerSecond: 2}})
+	alice := rateLimitKey{principal: "alice"}
+
+	for i := range 2 {
+		if err := l.allowRequest(alice); err != nil {
+			t.Fatalf("request %d within burst: %v", i, err)
+		}
+	}
+	err := l.allowRequest(alice)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("request above burst error = %v, want ResourceExhausted", err)
+	}
+	if got := retryAfterSeconds(err); got != 1 {
+		t.Errorf("retry after = %ds, want 1s", got)
+	}
+	if err := l.allowRequest(rateLimitKey{principal: "bob"}); err != nil {
+		t.Errorf("other principal limited: %v", err)
+	}
+	if err := l.allowRequest(rateLimitKey{principal: "alice", catalog: "sales"}); err != nil {
+		t.Errorf("other catalog limited: %v", err)
+	}
+
+	advance(500 * time.Millisecond)
+	if err := l.allowRequest(alice); err != nil {
+		t.Errorf("request after refill: %v", err)
+	}
+}
+
+func TestRateLimiterStreams(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimits{Default: RateLimit{MaxConcurrentStreams: 1}})
+	key := rateLimitKey{principal: "alice"}
+
+	release, err := l.acquireStream(key)
+	if err != nil {
+		t.Fatalf("first stream: %v", err)
+	}
+	if _, err := l.acquireStream(key); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second stream error = %v, want ResourceExhausted", err)
+	}
+	release()
+	if _, err := l.acquireStream(key); err != nil {
+		t.Errorf("stream after release: %v", err)
+	}
+}
+
+func TestRateLimiterBytes(t *testing.T) {
+	l, advance := newTestRateLimiter(RateLimits{Default: RateLimit{MaxBytesPerMinute: 600}})
+	key := rateLimitKey{principal: "alice"}
+
+	// A message larger than the remaining quota is sent; the next one fails.
+	if err := l.takeBytes(key, 1000); err != nil {
+		t.Fatalf("first message: %v", err)
+	}
+	err := l.takeBytes(key, 1)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("message over quota error = %v, want ResourceExhausted", err)
+	}
+	// 400 bytes over quota plus one byte at 10 bytes per second.
+	if got := retryAfterSeconds(err); got != 41 {
+		t.Errorf("retry after = %ds, want 41s", got)
+	}
+
+	advance(41 * time.Second)
+	if err := l.takeBytes(key, 1); err != nil {
+		t.Errorf("message after refill: %v", err)
+	}
+}
+
+func TestRateLimitsOverrides(t *testing.T) {
+	limits := RateLimits{
+		Default:    RateLimit{RequestsPerSecond: 1},
+		Catalogs:   map[string]RateLimit{"sales": {RequestsPerSecond: 2}},
+		Principals: map[string]RateLimit{"etl": {}},
+	}
+	tests := []struct {
+		principal, catalog string
+		want               float64
+	}{
+		{"alice", "", 1},
+		{"alice", "sales", 2},
+		{"etl", "sales", 0},
+	}
+	for _, tt := range tests {
+		if got := limits.limit(tt.principal, tt.catalog).RequestsPerSecond; got != tt.want {
+			t.Errorf("limit(%q, %q) = %g requests per second, want %g", tt.principal, tt.catalog, got, tt.want)
+		}
+	}
+
+	limits.Catalogs["sales"] = RateLimit{MaxConcurrentStreams: -1}
+	if err := limits.Validate(); err == nil {
+		t.Error("Validate should reject negative limits")
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	l, advance := newTestRateLimiter(RateLimits{Default: RateLimit{RequestsPerSecond: 1, MaxConcurrentStreams: 1}})
+	_ = l.allowRequest(rateLimitKey{principal: "alice"})
+	release, _ := l.acquireStream(rateLimitKey{principal: "bob"})
+
+	advance(time.Minute)
+	_ = l.allowRequest(rateLimitKey{principal: "carol"})
+	if _, ok := l.states[rateLimitKey{principal: "alice"}]; ok {
+		t.Error("idle state was not swept")
+	}
+	if _, ok := l.states[rateLimitKey{principal: "bob"}]; !ok {
+		t.Error("state with an open stream was swept")
+	}
+	release()
+}
+
+// trailerServerStream is a server stream with a request context that records
+// its trailer and the messages it sends.
+type trailerServerStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+	sent    int
+}
+
+func (s *trailerServerStream) Context() context.Context  { return s.ctx }
+func (s *trailerServerStream) SetTrailer(md metadata.MD) { s.trailer = metadata.Join(s.trailer, md) }
+func (s *trailerServerStream) SendMsg(any) error         { s.sent++; return nil }
+
+func TestRateLimitStreamInterceptor(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{Default: RateLimit{MaxConcurrentStreams: 1, MaxBytesPerMinute: 100}})
+	interceptor := RateLimitStreamInterceptor(limiter)
+	ctx := auth.WithIdentity(context.Background(), "alice")
+	doGet := &grpc.StreamServerInfo{FullMethod: "/arrow.flight.protocol.FlightService/DoGet"}
+
+	// Concurrent streams: the second DoGet is rejected while the first is open.
+	first := &trailerServerStream{ctx: ctx}
+	err := interceptor(nil, first, doGet, func(any, grpc.ServerStream) error {
+		second := &trailerServerStream{ctx: ctx}
+		err := interceptor(nil, second, doGet, func(any, grpc.ServerStream) error { return nil })
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("second stream error = %v, want ResourceExhausted", err)
+		}
+		if got := second.trailer.Get(HeaderRetryAfter); len(got) != 1 || got[0] != "1" {
+			t.Errorf("retry-after trailer = %v, want [1]", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("first stream: %v", err)
+	}
+
+	// Bytes: the handler's wrapped send error is replaced by the quota error.
+	stream := &trailerServerStream{ctx: ctx}
+	err = interceptor(nil, stream, doGet, func(_ any, ss grpc.ServerStream) error {
+		data := &flight.FlightData{DataBody: make([]byte, 200)}
+		for range 2 {
+			if err := ss.SendMsg(data); err != nil {
+				return status.Errorf(codes.Internal, "failed to write batch: %v", err)
+			}
+		}
+		return nil
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("stream over byte quota error = %v, want ResourceExhausted", err)
+	}
+	if stream.sent != 1 {
+		t.Errorf("sent %d messages, want 1", stream.sent)
+	}
+	if len(stream.trailer.Get(HeaderRetryAfter)) != 1 {
+		t.Errorf("trailer = %v, want retry-after", stream.trailer)
+	}
+
+	// A used up byte quota rejects new data streams before the handler runs.
+	err = interceptor(nil, &trailerServerStream{ctx: ctx}, doGet, func(any, grpc.ServerStream) error {
+		return errors.New("handler should not run")
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("new stream error = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestRateLimitUnaryInterceptor(t *testing.T) {
+	interceptor := RateLimitUnaryInterceptor(NewRateLimiter(RateLimits{Default: RateLimit{RequestsPerSecond: 1}}))
+	ctx := auth.WithIdentity(context.Background(), "alice")
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("second request error = %v, want ResourceExhausted", err)
+	}
+
+	if _, err := RateLimitUnaryInterceptor(nil)(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Errorf("nil limiter: %v", err)
+	}
+}
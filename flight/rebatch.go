@@ -0,0 +1,212 @@
+package flight
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// rebatchReader reshapes the batches of a RecordReader toward a target row count
+// and byte size. Batches above the target are sliced; smaller batches are
+// coalesced until the target is reached or the source is exhausted.
+//
+// The byte size of a row is estimated per source batch from its buffer sizes.
+type rebatchReader struct {
+	refCount atomic.Int64
+
+	src         array.RecordReader
+	alloc       memory.Allocator
+	targetRows  int64
+	targetBytes int64
+
+	cur      arrow.RecordBatch // source batch being consumed
+	offset   int64             // rows of cur already emitted
+	rowLimit int64             // max rows per output batch derived from cur
+	out      arrow.RecordBatch
+	err      error
+}
+
+// newRebatchReader wraps src and takes ownership of it.
+// src is released when the returned reader is released.
+func newRebatchReader(src array.RecordReader, alloc memory.Allocator, targetRows int, targetBytes int64) *rebatchReader {
+	r := &rebatchReader{
+		src:         src,
+		alloc:       alloc,
+		targetRows:  int64(targetRows),
+		targetBytes: targetBytes,
+	}
+	r.refCount.Add(1)
+	return r
+}
+
+func (r *rebatchReader) Retain() {
+	r.refCount.Add(1)
+}
+
+func (r *rebatchReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		if r.out != nil {
+			r.out.Release()
+			r.out = nil
+		}
+		r.src.Release()
+	}
+}
+
+func (r *rebatchReader) Schema() *arrow.Schema {
+	return r.src.Schema()
+}
+
+func (r *rebatchReader) RecordBatch() arrow.RecordBatch {
+	return r.out
+}
+
+// Deprecated: Use [rebatchReader.RecordBatch] instead.
+func (r *rebatchReader) Record() arrow.RecordBatch {
+	return r.out
+}
+
+func (r *rebatchReader) Err() error {
+	return r.err
+}
+
+func (r *rebatchReader) Next() bool {
+	if r.out != nil {
+		r.out.Release()
+		r.out = nil
+	}
+	if r.err != nil {
+		return false
+	}
+
+	var (
+		pending     []arrow.RecordBatch
+		pendingRows int64
+		limit       int64 = math.MaxInt64
+	)
+	defer func() {
+		for _, p := range pending {
+			p.Release()
+		}
+	}()
+
+	for {
+		if r.cur == nil || r.offset >= r.cur.NumRows() {
+			if r.cur != nil {
+				r.cur.Release()
+				r.cur = nil
+			}
+			if !r.src.Next() {
+				r.err = r.src.Err()
+				break
+			}
+			r.cur = r.src.RecordBatch()
+			r.cur.Retain()
+			r.offset = 0
+			r.rowLimit = r.rowsPerBatch(r.cur)
+			continue
+		}
+
+		// Rows of differing width share the smallest limit
+		limit = min(limit, r.rowLimit)
+		if pendingRows >= limit {
+			break
+		}
+
+		n := min(r.cur.NumRows()-r.offset, limit-pendingRows)
+		pending = append(pending, r.cur.NewSlice(r.offset, r.offset+n))
+		r.offset += n
+		pendingRows += n
+		if pendingRows >= limit {
+			break
+		}
+	}
+
+	if r.err != nil || len(pending) == 0 {
+		return false
+	}
+	if len(pending) == 1 {
+		r.out = pending[0]
+		pending = nil
+		return true
+	}
+
+	out, err := concatRecords(r.src.Schema(), pending, pendingRows, r.alloc)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	r.out = out
+	return true
+}
+
+// rowsPerBatch returns the maximum rows per output batch for rows shaped like rec.
+func (r *rebatchReader) rowsPerBatch(rec arrow.RecordBatch) int64 {
+	limit := int64(math.MaxInt64)
+	if r.targetRows > 0 {
+		limit = r.targetRows
+	}
+	if r.targetBytes > 0 && rec.NumRows() > 0 {
+		rowBytes := max(recordSize(rec)/rec.NumRows(), 1)
+		limit = min(limit, max(r.targetBytes/rowBytes, 1))
+	}
+	return limit
+}
+
+// recordSize returns the total size of the buffers referenced by rec.
+func recordSize(rec arrow.RecordBatch) int64 {
+	var n int64
+	for _, col := range rec.Columns() {
+		n += arrayDataSize(col.Data())
+	}
+	return n
+}
+
+// arrayDataSize returns the total size of the buffers referenced by data and its children.
+func arrayDataSize(data arrow.ArrayData) int64 {
+	var n int64
+	for _, buf := range data.Buffers() {
+		if buf != nil {
+			n += int64(buf.Len())
+		}
+	}
+	for _, child := range data.Children() {
+		n += arrayDataSize(child)
+	}
+	if data.DataType().ID() == arrow.DICTIONARY {
+		n += arrayDataSize(data.Dictionary())
+	}
+	return n
+}
+
+// concatRecords concatenates records column by column into a new record.
+func concatRecords(schema *arrow.Schema, records []arrow.RecordBatch, numRows int64, alloc memory.Allocator) (arrow.RecordBatch, error) {
+	cols := make([]arrow.Array, schema.NumFields())
+	defer func() {
+		for _, c := range cols {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+
+	parts := make([]arrow.Array, len(records))
+	for i := range cols {
+		for j, rec := range records {
+			parts[j] = rec.Column(i)
+		}
+		col, err := array.Concatenate(parts, alloc)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	return array.NewRecordBatch(schema, cols, numRows), nil
+}
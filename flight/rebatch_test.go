@@ -0,0 +1,168 @@
+package flight
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// int64Reader returns a reader over batches of consecutive int64 values with the given sizes.
+func int64Reader(t *testing.T, alloc memory.Allocator, sizes ...int) array.RecordReader {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewInt64Builder(alloc)
+	defer b.Release()
+
+	var (
+		records []arrow.RecordBatch
+		next    int64
+	)
+	for _, size := range sizes {
+		for range size {
+			b.Append(next)
+			next++
+		}
+		col := b.NewArray()
+		records = append(records, array.NewRecordBatch(schema, []arrow.Array{col}, int64(size)))
+		col.Release()
+	}
+	defer func() {
+		for _, rec := range records {
+			rec.Release()
+		}
+	}()
+
+	reader, err := array.NewRecordReader(schema, records)
+	if err != nil {
+		t.Fatalf("NewRecordReader failed: %v", err)
+	}
+	return reader
+}
+
+// readBatchSizes drains reader and returns the row count of each batch.
+// It also checks that values are consecutive, i.e. no rows were lost or reordered.
+func readBatchSizes(t *testing.T, reader array.RecordReader) []int64 {
+	t.Helper()
+
+	var (
+		sizes []int64
+		next  int64
+	)
+	for reader.Next() {
+		rec := reader.RecordBatch()
+		sizes = append(sizes, rec.NumRows())
+		col := rec.Column(0).(*array.Int64)
+		for i := 0; i < col.Len(); i++ {
+			if col.Value(i) != next {
+				t.Fatalf("expected value %d, got %d", next, col.Value(i))
+			}
+			next++
+		}
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("reader error: %v", err)
+	}
+	return sizes
+}
+
+func TestRebatchReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		sizes       []int
+		targetRows  int
+		targetBytes int64
+		want        []int64
+	}{
+		{
+			name:       "slice oversized batch",
+			sizes:      []int{250},
+			targetRows: 100,
+			want:       []int64{100, 100, 50},
+		},
+		{
+			name:       "coalesce small batches",
+			sizes:      []int{10, 10, 10, 10, 10},
+			targetRows: 25,
+			want:       []int64{25, 25},
+		},
+		{
+			name:       "mixed sizes",
+			sizes:      []int{5, 120, 3, 0, 40},
+			targetRows: 50,
+			want:       []int64{50, 50, 50, 18},
+		},
+		{
+			name:        "byte target",
+			sizes:       []int{1000},
+			targetBytes: 8 * 300, // int64 values with no validity bitmap
+			want:        []int64{300, 300, 300, 100},
+		},
+		{
+			name:        "smallest target wins",
+			sizes:       []int{1000},
+			targetRows:  200,
+			targetBytes: 8 * 500,
+			want:        []int64{200, 200, 200, 200, 200},
+		},
+		{
+			name:       "empty input",
+			sizes:      []int{0, 0},
+			targetRows: 10,
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer alloc.AssertSize(t, 0)
+
+			reader := newRebatchReader(int64Reader(t, alloc, tt.sizes...), alloc, tt.targetRows, tt.targetBytes)
+			defer reader.Release()
+
+			got := readBatchSizes(t, reader)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected batch sizes %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected batch sizes %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDoGetBatchTarget(t *testing.T) {
+	var lastOpts *catalog.ScanOptions
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 100, 7, &lastOpts),
+	}, nil, nil, nil, nil)
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+	srv.SetBatchTarget(40, 0)
+
+	endpoints := requestEndpoints(t, srv, "main", "numbers", nil)
+	stream := &fakeDoGetStream{}
+	if err := srv.DoGet(endpoints[0].GetTicket(), stream); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+
+	if lastOpts == nil || lastOpts.BatchSize != 40 {
+		t.Errorf("expected ScanOptions.BatchSize 40, got %+v", lastOpts)
+	}
+
+	var sizes []int64
+	for _, rec := range stream.records(t) {
+		sizes = append(sizes, rec.NumRows())
+	}
+	want := []int64{40, 40, 20}
+	if len(sizes) != len(want) || sizes[0] != 40 || sizes[1] != 40 || sizes[2] != 20 {
+		t.Errorf("expected batch sizes %v, got %v", want, sizes)
+	}
+}
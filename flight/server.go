@@ -4,12 +4,18 @@ package flight
 import (
 	"log/slog"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow/flight"
 	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 
+	"github.com/hugr-lab/airport-go/audit"
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
 // Server implements the Flight service handlers.
@@ -22,6 +28,26 @@ type Server struct {
 	logger    *slog.Logger
 	address   string                     // Server's public address for FlightEndpoint locations
 	txManager catalog.TransactionManager // Optional transaction coordinator
+	txTimeout time.Duration              // Idle time after which open transactions are rolled back (0 = never)
+
+	txMultiStatement bool // Keep transactions open across operations until commit or rollback (false = auto-commit)
+
+	txMu    sync.Mutex
+	openTxs map[string]*openTransaction // Open transactions by ID, for the idle timeout
+
+	batchTargetRows  int   // Target rows per DoGet batch (0 = no row target)
+	batchTargetBytes int64 // Target bytes per DoGet batch (0 = no byte target)
+
+	compression Compression // IPC body compression for result streams
+
+	ticketSigner *TicketSigner // Optional ticket signer (nil = unsigned tickets)
+	ticketStore  TicketStore   // Optional server-side ticket store (nil = full tickets)
+
+	authorizer auth.Authorizer // Optional operation authorizer (nil = allow all)
+
+	tracerProvider trace.TracerProvider // Optional tracer provider (nil = global provider)
+	metrics        metrics.Recorder     // Optional metrics recorder (nil = no metrics)
+	auditSink      audit.Sink           // Optional audit sink (nil = no audit records)
 }
 
 // NewServer creates a new Flight server with the given catalog and allocator.
@@ -39,6 +65,7 @@ func NewServer(cat catalog.Catalog, allocator memory.Allocator, logger *slog.Log
 		allocator: allocator,
 		logger:    logger,
 		address:   address,
+		txTimeout: DefaultTransactionTimeout,
 	}
 }
 
@@ -57,6 +84,7 @@ func NewServerWithTxManager(cat catalog.Catalog, allocator memory.Allocator, log
 		logger:    logger,
 		address:   address,
 		txManager: txManager,
+		txTimeout: DefaultTransactionTimeout,
 	}
 }
 
@@ -67,6 +95,37 @@ func (s *Server) SetTransactionManager(txManager catalog.TransactionManager) {
 	s.txManager = txManager
 }
 
+// SetMultiStatementTransactions sets whether a transaction stays open across
+// the DML operations that share its ID until the client sends commit_transaction
+// or rollback_transaction. When disabled (the default), each DML operation
+// commits its transaction when it succeeds; DuckDB Airport clients rely on
+// this, as they never send commit_transaction.
+func (s *Server) SetMultiStatementTransactions(enabled bool) {
+	s.txMultiStatement = enabled
+}
+
+// SetTransactionTimeout sets how long a multi-statement transaction may stay
+// idle between operations before the server rolls it back
+// (DefaultTransactionTimeout if not set). Zero disables the timeout.
+// Applies to timers started after the call. The timer is paused while an
+// operation holds the transaction; idle expiry of the transaction manager,
+// if any, should be longer than the timeout plus the longest operation.
+func (s *Server) SetTransactionTimeout(timeout time.Duration) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+	s.txTimeout = timeout
+}
+
+// SetBatchTarget sets the target row count and byte size of DoGet record batches.
+// Batches larger than the target are sliced and smaller batches are coalesced
+// toward it. A zero value disables the corresponding target; when both are zero,
+// batches are streamed as returned by the table.
+// The row target is passed to tables and table functions as ScanOptions.BatchSize.
+func (s *Server) SetBatchTarget(rows int, bytes int64) {
+	s.batchTargetRows = rows
+	s.batchTargetBytes = bytes
+}
+
 // RegisterFlightServer registers the Flight service on the provided gRPC server.
 // This follows the standard gRPC service registration pattern.
 func RegisterFlightServer(grpcServer *grpc.Server, flightServer flight.FlightServer) {
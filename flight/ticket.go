@@ -1,9 +1,15 @@
 package flight
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
 )
 
@@ -45,6 +51,21 @@ type TicketData struct {
 
 	// Filters to apply (optional)
 	Filters []byte `json:"filters,omitempty"`
+
+	// Limit is the maximum number of rows to return (optional)
+	// If 0 or negative, no limit
+	// DoGet stops streaming once the limit is reached, even if the scan ignores it
+	Limit int64 `json:"limit,omitempty"`
+
+	// Partition is the table partition descriptor (optional)
+	// Set for endpoints produced by catalog.PartitionedTable
+	// Only valid when Table is set
+	Partition []byte `json:"partition,omitempty"`
+
+	// TransactionID is the transaction the ticket was issued in (optional)
+	// Set from the airport-transaction-id header of the flight_info or endpoints request
+	// DoGet runs the scan inside this transaction (see catalog.TransactionIDFromContext)
+	TransactionID string `json:"transaction_id,omitempty"`
 }
 
 // EncodeTableTicket creates an opaque ticket from schema and table names.
@@ -101,6 +122,11 @@ func DecodeTicket(ticketBytes []byte) (*TicketData, error) {
 		return nil, fmt.Errorf("function_params only valid with table_function")
 	}
 
+	// Partitions only valid with tables
+	if ticket.Partition != nil && ticket.Table == "" {
+		return nil, fmt.Errorf("partition only valid with table")
+	}
+
 	// Validate time point parameters
 	if ticket.TimePointUnit != "" && ticket.TimePointValue == "" {
 		return nil, fmt.Errorf("time_point_value must be set when time_point_unit is specified")
@@ -126,8 +152,10 @@ func (td *TicketData) Encode() ([]byte, error) {
 // This extracts time point parameters and converts them to TimePoint for the catalog layer.
 func (td *TicketData) ToScanOptions() *catalog.ScanOptions {
 	opts := &catalog.ScanOptions{
-		Columns: td.Columns,
-		Filter:  td.Filters,
+		Columns:   td.Columns,
+		Filter:    td.Filters,
+		Limit:     td.Limit,
+		Partition: td.Partition,
 	}
 
 	// Convert time point parameters to TimePoint
@@ -140,3 +168,64 @@ func (td *TicketData) ToScanOptions() *catalog.ScanOptions {
 
 	return opts
 }
+
+// issueTicket encodes td into a ticket for the client: a handle if a TicketStore
+// is set, the encoded TicketData otherwise; signed for the identity in ctx if a
+// TicketSigner is set.
+func (s *Server) issueTicket(ctx context.Context, td *TicketData) ([]byte, error) {
+	var ticket []byte
+	if s.ticketStore != nil {
+		stored := *td
+		id, err := s.ticketStore.Put(ctx, &stored)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store ticket: %w", err)
+		}
+		ticket = encodeTicketHandle(s.CatalogName(), id)
+	} else {
+		var err error
+		if ticket, err = td.Encode(); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.ticketSigner == nil {
+		return ticket, nil
+	}
+	return s.ticketSigner.Sign(ticket, auth.IdentityFromContext(ctx))
+}
+
+// decodeTicket decodes a DoGet ticket issued by issueTicket: it verifies the
+// signature for the identity in ctx if a TicketSigner is set and resolves
+// handles through the TicketStore. Returns a gRPC status error.
+func (s *Server) decodeTicket(ctx context.Context, ticket []byte) (*TicketData, error) {
+	if s.ticketSigner != nil {
+		var err error
+		ticket, err = s.ticketSigner.Verify(ticket, auth.IdentityFromContext(ctx))
+		switch {
+		case errors.Is(err, ErrTicketExpired):
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid ticket: %v", err)
+		case err != nil:
+			return nil, status.Errorf(codes.PermissionDenied, "invalid ticket: %v", err)
+		}
+	}
+
+	if id, _, ok := decodeTicketHandle(ticket); ok {
+		if s.ticketStore == nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid ticket: ticket handles are not enabled")
+		}
+		ticketData, err := s.ticketStore.Get(ctx, id)
+		switch {
+		case errors.Is(err, ErrTicketNotFound):
+			return nil, status.Error(codes.NotFound, "ticket expired or unknown; request new endpoints")
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "failed to resolve ticket: %v", err)
+		}
+		return ticketData, nil
+	}
+
+	ticketData, err := DecodeTicket(ticket)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ticket: %v", err)
+	}
+	return ticketData, nil
+}
@@ -0,0 +1,212 @@
+package flight
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrTicketSignature is returned for unsigned, tampered or unknown-key tickets.
+	ErrTicketSignature = errors.New("invalid ticket signature")
+
+	// ErrTicketExpired is returned for tickets older than the signer's TTL.
+	ErrTicketExpired = errors.New("ticket expired")
+
+	// ErrTicketIdentity is returned when a ticket is redeemed by an identity
+	// other than the one it was issued to.
+	ErrTicketIdentity = errors.New("ticket issued to a different identity")
+)
+
+// TicketKey is a named HMAC key used to sign tickets.
+type TicketKey struct {
+	// ID identifies the key in signed tickets, so that tickets signed with a
+	// previous key can be verified after rotation.
+	ID string
+
+	// Secret is the HMAC-SHA256 key. MUST NOT be empty; 32 random bytes recommended.
+	Secret []byte
+}
+
+// TicketSigner signs Flight tickets with HMAC-SHA256 and verifies them in DoGet.
+//
+// A signed ticket wraps the encoded TicketData together with the time it was
+// issued, the authenticated identity it was issued to and the ID of the signing
+// key. Verification rejects tickets that were modified, that are older than the
+// TTL, or that are redeemed by a different identity.
+//
+// The first key signs new tickets; all keys verify. To rotate keys, call SetKeys
+// with the new key first and keep the previous key until tickets signed with it
+// have expired.
+//
+// TicketSigner is goroutine-safe.
+type TicketSigner struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu   sync.RWMutex
+	keys []TicketKey
+}
+
+// signedTicket is the wire format of a signed ticket.
+type signedTicket struct {
+	Ticket   []byte `json:"ticket"`
+	IssuedAt int64  `json:"iat"`
+	Identity string `json:"sub,omitempty"`
+	KeyID    string `json:"kid,omitempty"`
+	MAC      []byte `json:"mac"`
+}
+
+// NewTicketSigner creates a TicketSigner.
+// Tickets older than ttl are rejected; if ttl is 0, tickets never expire.
+// Returns error if no key is given, a key has an empty secret or key IDs repeat.
+func NewTicketSigner(ttl time.Duration, keys ...TicketKey) (*TicketSigner, error) {
+	if ttl < 0 {
+		return nil, fmt.Errorf("ticket TTL must not be negative")
+	}
+	ts := &TicketSigner{ttl: ttl, now: time.Now}
+	if err := ts.SetKeys(keys...); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// SetKeys replaces the signing keys. The first key signs new tickets;
+// all keys are accepted for verification.
+func (ts *TicketSigner) SetKeys(keys ...TicketKey) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("at least one ticket key is required")
+	}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if len(key.Secret) == 0 {
+			return fmt.Errorf("ticket key %q has an empty secret", key.ID)
+		}
+		if seen[key.ID] {
+			return fmt.Errorf("duplicate ticket key ID %q", key.ID)
+		}
+		seen[key.ID] = true
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.keys = append([]TicketKey(nil), keys...)
+	return nil
+}
+
+// Sign wraps an encoded ticket into a signed ticket issued to identity.
+// identity is the authenticated user (auth.IdentityFromContext); empty for
+// unauthenticated servers.
+func (ts *TicketSigner) Sign(ticket []byte, identity string) ([]byte, error) {
+	ts.mu.RLock()
+	key := ts.keys[0]
+	ts.mu.RUnlock()
+
+	st := signedTicket{
+		Ticket:   ticket,
+		IssuedAt: ts.now().Unix(),
+		Identity: identity,
+		KeyID:    key.ID,
+	}
+	st.MAC = st.mac(key.Secret)
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed ticket: %w", err)
+	}
+	return data, nil
+}
+
+// Verify checks a signed ticket redeemed by identity and returns the encoded
+// ticket it wraps.
+// Returns ErrTicketSignature, ErrTicketExpired or ErrTicketIdentity if the
+// ticket is rejected.
+func (ts *TicketSigner) Verify(signed []byte, identity string) ([]byte, error) {
+	var st signedTicket
+	if err := json.Unmarshal(signed, &st); err != nil || st.MAC == nil {
+		return nil, ErrTicketSignature
+	}
+
+	secret, ok := ts.secret(st.KeyID)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrTicketSignature, st.KeyID)
+	}
+	if !hmac.Equal(st.MAC, st.mac(secret)) {
+		return nil, ErrTicketSignature
+	}
+
+	if age := ts.now().Sub(time.Unix(st.IssuedAt, 0)); ts.ttl > 0 && age > ts.ttl {
+		return nil, fmt.Errorf("%w: issued %s ago", ErrTicketExpired, age.Truncate(time.Second))
+	}
+	if st.Identity != identity {
+		return nil, ErrTicketIdentity
+	}
+	return st.Ticket, nil
+}
+
+// DecodeTicket verifies a signed ticket redeemed by identity and decodes the
+// TicketData it wraps.
+func (ts *TicketSigner) DecodeTicket(signed []byte, identity string) (*TicketData, error) {
+	ticket, err := ts.Verify(signed, identity)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeTicket(ticket)
+}
+
+// secret returns the secret of the key with the given ID.
+func (ts *TicketSigner) secret(keyID string) ([]byte, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	for _, key := range ts.keys {
+		if key.ID == keyID {
+			return key.Secret, true
+		}
+	}
+	return nil, false
+}
+
+// peekSignedTicket returns the ticket wrapped by a signed ticket without
+// verifying it. Returns false if ticket is not signed.
+func peekSignedTicket(ticket []byte) ([]byte, bool) {
+	var st signedTicket
+	if err := json.Unmarshal(ticket, &st); err != nil || st.MAC == nil {
+		return nil, false
+	}
+	return st.Ticket, true
+}
+
+// mac computes the HMAC of the signed fields. Fields are length-prefixed so
+// that no two distinct tickets produce the same input.
+func (st *signedTicket) mac(secret []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	for _, field := range [][]byte{
+		st.Ticket,
+		[]byte(strconv.FormatInt(st.IssuedAt, 10)),
+		[]byte(st.Identity),
+		[]byte(st.KeyID),
+	} {
+		var n [8]byte
+		binary.BigEndian.PutUint64(n[:], uint64(len(field)))
+		h.Write(n[:])
+		h.Write(field)
+	}
+	return h.Sum(nil)
+}
+
+// SetTicketSigner enables signing of the scan tickets returned by GetFlightInfo
+// and the flight_info, endpoints, table function and create_table actions.
+// DoGet then only accepts tickets signed by ts for the calling identity.
+// Tickets embedded in list_schemas contents are not signed, so that the
+// contents hash stays stable; clients request scan tickets via endpoints.
+// Nil disables signing.
+func (s *Server) SetTicketSigner(ts *TicketSigner) {
+	s.ticketSigner = ts
+}
@@ -0,0 +1,115 @@
+package flight
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+func TestTicketSigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ts, err := NewTicketSigner(time.Minute, TicketKey{ID: "k1", Secret: []byte("secret-1")})
+	if err != nil {
+		t.Fatalf("NewTicketSigner failed: %v", err)
+	}
+	ts.now = func() time.Time { return now }
+
+	ticket, _ := EncodeTableTicket("", "main", "users")
+	signed, err := ts.Sign(ticket, "alice")
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	td, err := ts.DecodeTicket(signed, "alice")
+	if err != nil {
+		t.Fatalf("DecodeTicket failed: %v", err)
+	}
+	if td.Schema != "main" || td.Table != "users" {
+		t.Errorf("decoded ticket = %+v", td)
+	}
+
+	// Re-issue the ticket to another identity without re-signing it.
+	var st signedTicket
+	json.Unmarshal(signed, &st)
+	st.Identity = "bob"
+	tampered, _ := json.Marshal(st)
+	if _, err := ts.Verify(tampered, "bob"); !errors.Is(err, ErrTicketSignature) {
+		t.Errorf("tampered ticket error = %v, want ErrTicketSignature", err)
+	}
+	if _, err := ts.Verify(ticket, "alice"); !errors.Is(err, ErrTicketSignature) {
+		t.Errorf("unsigned ticket error = %v, want ErrTicketSignature", err)
+	}
+	if _, err := ts.Verify(signed, "bob"); !errors.Is(err, ErrTicketIdentity) {
+		t.Errorf("other identity error = %v, want ErrTicketIdentity", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := ts.Verify(signed, "alice"); !errors.Is(err, ErrTicketExpired) {
+		t.Errorf("expired ticket error = %v, want ErrTicketExpired", err)
+	}
+}
+
+func TestTicketSignerKeyRotation(t *testing.T) {
+	k1 := TicketKey{ID: "k1", Secret: []byte("secret-1")}
+	k2 := TicketKey{ID: "k2", Secret: []byte("secret-2")}
+	ts, _ := NewTicketSigner(0, k1)
+
+	ticket, _ := EncodeTableTicket("", "main", "users")
+	old, _ := ts.Sign(ticket, "")
+
+	// The new key signs; the previous key still verifies.
+	if err := ts.SetKeys(k2, k1); err != nil {
+		t.Fatalf("SetKeys failed: %v", err)
+	}
+	current, _ := ts.Sign(ticket, "")
+	for _, signed := range [][]byte{old, current} {
+		if _, err := ts.Verify(signed, ""); err != nil {
+			t.Errorf("Verify failed: %v", err)
+		}
+	}
+
+	// Once the previous key is dropped, its tickets are rejected.
+	ts.SetKeys(k2)
+	if _, err := ts.Verify(old, ""); !errors.Is(err, ErrTicketSignature) {
+		t.Errorf("retired key error = %v, want ErrTicketSignature", err)
+	}
+
+	if err := ts.SetKeys(k1, TicketKey{ID: "k1", Secret: []byte("x")}); err == nil {
+		t.Error("SetKeys should reject duplicate key IDs")
+	}
+	if _, err := NewTicketSigner(0, TicketKey{ID: "empty"}); err == nil {
+		t.Error("NewTicketSigner should reject empty secrets")
+	}
+}
+
+func TestDoGetSignedTickets(t *testing.T) {
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 10, 10, nil),
+	}, nil, nil, nil, nil)
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+	ts, _ := NewTicketSigner(time.Minute, TicketKey{Secret: []byte("secret")})
+	srv.SetTicketSigner(ts)
+
+	endpoints := requestEndpoints(t, srv, "main", "numbers", nil)
+	stream := &fakeDoGetStream{}
+	if err := srv.DoGet(endpoints[0].GetTicket(), stream); err != nil {
+		t.Fatalf("DoGet with signed ticket failed: %v", err)
+	}
+	if rows := stream.rows(t); rows != 10 {
+		t.Errorf("expected 10 rows, got %d", rows)
+	}
+
+	forged, _ := (&TicketData{Schema: "main", Table: "numbers"}).Encode()
+	endpoints[0].GetTicket().Ticket = forged
+	if err := srv.DoGet(endpoints[0].GetTicket(), &fakeDoGetStream{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DoGet with unsigned ticket error = %v, want PermissionDenied", err)
+	}
+}
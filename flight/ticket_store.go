@@ -0,0 +1,156 @@
+package flight
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTicketTTL is how long MemoryTicketStore keeps tickets when no TTL is given.
+const DefaultTicketTTL = 10 * time.Minute
+
+// ErrTicketNotFound is returned by TicketStore.Get for unknown or evicted handles.
+var ErrTicketNotFound = errors.New("ticket not found")
+
+// ticketHandlePrefix marks tickets that are store handles rather than encoded TicketData.
+const ticketHandlePrefix = "airport-ticket-handle:"
+
+// TicketStore keeps ticket data server-side, so that tickets sent to clients
+// are short opaque handles instead of the full TicketData with filters and
+// function parameters.
+//
+// Implementations MUST be goroutine-safe. In multi-catalog servers one store is
+// shared by all catalogs.
+type TicketStore interface {
+	// Put stores td and returns the ID it can be retrieved with.
+	// IDs MUST be unguessable and MUST NOT contain ':'.
+	Put(ctx context.Context, td *TicketData) (id string, err error)
+
+	// Get returns the ticket data stored under id.
+	// Returns ErrTicketNotFound if id is unknown or was evicted.
+	// Tickets may be redeemed several times until they are evicted.
+	Get(ctx context.Context, id string) (*TicketData, error)
+}
+
+// MemoryTicketStore is an in-memory TicketStore that evicts tickets a fixed
+// time after they were stored.
+type MemoryTicketStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	tickets   map[string]memoryTicket
+	lastSweep time.Time
+}
+
+// memoryTicket is a stored ticket and its eviction time.
+type memoryTicket struct {
+	data    *TicketData
+	expires time.Time
+}
+
+var _ TicketStore = (*MemoryTicketStore)(nil)
+
+// NewMemoryTicketStore creates a MemoryTicketStore that keeps tickets for ttl.
+// If ttl is zero or negative, DefaultTicketTTL is used.
+func NewMemoryTicketStore(ttl time.Duration) *MemoryTicketStore {
+	if ttl <= 0 {
+		ttl = DefaultTicketTTL
+	}
+	return &MemoryTicketStore{
+		ttl:     ttl,
+		now:     time.Now,
+		tickets: make(map[string]memoryTicket),
+	}
+}
+
+// Put implements TicketStore.
+// Expired tickets are swept at most once per TTL, while storing new ones.
+func (m *MemoryTicketStore) Put(ctx context.Context, td *TicketData) (string, error) {
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", fmt.Errorf("failed to generate ticket ID: %w", err)
+	}
+	id := base64.RawURLEncoding.EncodeToString(raw[:])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= m.ttl {
+		for key, t := range m.tickets {
+			if now.After(t.expires) {
+				delete(m.tickets, key)
+			}
+		}
+		m.lastSweep = now
+	}
+	m.tickets[id] = memoryTicket{data: td, expires: now.Add(m.ttl)}
+	return id, nil
+}
+
+// Get implements TicketStore.
+// It returns a copy, so that callers cannot change the stored ticket.
+func (m *MemoryTicketStore) Get(ctx context.Context, id string) (*TicketData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tickets[id]
+	if !ok {
+		return nil, ErrTicketNotFound
+	}
+	if m.now().After(t.expires) {
+		delete(m.tickets, id)
+		return nil, ErrTicketNotFound
+	}
+	data := *t.data
+	return &data, nil
+}
+
+// Len returns the number of stored tickets, including expired ones not yet swept.
+func (m *MemoryTicketStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.tickets)
+}
+
+// SetTicketStore enables opaque ticket handles: scan tickets are kept in store
+// and clients receive a short handle that DoGet resolves.
+// Nil sends the full ticket data to clients.
+func (s *Server) SetTicketStore(store TicketStore) {
+	s.ticketStore = store
+}
+
+// encodeTicketHandle creates the handle ticket for a stored ticket of catalogName.
+// The catalog name is part of the handle so that multi-catalog servers can
+// route DoGet without resolving it.
+func encodeTicketHandle(catalogName, id string) []byte {
+	return []byte(ticketHandlePrefix + id + ":" + catalogName)
+}
+
+// decodeTicketHandle returns the store ID and catalog name of a handle ticket.
+// Returns false if ticket is not a handle.
+func decodeTicketHandle(ticket []byte) (id, catalogName string, ok bool) {
+	rest, found := bytes.CutPrefix(ticket, []byte(ticketHandlePrefix))
+	if !found {
+		return "", "", false
+	}
+	id, catalogName, ok = strings.Cut(string(rest), ":")
+	return id, catalogName, ok
+}
+
+// ticketHandleCatalog returns the catalog name of a handle ticket, which may
+// be signed. The signature is not verified; the catalog server does that.
+func ticketHandleCatalog(ticket []byte) (string, bool) {
+	if inner, ok := peekSignedTicket(ticket); ok {
+		ticket = inner
+	}
+	_, catalogName, ok := decodeTicketHandle(ticket)
+	return catalogName, ok
+}
@@ -0,0 +1,138 @@
+package flight
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// namedCatalog gives a catalog a name for multi-catalog routing.
+type namedCatalog struct {
+	catalog.Catalog
+	name string
+}
+
+func (c *namedCatalog) Name() string { return c.name }
+
+func TestMemoryTicketStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryTicketStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	first, err := store.Put(ctx, &TicketData{Schema: "main", Table: "a"})
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	now = now.Add(45 * time.Second)
+	second, _ := store.Put(ctx, &TicketData{Schema: "main", Table: "b"})
+	if first == second || strings.Contains(first, ":") {
+		t.Errorf("IDs %q and %q should be distinct and contain no ':'", first, second)
+	}
+
+	// Tickets can be redeemed repeatedly until they expire; changing a
+	// redeemed ticket does not change the stored one.
+	for range 2 {
+		td, err := store.Get(ctx, first)
+		if err != nil || td.Table != "a" {
+			t.Fatalf("Get = %+v, %v; want table a", td, err)
+		}
+		td.Table = "changed"
+	}
+	now = now.Add(30 * time.Second)
+	if _, err := store.Get(ctx, first); !errors.Is(err, ErrTicketNotFound) {
+		t.Errorf("expired ticket error = %v, want ErrTicketNotFound", err)
+	}
+	if _, err := store.Get(ctx, second); err != nil {
+		t.Errorf("Get of live ticket failed: %v", err)
+	}
+
+	// Expired tickets are swept while storing new ones.
+	now = now.Add(2 * time.Minute)
+	store.Put(ctx, &TicketData{Schema: "main", Table: "c"})
+	if n := store.Len(); n != 1 {
+		t.Errorf("store holds %d tickets after sweep, want 1", n)
+	}
+}
+
+func TestDoGetTicketHandles(t *testing.T) {
+	var lastOpts *catalog.ScanOptions
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 10, 10, &lastOpts),
+	}, nil, nil, nil, nil)
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+	store := NewMemoryTicketStore(time.Minute)
+	srv.SetTicketStore(store)
+
+	filters := `{"filters":[],"column_binding_names_by_index":["id"],"padding":"` + strings.Repeat("x", 4096) + `"}`
+	endpoints := requestEndpoints(t, srv, "main", "numbers", map[string]any{"json_filters": filters, "limit": 3})
+	ticket := endpoints[0].GetTicket().GetTicket()
+	if len(ticket) > 100 {
+		t.Errorf("handle ticket is %d bytes, want a short handle", len(ticket))
+	}
+
+	stream := &fakeDoGetStream{}
+	if err := srv.DoGet(endpoints[0].GetTicket(), stream); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	if rows := stream.rows(t); rows != 3 {
+		t.Errorf("expected 3 rows, got %d", rows)
+	}
+	if lastOpts == nil || !bytes.Equal(lastOpts.Filter, []byte(filters)) {
+		t.Error("scan did not receive the stored filters")
+	}
+
+	unknown := &flight.Ticket{Ticket: encodeTicketHandle("", "unknown")}
+	if err := srv.DoGet(unknown, &fakeDoGetStream{}); status.Code(err) != codes.NotFound {
+		t.Errorf("DoGet with unknown handle error = %v, want NotFound", err)
+	}
+
+	srv.SetTicketStore(nil)
+	if err := srv.DoGet(endpoints[0].GetTicket(), &fakeDoGetStream{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("DoGet with handle and no store error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestMultiCatalogDoGetRoutesHandles(t *testing.T) {
+	store := NewMemoryTicketStore(time.Minute)
+	signer, _ := NewTicketSigner(time.Minute, TicketKey{Secret: []byte("secret")})
+	var servers []*Server
+	for _, name := range []string{"sales", "analytics"} {
+		cat := catalog.NewStaticCatalog()
+		cat.AddSchema("main", "", map[string]catalog.Table{
+			"numbers": newSequenceTable("numbers", 5, 5, nil),
+		}, nil, nil, nil, nil)
+		srv := NewServer(&namedCatalog{Catalog: cat, name: name}, memory.DefaultAllocator, testLogger(), "")
+		srv.SetTicketStore(store)
+		srv.SetTicketSigner(signer)
+		servers = append(servers, srv)
+	}
+	mcs, err := NewMultiCatalogServerInternal(testLogger(), servers...)
+	if err != nil {
+		t.Fatalf("NewMultiCatalogServerInternal failed: %v", err)
+	}
+
+	// The request carries no catalog header; the handle routes to analytics.
+	endpoints := requestEndpoints(t, servers[1], "main", "numbers", nil)
+	if catalogName, ok := ticketHandleCatalog(endpoints[0].GetTicket().GetTicket()); !ok || catalogName != "analytics" {
+		t.Fatalf("handle catalog = %q, %v; want analytics", catalogName, ok)
+	}
+	stream := &fakeDoGetStream{}
+	if err := mcs.DoGet(endpoints[0].GetTicket(), stream); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	if rows := stream.rows(t); rows != 5 {
+		t.Errorf("expected 5 rows, got %d", rows)
+	}
+}
@@ -181,6 +181,15 @@ func TestToScanOptions(t *testing.T) {
 			},
 			wantTimePointNil: true,
 		},
+		{
+			name: "with partition",
+			ticket: TicketData{
+				Schema:    "main",
+				Table:     "users",
+				Partition: []byte("p1"),
+			},
+			wantTimePointNil: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -208,6 +217,11 @@ func TestToScanOptions(t *testing.T) {
 				}
 			}
 
+			// Check partition
+			if string(opts.Partition) != string(tt.ticket.Partition) {
+				t.Errorf("Partition = %q, want %q", opts.Partition, tt.ticket.Partition)
+			}
+
 			// Check columns
 			if len(tt.ticket.Columns) > 0 {
 				if len(opts.Columns) != len(tt.ticket.Columns) {
@@ -287,6 +301,16 @@ func TestDecodeTicketValidation(t *testing.T) {
 			ticket:    []byte(`{"schema":"main"}`),
 			wantError: true,
 		},
+		{
+			name:      "partition with table",
+			ticket:    []byte(`{"schema":"main","table":"users","partition":"cDE="}`),
+			wantError: false,
+		},
+		{
+			name:      "partition with table function",
+			ticket:    []byte(`{"schema":"main","table_function":"range","partition":"cDE="}`),
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,124 @@
+package flight
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// tracerName is the instrumentation scope name of the server spans.
+const tracerName = "github.com/hugr-lab/airport-go/flight"
+
+// Span attribute keys.
+const (
+	attrCatalog   = attribute.Key("airport.catalog")
+	attrSchema    = attribute.Key("airport.schema")
+	attrTable     = attribute.Key("airport.table")
+	attrFunction  = attribute.Key("airport.function")
+	attrAction    = attribute.Key("airport.action")
+	attrOperation = attribute.Key("airport.operation")
+	attrRows      = attribute.Key("airport.rows")
+	attrBatches   = attribute.Key("airport.batches")
+	attrTraceID   = attribute.Key("airport.trace_id")
+	attrSessionID = attribute.Key("airport.client_session_id")
+)
+
+// SetTracerProvider sets the OpenTelemetry tracer provider used for RPC and
+// catalog call spans. Nil uses the global provider (otel.GetTracerProvider).
+func (s *Server) SetTracerProvider(tp trace.TracerProvider) {
+	s.tracerProvider = tp
+}
+
+// tracer returns the tracer of the configured or global provider.
+func (s *Server) tracer() trace.Tracer {
+	tp := s.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// startRPCSpan starts the server span of an RPC.
+// The DuckDB trace and session IDs from the request metadata are recorded as
+// attributes, and the trace ID is linked to the span.
+func (s *Server) startRPCSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindServer)}
+	attrs = append(attrs, attrCatalog.String(s.CatalogName()))
+	if meta := MetaFromContext(ctx); meta != nil {
+		if meta.TraceID != "" {
+			attrs = append(attrs, attrTraceID.String(meta.TraceID))
+			opts = append(opts, trace.WithLinks(traceLink(meta.TraceID)))
+		}
+		if meta.SessionID != "" {
+			attrs = append(attrs, attrSessionID.String(meta.SessionID))
+		}
+	}
+	opts = append(opts, trace.WithAttributes(attrs...))
+	return s.tracer().Start(ctx, name, opts...)
+}
+
+// startSpan starts a child span around a catalog call.
+func (s *Server) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attrCatalog.String(s.CatalogName()))
+	return s.tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceLink returns a link to the DuckDB trace with the given ID.
+// IDs of 32 hex digits (optionally dash separated, as in UUIDs) are used as the
+// OpenTelemetry trace ID; other IDs are hashed, so requests that share a DuckDB
+// trace ID link to the same trace. The link carries no span ID.
+func traceLink(id string) trace.Link {
+	traceID, err := trace.TraceIDFromHex(strings.ReplaceAll(strings.ToLower(id), "-", ""))
+	if err != nil {
+		sum := sha256.Sum256([]byte(id))
+		copy(traceID[:], sum[:len(traceID)])
+	}
+	return trace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, Remote: true}),
+		Attributes:  []attribute.KeyValue{attrTraceID.String(id)},
+	}
+}
+
+// lookupSchema returns the named schema of the catalog in a catalog.Schema span.
+func (s *Server) lookupSchema(ctx context.Context, name string) (catalog.Schema, error) {
+	ctx, span := s.startSpan(ctx, "catalog.Schema", attrSchema.String(name))
+	schema, err := s.catalog.Schema(ctx, name)
+	endSpan(span, err)
+	return schema, err
+}
+
+// lookupTable returns the named table of schema in a catalog.Table span.
+func (s *Server) lookupTable(ctx context.Context, schema catalog.Schema, name string) (catalog.Table, error) {
+	ctx, span := s.startSpan(ctx, "catalog.Table", attrSchema.String(schema.Name()), attrTable.String(name))
+	table, err := schema.Table(ctx, name)
+	endSpan(span, err)
+	return table, err
+}
+
+// traceDML runs a DML call of table in a span named name and records the
+// affected row count.
+func (s *Server) traceDML(ctx context.Context, name string, table catalog.Table, fn func(context.Context) (*catalog.DMLResult, error)) (*catalog.DMLResult, error) {
+	ctx, span := s.startSpan(ctx, name, attrTable.String(table.Name()))
+	result, err := fn(ctx)
+	if result != nil {
+		span.SetAttributes(attrRows.Int64(result.AffectedRows))
+	}
+	endSpan(span, err)
+	return result, err
+}
@@ -0,0 +1,172 @@
+package flight
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// contextDoGetStream is a fakeDoGetStream with a request context.
+type contextDoGetStream struct {
+	fakeDoGetStream
+	ctx context.Context
+}
+
+func (c *contextDoGetStream) Context() context.Context { return c.ctx }
+
+// newTracingTestServer creates a server whose spans are recorded by the returned exporter.
+func newTracingTestServer(t *testing.T) (*Server, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": newSequenceTable("numbers", 5, 2, nil),
+	}, nil, nil, nil, nil)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	srv := NewServer(cat, memory.DefaultAllocator, testLogger(), "")
+	srv.SetTracerProvider(tp)
+	return srv, exporter
+}
+
+// spanAttr returns the value of attribute key of span, or an invalid value if not set.
+func spanAttr(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingDoGet(t *testing.T) {
+	srv, exporter := newTracingTestServer(t)
+
+	endpoints := requestEndpoints(t, srv, "main", "numbers", nil)
+	exporter.Reset()
+
+	const duckdbTraceID = "0af76519-16cd-43dd-8448-eb211c80319c"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		HeaderTraceID, duckdbTraceID,
+		HeaderSessionID, "session-1",
+	))
+	if err := srv.DoGet(endpoints[0].GetTicket(), &contextDoGetStream{ctx: ctx}); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	if want := []string{"catalog.Schema", "catalog.Table", "catalog.Scan", "DoGet"}; !slices.Equal(names, want) {
+		t.Fatalf("spans = %v, want %v", names, want)
+	}
+
+	root := spans[3]
+	for _, child := range spans[:3] {
+		if child.Parent.SpanID() != root.SpanContext.SpanID() {
+			t.Errorf("%s is not a child of DoGet", child.Name)
+		}
+		if got := spanAttr(child, attrCatalog).AsString(); got != "" {
+			t.Errorf("%s catalog = %q, want empty", child.Name, got)
+		}
+	}
+	if got := spanAttr(spans[2], attrTable).AsString(); got != "numbers" {
+		t.Errorf("catalog.Scan table = %q, want numbers", got)
+	}
+
+	tests := []struct {
+		key  attribute.Key
+		want attribute.Value
+	}{
+		{attrSchema, attribute.StringValue("main")},
+		{attrTable, attribute.StringValue("numbers")},
+		{attrRows, attribute.Int64Value(5)},
+		{attrBatches, attribute.IntValue(3)},
+		{attrTraceID, attribute.StringValue(duckdbTraceID)},
+		{attrSessionID, attribute.StringValue("session-1")},
+	}
+	for _, tt := range tests {
+		if got := spanAttr(root, tt.key); got != tt.want {
+			t.Errorf("DoGet %s = %v, want %v", tt.key, got.Emit(), tt.want.Emit())
+		}
+	}
+
+	if len(root.Links) != 1 {
+		t.Fatalf("DoGet links = %d, want 1", len(root.Links))
+	}
+	if got := root.Links[0].SpanContext.TraceID().String(); got != "0af7651916cd43dd8448eb211c80319c" {
+		t.Errorf("linked trace ID = %s", got)
+	}
+}
+
+func TestTracingErrors(t *testing.T) {
+	srv, exporter := newTracingTestServer(t)
+
+	_, err := srv.GetFlightInfo(context.Background(), &flight.FlightDescriptor{
+		Type: flight.DescriptorPATH,
+		Path: []string{"main", "missing"},
+	})
+	if err == nil {
+		t.Fatal("GetFlightInfo of a missing table should fail")
+	}
+
+	spans := exporter.GetSpans()
+	root := spans[len(spans)-1]
+	if root.Name != "GetFlightInfo" {
+		t.Fatalf("last span = %s, want GetFlightInfo", root.Name)
+	}
+	if root.Status.Code != codes.Error {
+		t.Errorf("GetFlightInfo status = %v, want Error", root.Status.Code)
+	}
+	if got := spanAttr(root, attrTable).AsString(); got != "missing" {
+		t.Errorf("GetFlightInfo table = %q, want missing", got)
+	}
+
+	exporter.Reset()
+	if err := srv.DoAction(&flight.Action{Type: "no_such_action"}, &fakeDoActionStream{}); err == nil {
+		t.Fatal("unknown action should fail")
+	}
+	spans = exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "DoAction no_such_action" || spans[0].Status.Code != codes.Error {
+		t.Errorf("spans = %+v, want one failed DoAction span", spans)
+	}
+}
+
+func TestTraceLink(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"4bf92f3577b34da6a3ce929d0e0e4736", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"4BF92F35-77B3-4DA6-A3CE-929D0E0E4736", "4bf92f3577b34da6a3ce929d0e0e4736"},
+	}
+	for _, tt := range tests {
+		if got := traceLink(tt.id).SpanContext.TraceID().String(); got != tt.want {
+			t.Errorf("traceLink(%q) trace ID = %s, want %s", tt.id, got, tt.want)
+		}
+	}
+
+	// Other IDs are hashed to a stable trace ID
+	a, b := traceLink("query-42"), traceLink("query-42")
+	if !a.SpanContext.TraceID().IsValid() || a.SpanContext.TraceID() != b.SpanContext.TraceID() {
+		t.Errorf("traceLink of an opaque ID = %s, then %s", a.SpanContext.TraceID(), b.SpanContext.TraceID())
+	}
+	if a.SpanContext.TraceID() == traceLink("query-43").SpanContext.TraceID() {
+		t.Error("different IDs link to the same trace")
+	}
+}
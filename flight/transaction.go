@@ -2,44 +2,253 @@ package flight
 
 import (
 	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
-// withTransaction wraps a DML operation with automatic transaction commit/rollback.
+// DefaultTransactionTimeout is how long a multi-statement transaction may stay
+// idle between operations before the server rolls it back, unless set with
+// SetTransactionTimeout. Clients that disconnect without commit_transaction or
+// rollback_transaction would otherwise leave their transactions open.
+const DefaultTransactionTimeout = 5 * time.Minute
+
+// openTransaction tracks a transaction between operations for the idle timeout.
+type openTransaction struct {
+	busy  int         // operations in progress
+	gen   uint64      // incremented whenever the timer is armed
+	timer *time.Timer // nil while busy or without timeout
+}
+
+// withTransaction runs a DML operation inside the transaction in context.
 // If no transaction ID is in context or no TransactionManager is configured,
 // the operation executes without transaction coordination.
 //
-// On success: commits the transaction (if present)
-// On failure: rolls back the transaction (if present)
+// By default each operation commits the transaction when it succeeds and rolls
+// it back when it fails, as DuckDB Airport clients expect.
+// With multi-statement transactions enabled (SetMultiStatementTransactions),
+// the transaction must be active and stays open after a successful operation,
+// so that several operations sharing the transaction ID are atomic. It ends with
+// the commit_transaction or rollback_transaction action or the idle timeout.
+// A failed operation rolls the transaction back.
 func (s *Server) withTransaction(ctx context.Context, fn func(context.Context) error) error {
-	// Extract and store transaction ID in context
 	txID, _ := catalog.TransactionIDFromContext(ctx)
 	if txID == "" || s.txManager == nil {
 		// No transaction - execute directly
 		return fn(ctx)
 	}
+	if !s.txMultiStatement {
+		return s.autoCommit(ctx, txID, fn)
+	}
 
-	// Execute with automatic commit/rollback
+	if err := s.checkTransactionActive(ctx, txID); err != nil {
+		return err
+	}
+
+	s.acquireTransaction(txID)
 	err := fn(ctx)
+	if err == nil {
+		s.releaseTransaction(txID)
+		return nil
+	}
 
-	if err != nil {
-		// Rollback on error (log but don't fail if rollback fails)
-		if rbErr := s.txManager.RollbackTransaction(ctx, txID); rbErr != nil {
-			s.logger.Error("transaction rollback failed",
-				"tx_id", txID,
-				"error", rbErr)
-		}
+	// A failed operation aborts the whole transaction
+	s.forgetTransaction(txID)
+	s.rollbackFailed(ctx, txID)
+	return err
+}
+
+// autoCommit runs fn in txID and commits the transaction on success or rolls
+// it back on failure.
+func (s *Server) autoCommit(ctx context.Context, txID string, fn func(context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		s.rollbackFailed(ctx, txID)
 		return err
 	}
 
-	// Commit on success
-	if cmErr := s.txManager.CommitTransaction(ctx, txID); cmErr != nil {
+	if err := s.txManager.CommitTransaction(ctx, txID); err != nil {
 		s.logger.Error("transaction commit failed",
 			"tx_id", txID,
-			"error", cmErr)
-		return cmErr
+			"error", err)
+		s.observeTransaction(metrics.TransactionFailed)
+		return err
 	}
+	s.observeTransaction(metrics.TransactionCommitted)
+	return nil
+}
 
+// rollbackFailed rolls back txID after a failed operation.
+// A rollback error is logged but not returned, so that the caller returns the
+// error of the operation.
+func (s *Server) rollbackFailed(ctx context.Context, txID string) {
+	if err := s.txManager.RollbackTransaction(ctx, txID); err != nil {
+		s.logger.Error("transaction rollback failed",
+			"tx_id", txID,
+			"error", err)
+		s.observeTransaction(metrics.TransactionFailed)
+		return
+	}
+	s.observeTransaction(metrics.TransactionAborted)
+}
+
+// holdTransaction prepares a read of the transaction in context.
+// With multi-statement transactions, the transaction must be active and its idle
+// timer is stopped until release is called. Unlike withTransaction, a failed
+// read does not roll back the transaction.
+func (s *Server) holdTransaction(ctx context.Context) (release func(), err error) {
+	txID, _ := catalog.TransactionIDFromContext(ctx)
+	if txID == "" || s.txManager == nil || !s.txMultiStatement {
+		return func() {}, nil
+	}
+
+	if err := s.checkTransactionActive(ctx, txID); err != nil {
+		return nil, err
+	}
+	s.acquireTransaction(txID)
+	return func() { s.releaseTransaction(txID) }, nil
+}
+
+// checkTransactionActive returns a gRPC error if txID is unknown or not active.
+func (s *Server) checkTransactionActive(ctx context.Context, txID string) error {
+	state, exists := s.txManager.GetTransactionStatus(ctx, txID)
+	if !exists {
+		return status.Errorf(codes.NotFound, "transaction %q not found", txID)
+	}
+	if state != catalog.TransactionActive {
+		return status.Errorf(codes.FailedPrecondition, "transaction %q is %s", txID, state)
+	}
 	return nil
 }
+
+// endTransaction commits or rolls back txID on behalf of the client.
+func (s *Server) endTransaction(ctx context.Context, txID string, commit bool) error {
+	s.forgetTransaction(txID)
+	var err error
+	outcome := metrics.TransactionRolledBack
+	if commit {
+		err = s.txManager.CommitTransaction(ctx, txID)
+		outcome = metrics.TransactionCommitted
+	} else {
+		err = s.txManager.RollbackTransaction(ctx, txID)
+	}
+	if err != nil {
+		outcome = metrics.TransactionFailed
+	}
+	s.observeTransaction(outcome)
+	return err
+}
+
+// trackTransaction starts the idle timer of a new transaction.
+// Transactions are only tracked while multi-statement transactions are enabled
+// and a timeout is set.
+func (s *Server) trackTransaction(txID string) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	if !s.txMultiStatement || s.txTimeout <= 0 {
+		return
+	}
+	if s.openTxs == nil {
+		s.openTxs = make(map[string]*openTransaction)
+	}
+	tx := &openTransaction{}
+	s.openTxs[txID] = tx
+	s.armTransactionLocked(txID, tx)
+}
+
+// acquireTransaction stops the idle timer of txID while an operation runs.
+// Transactions not begun by this server are tracked from their first operation.
+func (s *Server) acquireTransaction(txID string) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	if s.txTimeout <= 0 {
+		return
+	}
+	if s.openTxs == nil {
+		s.openTxs = make(map[string]*openTransaction)
+	}
+	tx, ok := s.openTxs[txID]
+	if !ok {
+		tx = &openTransaction{}
+		s.openTxs[txID] = tx
+	}
+	tx.busy++
+	if tx.timer != nil {
+		tx.timer.Stop()
+		tx.timer = nil
+	}
+}
+
+// releaseTransaction restarts the idle timer of txID once no operation runs.
+func (s *Server) releaseTransaction(txID string) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	tx, ok := s.openTxs[txID]
+	if !ok {
+		return
+	}
+	if tx.busy--; tx.busy == 0 {
+		s.armTransactionLocked(txID, tx)
+	}
+}
+
+// forgetTransaction stops tracking txID.
+func (s *Server) forgetTransaction(txID string) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	if tx, ok := s.openTxs[txID]; ok {
+		if tx.timer != nil {
+			tx.timer.Stop()
+		}
+		delete(s.openTxs, txID)
+	}
+}
+
+// armTransactionLocked starts the idle timer of tx if a timeout is set.
+// Caller must hold s.txMu.
+func (s *Server) armTransactionLocked(txID string, tx *openTransaction) {
+	if s.txTimeout <= 0 {
+		return
+	}
+	tx.gen++
+	gen, timeout := tx.gen, s.txTimeout
+	tx.timer = time.AfterFunc(timeout, func() {
+		s.expireTransaction(txID, gen, timeout)
+	})
+}
+
+// expireTransaction rolls back txID if it is still idle since the timer
+// generation gen was armed and the transaction is still active.
+func (s *Server) expireTransaction(txID string, gen uint64, timeout time.Duration) {
+	s.txMu.Lock()
+	tx, ok := s.openTxs[txID]
+	if !ok || tx.busy > 0 || tx.gen != gen {
+		s.txMu.Unlock()
+		return
+	}
+	delete(s.openTxs, txID)
+	s.txMu.Unlock()
+
+	ctx := context.Background()
+	if state, exists := s.txManager.GetTransactionStatus(ctx, txID); !exists || state != catalog.TransactionActive {
+		return
+	}
+	s.logger.Warn("transaction timed out, rolling back",
+		"tx_id", txID,
+		"timeout", timeout)
+	if err := s.txManager.RollbackTransaction(ctx, txID); err != nil {
+		s.logger.Error("transaction rollback failed",
+			"tx_id", txID,
+			"error", err)
+		s.observeTransaction(metrics.TransactionFailed)
+		return
+	}
+	s.observeTransaction(metrics.TransactionTimedOut)
+}
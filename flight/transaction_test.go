@@ -0,0 +1,353 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/internal/msgpack"
+)
+
+// testTxManager records the state transitions of its transactions.
+type testTxManager struct {
+	mu     sync.Mutex
+	states map[string]catalog.TransactionState
+	next   int
+}
+
+func newTestTxManager() *testTxManager {
+	return &testTxManager{states: make(map[string]catalog.TransactionState)}
+}
+
+func (m *testTxManager) BeginTransaction(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.next++
+	txID := string(rune('a' + m.next - 1))
+	m.states[txID] = catalog.TransactionActive
+	return txID, nil
+}
+
+func (m *testTxManager) CommitTransaction(ctx context.Context, txID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[txID] = catalog.TransactionCommitted
+	return nil
+}
+
+func (m *testTxManager) RollbackTransaction(ctx context.Context, txID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[txID] = catalog.TransactionAborted
+	return nil
+}
+
+func (m *testTxManager) GetTransactionStatus(ctx context.Context, txID string) (catalog.TransactionState, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[txID]
+	return state, ok
+}
+
+func (m *testTxManager) state(txID string) catalog.TransactionState {
+	state, _ := m.GetTransactionStatus(context.Background(), txID)
+	return state
+}
+
+// doTxAction runs a transaction action and returns the decoded status.
+func doTxAction(s *Server, actionType string, body map[string]any) (string, error) {
+	data, err := msgpack.Encode(body)
+	if err != nil {
+		return "", err
+	}
+	stream := &fakeDoActionStream{}
+	if err := s.DoAction(&flight.Action{Type: actionType, Body: data}, stream); err != nil {
+		return "", err
+	}
+	var response struct {
+		Identifier string `msgpack:"identifier"`
+		Status     string `msgpack:"status"`
+	}
+	if err := msgpack.Decode(stream.results[0].Body, &response); err != nil {
+		return "", err
+	}
+	if actionType == "create_transaction" {
+		return response.Identifier, nil
+	}
+	return response.Status, nil
+}
+
+func TestWithTransactionKeepsTransactionOpen(t *testing.T) {
+	txm := newTestTxManager()
+	s := NewServerWithTxManager(nil, memory.DefaultAllocator, testLogger(), "", txm)
+	s.SetMultiStatementTransactions(true)
+
+	txID, err := doTxAction(s, "create_transaction", map[string]any{"catalog_name": ""})
+	if err != nil {
+		t.Fatalf("create_transaction failed: %v", err)
+	}
+	ctx := catalog.WithTransactionID(context.Background(), txID)
+
+	var seen []string
+	for range 2 {
+		err := s.withTransaction(ctx, func(ctx context.Context) error {
+			id, _ := catalog.TransactionIDFromContext(ctx)
+			seen = append(seen, id)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("operation failed: %v", err)
+		}
+		if got := txm.state(txID); got != catalog.TransactionActive {
+			t.Fatalf("state after operation = %q, want active", got)
+		}
+	}
+	if len(seen) != 2 || seen[0] != txID || seen[1] != txID {
+		t.Errorf("operations saw transactions %q, want %q twice", seen, txID)
+	}
+
+	got, err := doTxAction(s, "commit_transaction", map[string]any{"transaction_id": txID})
+	if err != nil || got != string(catalog.TransactionCommitted) {
+		t.Fatalf("commit_transaction = %q, %v; want committed", got, err)
+	}
+
+	// Operations and rollback after commit are rejected.
+	err = s.withTransaction(ctx, func(context.Context) error { return nil })
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("operation after commit error = %v, want FailedPrecondition", err)
+	}
+	_, err = doTxAction(s, "rollback_transaction", map[string]any{"transaction_id": txID})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("rollback after commit error = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestWithTransactionRollsBackOnFailure(t *testing.T) {
+	txm := newTestTxManager()
+	s := NewServerWithTxManager(nil, memory.DefaultAllocator, testLogger(), "", txm)
+	s.SetMultiStatementTransactions(true)
+
+	txID, _ := txm.BeginTransaction(context.Background())
+	ctx := catalog.WithTransactionID(context.Background(), txID)
+
+	errFail := errors.New("insert failed")
+	if err := s.withTransaction(ctx, func(context.Context) error { return errFail }); !errors.Is(err, errFail) {
+		t.Fatalf("error = %v, want %v", err, errFail)
+	}
+	if got := txm.state(txID); got != catalog.TransactionAborted {
+		t.Errorf("state = %q, want aborted", got)
+	}
+	_, err := doTxAction(s, "commit_transaction", map[string]any{"transaction_id": txID})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("commit after failure error = %v, want FailedPrecondition", err)
+	}
+
+	err = s.withTransaction(catalog.WithTransactionID(context.Background(), "unknown"), func(context.Context) error { return nil })
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unknown transaction error = %v, want NotFound", err)
+	}
+}
+
+func TestRollbackTransactionAction(t *testing.T) {
+	txm := newTestTxManager()
+	s := NewServerWithTxManager(nil, memory.DefaultAllocator, testLogger(), "", txm)
+
+	txID, _ := txm.BeginTransaction(context.Background())
+	got, err := doTxAction(s, "rollback_transaction", map[string]any{"transaction_id": txID})
+	if err != nil || got != string(catalog.TransactionAborted) {
+		t.Fatalf("rollback_transaction = %q, %v; want aborted", got, err)
+	}
+
+	if _, err := doTxAction(s, "commit_transaction", map[string]any{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("commit without ID error = %v, want InvalidArgument", err)
+	}
+	if _, err := doTxAction(s, "commit_transaction", map[string]any{"transaction_id": "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("commit of unknown transaction error = %v, want NotFound", err)
+	}
+
+	noTx := NewServer(nil, memory.DefaultAllocator, testLogger(), "")
+	if _, err := doTxAction(noTx, "commit_transaction", map[string]any{"transaction_id": txID}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("commit without manager error = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestTransactionTimeout(t *testing.T) {
+	txm := newTestTxManager()
+	s := NewServerWithTxManager(nil, memory.DefaultAllocator, testLogger(), "", txm)
+	s.SetMultiStatementTransactions(true)
+	s.SetTransactionTimeout(50 * time.Millisecond)
+
+	idle, err := doTxAction(s, "create_transaction", map[string]any{"catalog_name": ""})
+	if err != nil {
+		t.Fatalf("create_transaction failed: %v", err)
+	}
+
+	// A running operation is not timed out.
+	busy, _ := doTxAction(s, "create_transaction", map[string]any{"catalog_name": ""})
+	err = s.withTransaction(catalog.WithTransactionID(context.Background(), busy), func(context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		if got := txm.state(busy); got != catalog.TransactionActive {
+			t.Errorf("state during operation = %q, want active", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("operation failed: %v", err)
+	}
+	if got := txm.state(idle); got != catalog.TransactionAborted {
+		t.Errorf("idle transaction state = %q, want aborted", got)
+	}
+	if _, err := doTxAction(s, "commit_transaction", map[string]any{"transaction_id": idle}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("commit of timed out transaction error = %v, want FailedPrecondition", err)
+	}
+
+	// The timer restarts after the operation.
+	deadline := time.Now().Add(time.Second)
+	for txm.state(busy) == catalog.TransactionActive && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := txm.state(busy); got != catalog.TransactionAborted {
+		t.Errorf("state after idle operation = %q, want aborted", got)
+	}
+}
+
+func TestWithTransactionAutoCommit(t *testing.T) {
+	txm := newTestTxManager()
+	s := NewServerWithTxManager(nil, memory.DefaultAllocator, testLogger(), "", txm)
+
+	txID, err := doTxAction(s, "create_transaction", map[string]any{"catalog_name": ""})
+	if err != nil {
+		t.Fatalf("create_transaction failed: %v", err)
+	}
+	if _, ok := s.openTxs[txID]; ok {
+		t.Error("auto-commit transaction is tracked for the idle timeout")
+	}
+	ctx := catalog.WithTransactionID(context.Background(), txID)
+	if err := s.withTransaction(ctx, func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("operation failed: %v", err)
+	}
+	if got := txm.state(txID); got != catalog.TransactionCommitted {
+		t.Errorf("state after operation = %q, want committed", got)
+	}
+
+	failed, _ := txm.BeginTransaction(context.Background())
+	errFail := errors.New("insert failed")
+	err = s.withTransaction(catalog.WithTransactionID(context.Background(), failed), func(context.Context) error { return errFail })
+	if !errors.Is(err, errFail) {
+		t.Fatalf("error = %v, want %v", err, errFail)
+	}
+	if got := txm.state(failed); got != catalog.TransactionAborted {
+		t.Errorf("state after failed operation = %q, want aborted", got)
+	}
+}
+
+func TestDefaultTransactionTimeout(t *testing.T) {
+	s := NewServerWithTxManager(nil, memory.DefaultAllocator, testLogger(), "", newTestTxManager())
+	s.SetMultiStatementTransactions(true)
+	if s.txTimeout != DefaultTransactionTimeout {
+		t.Errorf("default timeout = %v, want %v", s.txTimeout, DefaultTransactionTimeout)
+	}
+	txID, err := doTxAction(s, "create_transaction", map[string]any{"catalog_name": ""})
+	if err != nil {
+		t.Fatalf("create_transaction failed: %v", err)
+	}
+	if _, ok := s.openTxs[txID]; !ok {
+		t.Error("transaction is not tracked with the default timeout")
+	}
+	_, _ = doTxAction(s, "rollback_transaction", map[string]any{"transaction_id": txID})
+
+	// Zero disables the timeout.
+	s.SetTransactionTimeout(0)
+	txID, _ = doTxAction(s, "create_transaction", map[string]any{"catalog_name": ""})
+	if _, ok := s.openTxs[txID]; ok {
+		t.Error("transaction is tracked with the timeout disabled")
+	}
+}
+
+func TestDoGetInTransaction(t *testing.T) {
+	var scanTxID string
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	cat := catalog.NewStaticCatalog()
+	cat.AddSchema("main", "", map[string]catalog.Table{
+		"numbers": catalog.NewStaticTable("numbers", "", schema, func(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+			scanTxID, _ = catalog.TransactionIDFromContext(ctx)
+			return array.NewRecordReader(schema, nil)
+		}),
+	}, nil, nil, nil, nil)
+
+	txm := newTestTxManager()
+	s := NewServerWithTxManager(cat, memory.DefaultAllocator, testLogger(), "", txm)
+	s.SetMultiStatementTransactions(true)
+	txID, _ := txm.BeginTransaction(context.Background())
+
+	// The transaction ID of the endpoints request travels in the ticket to the scan.
+	ctx := catalog.WithTransactionID(context.Background(), txID)
+	endpoints := requestEndpointsContext(t, ctx, s, "main", "numbers", nil)
+	ticketData, err := DecodeTicket(endpoints[0].GetTicket().GetTicket())
+	if err != nil {
+		t.Fatalf("DecodeTicket failed: %v", err)
+	}
+	if ticketData.TransactionID != txID {
+		t.Errorf("ticket transaction ID = %q, want %q", ticketData.TransactionID, txID)
+	}
+	if err := s.DoGet(endpoints[0].GetTicket(), &fakeDoGetStream{}); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	if scanTxID != txID {
+		t.Errorf("scan transaction ID = %q, want %q", scanTxID, txID)
+	}
+	if got := txm.state(txID); got != catalog.TransactionActive {
+		t.Errorf("state after scan = %q, want active", got)
+	}
+
+	// Tickets of a finished transaction are rejected.
+	txm.CommitTransaction(context.Background(), txID)
+	if err := s.DoGet(endpoints[0].GetTicket(), &fakeDoGetStream{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DoGet after commit error = %v, want FailedPrecondition", err)
+	}
+
+	// Without a transaction the scan sees none.
+	scanTxID = ""
+	endpoints = requestEndpoints(t, s, "main", "numbers", nil)
+	if err := s.DoGet(endpoints[0].GetTicket(), &fakeDoGetStream{}); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	if scanTxID != "" {
+		t.Errorf("scan transaction ID = %q, want none", scanTxID)
+	}
+}
+
+func TestTableFunctionTicketInTransaction(t *testing.T) {
+	s := NewServer(catalog.NewStaticCatalog(), memory.DefaultAllocator, testLogger(), "")
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	// The transaction ID of the table_function_flight_info request travels
+	// in the ticket, as it does for table scans.
+	ctx := catalog.WithTransactionID(context.Background(), "tx-1")
+	result, err := s.buildTableFunctionFlightInfo(ctx, "main", "numbers", nil, schema)
+	if err != nil {
+		t.Fatalf("buildTableFunctionFlightInfo failed: %v", err)
+	}
+	var info flight.FlightInfo
+	if err := proto.Unmarshal(result.GetBody(), &info); err != nil {
+		t.Fatalf("failed to unmarshal FlightInfo: %v", err)
+	}
+	ticketData, err := DecodeTicket(info.GetEndpoint()[0].GetTicket().GetTicket())
+	if err != nil {
+		t.Fatalf("DecodeTicket failed: %v", err)
+	}
+	if ticketData.TransactionID != "tx-1" {
+		t.Errorf("ticket transaction ID = %q, want %q", ticketData.TransactionID, "tx-1")
+	}
+}
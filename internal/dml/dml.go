@@ -0,0 +1,105 @@
+// Package dml forwards batch DML calls of table decorators to the wrapped table.
+// Used by the catalog/rls and catalog/masking decorators, which implement the
+// batch DML interfaces for tables of either kind.
+package dml
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+// RowIDValues returns the values of a rowid column as int64.
+// Int64, Int32 and Uint64 columns are supported.
+// Returns catalog.ErrNullRowID if any value is null.
+func RowIDValues(arr arrow.Array) ([]int64, error) {
+	if arr.NullN() > 0 {
+		return nil, catalog.ErrNullRowID
+	}
+	ids := make([]int64, arr.Len())
+	switch a := arr.(type) {
+	case *array.Int64:
+		copy(ids, a.Int64Values())
+	case *array.Int32:
+		for i := range ids {
+			ids[i] = int64(a.Value(i))
+		}
+	case *array.Uint64:
+		for i := range ids {
+			ids[i] = int64(a.Value(i))
+		}
+	default:
+		return nil, fmt.Errorf("rowid column must be Int64, Int32, or Uint64, got %s", arr.DataType())
+	}
+	return ids, nil
+}
+
+// UpdateBatch updates table with rows, a batch holding the rowid column and
+// the new column values.
+//
+// Tables implementing catalog.UpdatableBatchTable receive rows as is. For tables
+// implementing only catalog.UpdatableTable, the rowids are passed separately and
+// the rowid column is removed from the data. Returns catalog.ErrUnimplemented if
+// table supports neither interface.
+func UpdateBatch(ctx context.Context, table catalog.Table, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	switch t := table.(type) {
+	case catalog.UpdatableBatchTable:
+		return t.Update(ctx, rows, opts)
+	case catalog.UpdatableTable:
+		idx := catalog.FindRowIDColumn(rows.Schema())
+		if idx < 0 {
+			return nil, fmt.Errorf("input has no rowid column")
+		}
+		ids, err := RowIDValues(rows.Column(idx))
+		if err != nil {
+			return nil, err
+		}
+		data := removeColumn(rows, idx)
+		defer data.Release()
+		rdr, err := array.NewRecordReader(data.Schema(), []arrow.RecordBatch{data})
+		if err != nil {
+			return nil, err
+		}
+		defer rdr.Release()
+		return t.Update(ctx, ids, rdr, opts)
+	default:
+		return nil, fmt.Errorf("table %q does not support UPDATE: %w", table.Name(), catalog.ErrUnimplemented)
+	}
+}
+
+// DeleteBatch deletes the rows of table whose rowids are in the rowid column of rows.
+//
+// Tables implementing catalog.DeletableBatchTable receive rows as is; tables
+// implementing only catalog.DeletableTable receive the rowid values. Returns
+// catalog.ErrUnimplemented if table supports neither interface.
+func DeleteBatch(ctx context.Context, table catalog.Table, rows arrow.RecordBatch, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	switch t := table.(type) {
+	case catalog.DeletableBatchTable:
+		return t.Delete(ctx, rows, opts)
+	case catalog.DeletableTable:
+		idx := catalog.FindRowIDColumn(rows.Schema())
+		if idx < 0 {
+			return nil, fmt.Errorf("input has no rowid column")
+		}
+		ids, err := RowIDValues(rows.Column(idx))
+		if err != nil {
+			return nil, err
+		}
+		return t.Delete(ctx, ids, opts)
+	default:
+		return nil, fmt.Errorf("table %q does not support DELETE: %w", table.Name(), catalog.ErrUnimplemented)
+	}
+}
+
+// removeColumn returns rec without column i.
+func removeColumn(rec arrow.RecordBatch, i int) arrow.RecordBatch {
+	fields := slices.Delete(slices.Clone(rec.Schema().Fields()), i, i+1)
+	cols := slices.Delete(slices.Clone(rec.Columns()), i, i+1)
+	meta := rec.Schema().Metadata()
+	return array.NewRecordBatch(arrow.NewSchema(fields, &meta), cols, rec.NumRows())
+}
@@ -0,0 +1,108 @@
+package dml
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/hugr-lab/airport-go/catalog"
+)
+
+func TestRowIDValues(t *testing.T) {
+	mem := memory.DefaultAllocator
+
+	b32 := array.NewInt32Builder(mem)
+	b32.AppendValues([]int32{3, 1}, nil)
+	i32 := b32.NewArray()
+	defer i32.Release()
+	ids, err := RowIDValues(i32)
+	if err != nil || !slices.Equal(ids, []int64{3, 1}) {
+		t.Errorf("RowIDValues(int32) = %v, %v, want [3 1]", ids, err)
+	}
+
+	b64 := array.NewInt64Builder(mem)
+	b64.AppendValues([]int64{1, 0}, []bool{true, false})
+	nulls := b64.NewArray()
+	defer nulls.Release()
+	if _, err := RowIDValues(nulls); !errors.Is(err, catalog.ErrNullRowID) {
+		t.Errorf("RowIDValues with null error = %v, want ErrNullRowID", err)
+	}
+
+	bs := array.NewStringBuilder(mem)
+	bs.Append("a")
+	strs := bs.NewArray()
+	defer strs.Release()
+	if _, err := RowIDValues(strs); err == nil {
+		t.Error("RowIDValues(string) should fail")
+	}
+}
+
+// legacyDMLTable implements only the legacy UpdatableTable and DeletableTable.
+type legacyDMLTable struct {
+	catalog.Table
+	ids     []int64
+	columns []string
+}
+
+func (l *legacyDMLTable) Update(ctx context.Context, rowIDs []int64, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	l.ids = rowIDs
+	for _, f := range rows.Schema().Fields() {
+		l.columns = append(l.columns, f.Name)
+	}
+	return &catalog.DMLResult{AffectedRows: int64(len(rowIDs))}, nil
+}
+
+func (l *legacyDMLTable) Delete(ctx context.Context, rowIDs []int64, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	l.ids = rowIDs
+	return &catalog.DMLResult{AffectedRows: int64(len(rowIDs))}, nil
+}
+
+func TestUpdateDeleteBatch(t *testing.T) {
+	mem := memory.DefaultAllocator
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "rowid", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{7, 9}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	rows := b.NewRecordBatch()
+	defer rows.Release()
+
+	ctx := context.Background()
+	base := catalog.NewStaticTable("t", "", schema, nil)
+	table := &legacyDMLTable{Table: base}
+
+	result, err := UpdateBatch(ctx, table, rows, nil)
+	if err != nil || result.AffectedRows != 2 {
+		t.Fatalf("UpdateBatch = %+v, %v", result, err)
+	}
+	if !slices.Equal(table.ids, []int64{7, 9}) || !slices.Equal(table.columns, []string{"name"}) {
+		t.Errorf("legacy Update got rowids %v and columns %v, want [7 9] and [name]", table.ids, table.columns)
+	}
+
+	table.ids = nil
+	if _, err := DeleteBatch(ctx, table, rows, nil); err != nil || !slices.Equal(table.ids, []int64{7, 9}) {
+		t.Errorf("DeleteBatch passed rowids %v, %v, want [7 9]", table.ids, err)
+	}
+
+	// Without a rowid column the legacy interfaces cannot be called.
+	noRowID := array.NewRecordBatch(arrow.NewSchema(schema.Fields()[1:], nil), rows.Columns()[1:], rows.NumRows())
+	defer noRowID.Release()
+	if _, err := DeleteBatch(ctx, table, noRowID, nil); err == nil {
+		t.Error("DeleteBatch without rowid column should fail")
+	}
+
+	if _, err := UpdateBatch(ctx, base, rows, nil); !errors.Is(err, catalog.ErrUnimplemented) {
+		t.Errorf("UpdateBatch of read-only table error = %v, want ErrUnimplemented", err)
+	}
+	if _, err := DeleteBatch(ctx, base, rows, nil); !errors.Is(err, catalog.ErrUnimplemented) {
+		t.Errorf("DeleteBatch of read-only table error = %v, want ErrUnimplemented", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Package metrics defines the metrics recorded by Airport servers.
+//
+// The server reports RPC requests, active streams, streamed and changed rows
+// and transaction outcomes to a Recorder. The metrics/prometheus package
+// provides a Recorder that is also a prometheus.Collector; other monitoring
+// systems can be supported by implementing Recorder.
+//
+// # Basic Usage
+//
+//	collector := prometheus.NewCollector(prometheus.Options{})
+//	registry.MustRegister(collector)
+//
+//	err := airport.NewServer(grpcServer, airport.ServerConfig{
+//		Catalog: cat,
+//		Metrics: collector,
+//	})
+//
+//	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOptions{}))
+package metrics
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Flight RPC method names passed to Recorder.
+const (
+	MethodDoAction      = "DoAction"
+	MethodDoExchange    = "DoExchange"
+	MethodDoGet         = "DoGet"
+	MethodGetFlightInfo = "GetFlightInfo"
+	MethodListFlights   = "ListFlights"
+)
+
+// UnknownName is the RPC name recorded for unknown action types and
+// DoExchange operations, so that clients cannot create arbitrary label values.
+const UnknownName = "unknown"
+
+// TransactionOutcome is how a transaction ended.
+type TransactionOutcome string
+
+// Transaction outcomes.
+const (
+	// TransactionCommitted is a transaction committed by the client.
+	TransactionCommitted TransactionOutcome = "committed"
+	// TransactionRolledBack is a transaction rolled back by the client.
+	TransactionRolledBack TransactionOutcome = "rolled_back"
+	// TransactionAborted is a transaction rolled back after a failed operation.
+	TransactionAborted TransactionOutcome = "aborted"
+	// TransactionTimedOut is a transaction rolled back after the idle timeout.
+	TransactionTimedOut TransactionOutcome = "timed_out"
+	// TransactionFailed is a transaction whose commit or rollback failed.
+	TransactionFailed TransactionOutcome = "failed"
+)
+
+// Recorder records server metrics.
+// Catalog is the name of the catalog serving the request (empty for an unnamed catalog).
+// Implementations MUST be goroutine-safe and should not block.
+type Recorder interface {
+	// RPC records a finished Flight RPC.
+	// Name is the action type for DoAction and the airport-operation header
+	// for DoExchange, empty for other methods.
+	RPC(catalog, method, name string, code codes.Code, duration time.Duration)
+
+	// StreamStarted records the start of a DoGet or DoExchange stream.
+	StreamStarted(catalog, method string)
+
+	// StreamFinished records the end of a stream reported by StreamStarted.
+	StreamFinished(catalog, method string)
+
+	// RowsStreamed records the rows and bytes a DoGet sent for a table,
+	// or a table function, of schema.
+	RowsStreamed(catalog, schema, table string, rows, bytes int64)
+
+	// RowsChanged records the rows affected by a DML operation
+	// ("insert", "update" or "delete") on a table of schema.
+	RowsChanged(catalog, schema, table, operation string, rows int64)
+
+	// TransactionEnded records the outcome of a transaction.
+	TransactionEnded(catalog string, outcome TransactionOutcome)
+}
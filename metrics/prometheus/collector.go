@@ -0,0 +1,168 @@
+// Package prometheus exports Airport server metrics to Prometheus.
+//
+// Collector implements metrics.Recorder for the server and prometheus.Collector
+// for a registry the application serves on its own HTTP mux:
+//
+//	collector := prometheus.NewCollector(prometheus.Options{})
+//	registry := prom.NewRegistry()
+//	registry.MustRegister(collector)
+//	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOptions{}))
+//
+//	err := airport.NewServer(grpcServer, airport.ServerConfig{
+//		Catalog: cat,
+//		Metrics: collector,
+//	})
+//
+// Exported metrics (with the default "airport" namespace):
+//
+//	airport_rpc_requests_total{catalog,method,name,code}
+//	airport_rpc_duration_seconds{catalog,method,name}
+//	airport_active_streams{catalog,method}
+//	airport_streamed_rows_total{catalog,schema,table}
+//	airport_streamed_bytes_total{catalog,schema,table}
+//	airport_dml_rows_total{catalog,schema,table,operation}
+//	airport_transactions_total{catalog,outcome}
+package prometheus
+
+import (
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+
+	"github.com/hugr-lab/airport-go/metrics"
+)
+
+// Options configures a Collector.
+type Options struct {
+	// Namespace prefixes the metric names. Default: "airport".
+	Namespace string
+
+	// ConstLabels are added to every metric, e.g. the server instance.
+	ConstLabels prom.Labels
+
+	// DurationBuckets are the buckets of the RPC duration histogram in seconds.
+	// Default: prometheus.DefBuckets.
+	DurationBuckets []float64
+}
+
+// Collector records Airport server metrics and exposes them to Prometheus.
+// It is safe for concurrent use.
+type Collector struct {
+	requests      *prom.CounterVec
+	duration      *prom.HistogramVec
+	activeStreams *prom.GaugeVec
+	streamedRows  *prom.CounterVec
+	streamedBytes *prom.CounterVec
+	dmlRows       *prom.CounterVec
+	transactions  *prom.CounterVec
+}
+
+var (
+	_ metrics.Recorder = (*Collector)(nil)
+	_ prom.Collector   = (*Collector)(nil)
+)
+
+// NewCollector creates a Collector. Register it with a prometheus.Registerer
+// to export its metrics.
+func NewCollector(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "airport"
+	}
+	if len(opts.DurationBuckets) == 0 {
+		opts.DurationBuckets = prom.DefBuckets
+	}
+	counter := func(name, help string, labels ...string) *prom.CounterVec {
+		return prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: opts.ConstLabels,
+		}, labels)
+	}
+
+	return &Collector{
+		requests: counter("rpc_requests_total",
+			"Flight RPCs by method, action or operation name, and status code.",
+			"catalog", "method", "name", "code"),
+		duration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "rpc_duration_seconds",
+			Help:        "Duration of Flight RPCs by method and action or operation name.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.DurationBuckets,
+		}, []string{"catalog", "method", "name"}),
+		activeStreams: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace:   opts.Namespace,
+			Name:        "active_streams",
+			Help:        "DoGet and DoExchange streams in progress.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"catalog", "method"}),
+		streamedRows: counter("streamed_rows_total",
+			"Rows sent by DoGet per table or table function.",
+			"catalog", "schema", "table"),
+		streamedBytes: counter("streamed_bytes_total",
+			"Arrow buffer bytes sent by DoGet per table or table function.",
+			"catalog", "schema", "table"),
+		dmlRows: counter("dml_rows_total",
+			"Rows affected by DML operations per table.",
+			"catalog", "schema", "table", "operation"),
+		transactions: counter("transactions_total",
+			"Ended transactions by outcome.",
+			"catalog", "outcome"),
+	}
+}
+
+// collectors returns the metric vectors of c.
+func (c *Collector) collectors() []prom.Collector {
+	return []prom.Collector{
+		c.requests, c.duration, c.activeStreams,
+		c.streamedRows, c.streamedBytes, c.dmlRows, c.transactions,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	for _, m := range c.collectors() {
+		m.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	for _, m := range c.collectors() {
+		m.Collect(ch)
+	}
+}
+
+// RPC implements metrics.Recorder.
+func (c *Collector) RPC(catalog, method, name string, code codes.Code, duration time.Duration) {
+	c.requests.WithLabelValues(catalog, method, name, code.String()).Inc()
+	c.duration.WithLabelValues(catalog, method, name).Observe(duration.Seconds())
+}
+
+// StreamStarted implements metrics.Recorder.
+func (c *Collector) StreamStarted(catalog, method string) {
+	c.activeStreams.WithLabelValues(catalog, method).Inc()
+}
+
+// StreamFinished implements metrics.Recorder.
+func (c *Collector) StreamFinished(catalog, method string) {
+	c.activeStreams.WithLabelValues(catalog, method).Dec()
+}
+
+// RowsStreamed implements metrics.Recorder.
+func (c *Collector) RowsStreamed(catalog, schema, table string, rows, bytes int64) {
+	c.streamedRows.WithLabelValues(catalog, schema, table).Add(float64(rows))
+	c.streamedBytes.WithLabelValues(catalog, schema, table).Add(float64(bytes))
+}
+
+// RowsChanged implements metrics.Recorder.
+func (c *Collector) RowsChanged(catalog, schema, table, operation string, rows int64) {
+	c.dmlRows.WithLabelValues(catalog, schema, table, operation).Add(float64(rows))
+}
+
+// TransactionEnded implements metrics.Recorder.
+func (c *Collector) TransactionEnded(catalog string, outcome metrics.TransactionOutcome) {
+	c.transactions.WithLabelValues(catalog, string(outcome)).Inc()
+}
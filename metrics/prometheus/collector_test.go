@@ -0,0 +1,71 @@
+package prometheus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+
+	"github.com/hugr-lab/airport-go/metrics"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(Options{ConstLabels: prom.Labels{"instance": "a"}})
+	registry := prom.NewPedanticRegistry()
+	registry.MustRegister(c)
+
+	c.RPC("sales", metrics.MethodDoAction, "list_schemas", codes.OK, 20*time.Millisecond)
+	c.RPC("sales", metrics.MethodDoAction, "list_schemas", codes.OK, 30*time.Millisecond)
+	c.RPC("sales", metrics.MethodDoExchange, "insert", codes.PermissionDenied, time.Millisecond)
+	c.StreamStarted("sales", metrics.MethodDoGet)
+	c.StreamStarted("sales", metrics.MethodDoGet)
+	c.StreamFinished("sales", metrics.MethodDoGet)
+	c.RowsStreamed("sales", "main", "orders", 100, 800)
+	c.RowsStreamed("sales", "main", "orders", 50, 400)
+	c.RowsChanged("sales", "main", "orders", "delete", 3)
+	c.TransactionEnded("sales", metrics.TransactionCommitted)
+
+	want := `
+# HELP airport_active_streams DoGet and DoExchange streams in progress.
+# TYPE airport_active_streams gauge
+airport_active_streams{catalog="sales",instance="a",method="DoGet"} 1
+# HELP airport_dml_rows_total Rows affected by DML operations per table.
+# TYPE airport_dml_rows_total counter
+airport_dml_rows_total{catalog="sales",instance="a",operation="delete",schema="main",table="orders"} 3
+# HELP airport_rpc_requests_total Flight RPCs by method, action or operation name, and status code.
+# TYPE airport_rpc_requests_total counter
+airport_rpc_requests_total{catalog="sales",code="OK",instance="a",method="DoAction",name="list_schemas"} 2
+airport_rpc_requests_total{catalog="sales",code="PermissionDenied",instance="a",method="DoExchange",name="insert"} 1
+# HELP airport_streamed_bytes_total Arrow buffer bytes sent by DoGet per table or table function.
+# TYPE airport_streamed_bytes_total counter
+airport_streamed_bytes_total{catalog="sales",instance="a",schema="main",table="orders"} 1200
+# HELP airport_streamed_rows_total Rows sent by DoGet per table or table function.
+# TYPE airport_streamed_rows_total counter
+airport_streamed_rows_total{catalog="sales",instance="a",schema="main",table="orders"} 150
+# HELP airport_transactions_total Ended transactions by outcome.
+# TYPE airport_transactions_total counter
+airport_transactions_total{catalog="sales",instance="a",outcome="committed"} 1
+`
+	names := []string{
+		"airport_active_streams", "airport_dml_rows_total", "airport_rpc_requests_total",
+		"airport_streamed_bytes_total", "airport_streamed_rows_total", "airport_transactions_total",
+	}
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), names...); err != nil {
+		t.Error(err)
+	}
+
+	if n := testutil.CollectAndCount(c, "airport_rpc_duration_seconds"); n != 2 {
+		t.Errorf("duration series = %d, want 2", n)
+	}
+}
+
+func TestCollectorNamespace(t *testing.T) {
+	c := NewCollector(Options{Namespace: "flight"})
+	c.TransactionEnded("", metrics.TransactionTimedOut)
+	if n := testutil.CollectAndCount(c, "flight_transactions_total"); n != 1 {
+		t.Errorf("flight_transactions_total series = %d, want 1", n)
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 
 	"github.com/hugr-lab/airport-go/auth"
 	"github.com/hugr-lab/airport-go/catalog"
 	"github.com/hugr-lab/airport-go/flight"
+	"github.com/hugr-lab/airport-go/metrics"
 )
 
 // MultiCatalogServer wraps flight.MultiCatalogServer to provide
@@ -67,6 +70,15 @@ type MultiCatalogServerConfig struct {
 	// Must implement CatalogTransactionManager for multi-catalog support.
 	TransactionManager catalog.CatalogTransactionManager
 
+	// MultiStatementTransactions keeps transactions open across DML operations
+	// until commit_transaction or rollback_transaction. Optional, defaults to
+	// auto-commit. See ServerConfig.MultiStatementTransactions.
+	MultiStatementTransactions bool
+
+	// TransactionTimeout is the idle time after which multi-statement transactions are
+	// rolled back. Optional, defaults to DefaultTransactionTimeout. See ServerConfig.TransactionTimeout.
+	TransactionTimeout time.Duration
+
 	// Auth is the authenticator for validating requests. Optional.
 	// If the authenticator also implements CatalogAuthorizer, AuthorizeCatalog
 	// is called after Authenticate to perform per-catalog authorization.
@@ -74,6 +86,55 @@ type MultiCatalogServerConfig struct {
 
 	// MaxMessageSize is the maximum gRPC message size. Optional.
 	MaxMessageSize int
+
+	// BatchTargetRows is the target number of rows per DoGet record batch. Optional.
+	// See ServerConfig.BatchTargetRows.
+	BatchTargetRows int
+
+	// BatchTargetBytes is the target size in bytes of each DoGet record batch. Optional,
+	// defaults to half of MaxMessageSize if that is set. See ServerConfig.BatchTargetBytes.
+	BatchTargetBytes int64
+
+	// Compression is the default Arrow IPC buffer compression for result data. Optional.
+	// See ServerConfig.Compression.
+	Compression Compression
+
+	// CatalogCompression overrides Compression for individual catalogs, keyed by
+	// catalog name (empty string for the default catalog). Optional.
+	// Also applies to catalogs added at runtime via AddCatalog().
+	CatalogCompression map[string]Compression
+
+	// TicketSigner signs the tickets returned for scans in all catalogs. Optional.
+	// See ServerConfig.TicketSigner.
+	TicketSigner *TicketSigner
+
+	// TicketStore keeps scan tickets of all catalogs server-side. Optional.
+	// Handles route DoGet to the catalog that issued them.
+	// See ServerConfig.TicketStore.
+	TicketStore TicketStore
+
+	// Authorizer checks operations in all catalogs. Optional.
+	// Resource.Catalog names the catalog of each operation.
+	// See ServerConfig.Authorizer.
+	Authorizer auth.Authorizer
+
+	// TracerProvider creates the spans of RPCs in all catalogs. Optional.
+	// See ServerConfig.TracerProvider.
+	TracerProvider trace.TracerProvider
+
+	// Metrics records the metrics of all catalogs. Optional.
+	// See ServerConfig.Metrics.
+	Metrics metrics.Recorder
+
+	// AuditSink records audited operations of all catalogs. Optional.
+	// Record.Catalog names the catalog of each operation.
+	// See ServerConfig.AuditSink.
+	AuditSink AuditSink
+
+	// RateLimits limits each principal in each catalog. Optional.
+	// RateLimits.Catalogs is keyed by the airport-catalog header.
+	// See ServerConfig.RateLimits.
+	RateLimits *RateLimits
 }
 
 // NewMultiCatalogServer creates and registers a multi-catalog Flight server.
@@ -138,6 +199,9 @@ func NewMultiCatalogServer(grpcServer *grpc.Server, config MultiCatalogServerCon
 		"has_auth", config.Auth != nil,
 		"has_tx_manager", config.TransactionManager != nil,
 		"max_message_size", config.MaxMessageSize,
+		"compression", string(config.Compression),
+		"signed_tickets", config.TicketSigner != nil,
+		"has_authorizer", config.Authorizer != nil,
 	)
 
 	return &MultiCatalogServer{
@@ -149,6 +213,25 @@ func NewMultiCatalogServer(grpcServer *grpc.Server, config MultiCatalogServerCon
 
 // validateMultiCatalogConfig checks that required MultiCatalogServerConfig fields are valid.
 func validateMultiCatalogConfig(config MultiCatalogServerConfig) error {
+	if config.BatchTargetRows < 0 || config.BatchTargetBytes < 0 {
+		return fmt.Errorf("batch targets must not be negative")
+	}
+	if config.TransactionTimeout < 0 {
+		return fmt.Errorf("transaction timeout must not be negative")
+	}
+	if err := config.Compression.Validate(); err != nil {
+		return err
+	}
+	for name, c := range config.CatalogCompression {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("catalog %q: %w", name, err)
+		}
+	}
+	if config.RateLimits != nil {
+		if err := config.RateLimits.Validate(); err != nil {
+			return err
+		}
+	}
 	if len(config.Catalogs) == 0 {
 		return nil
 	}
@@ -172,16 +255,35 @@ func validateMultiCatalogConfig(config MultiCatalogServerConfig) error {
 // newServerForCatalog creates a flight.Server for the given catalog and configuration.
 // If TransactionManager is set in config, wraps it with an adapter for catalog context.
 func newServerForCatalog(cat catalog.Catalog, config MultiCatalogServerConfig) *flight.Server {
+	var server *flight.Server
 	if config.TransactionManager != nil {
 		// Create adapter that implements catalog.TransactionManager
 		adapter := &catalogTxManagerAdapter{
 			ctm:         config.TransactionManager,
 			catalogName: getCatalogName(cat),
 		}
-		return flight.NewServerWithTxManager(cat, config.Allocator, config.Logger, config.Address, adapter)
+		server = flight.NewServerWithTxManager(cat, config.Allocator, config.Logger, config.Address, adapter)
+	} else {
+		server = flight.NewServer(cat, config.Allocator, config.Logger, config.Address)
+	}
+	server.SetBatchTarget(config.BatchTargetRows, batchTargetBytes(config.BatchTargetBytes, config.MaxMessageSize))
+	server.SetMultiStatementTransactions(config.MultiStatementTransactions)
+	if config.TransactionTimeout > 0 {
+		server.SetTransactionTimeout(config.TransactionTimeout)
 	}
 
-	return flight.NewServer(cat, config.Allocator, config.Logger, config.Address)
+	compression := config.Compression
+	if c, ok := config.CatalogCompression[getCatalogName(cat)]; ok {
+		compression = c
+	}
+	server.SetCompression(compression)
+	server.SetTicketSigner(config.TicketSigner)
+	server.SetTicketStore(config.TicketStore)
+	server.SetAuthorizer(config.Authorizer)
+	server.SetTracerProvider(config.TracerProvider)
+	server.SetMetrics(config.Metrics)
+	server.SetAuditSink(config.AuditSink)
+	return server
 }
 
 // getCatalogName returns the name of a catalog if it implements NamedCatalog.
@@ -192,7 +294,7 @@ func getCatalogName(cat catalog.Catalog) string {
 	return ""
 }
 
-// MultiCatalogServerOptions returns gRPC server options with authentication interceptors
+// MultiCatalogServerOptions returns gRPC server options with authentication and rate limit interceptors
 // configured for multi-catalog support.
 // Use this when creating a gRPC server if you want authentication enabled.
 //
@@ -213,6 +315,14 @@ func MultiCatalogServerOptions(config MultiCatalogServerConfig) []grpc.ServerOpt
 		grpc.UnaryInterceptor(flight.UnaryServerInterceptor(config.Auth)),
 		grpc.StreamInterceptor(flight.StreamServerInterceptor(config.Auth)),
 	)
+	// Add rate limit interceptors after auth, so limits apply to the identity
+	if config.RateLimits != nil {
+		limiter := rateLimiter(config.RateLimits)
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(flight.RateLimitUnaryInterceptor(limiter)),
+			grpc.ChainStreamInterceptor(flight.RateLimitStreamInterceptor(limiter)),
+		)
+	}
 	// Add max message size if specified
 	if config.MaxMessageSize > 0 {
 		opts = append(opts,
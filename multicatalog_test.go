@@ -121,6 +121,29 @@ func TestValidateMultiCatalogConfig_DuplicateDefaultCatalogs(t *testing.T) {
 	}
 }
 
+func TestValidateMultiCatalogConfig_NegativeBatchTarget(t *testing.T) {
+	config := MultiCatalogServerConfig{
+		Catalogs:        []catalog.Catalog{&mockCatalog{name: "sales"}},
+		BatchTargetRows: -1,
+	}
+
+	if err := validateMultiCatalogConfig(config); err == nil {
+		t.Fatal("expected error for negative batch target")
+	}
+}
+
+func TestValidateMultiCatalogConfig_InvalidCompression(t *testing.T) {
+	config := MultiCatalogServerConfig{
+		Catalogs:           []catalog.Catalog{&mockCatalog{name: "sales"}},
+		Compression:        CompressionLZ4,
+		CatalogCompression: map[string]Compression{"sales": "gzip"},
+	}
+
+	if err := validateMultiCatalogConfig(config); err == nil {
+		t.Fatal("expected error for unsupported catalog compression")
+	}
+}
+
 func TestGetCatalogName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"runtime"
+	"sync"
+	"weak"
 
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"google.golang.org/grpc"
@@ -73,6 +76,18 @@ func NewServer(grpcServer *grpc.Server, config ServerConfig) error {
 	} else {
 		flightServer = flight.NewServer(config.Catalog, allocator, logger, config.Address)
 	}
+	flightServer.SetBatchTarget(config.BatchTargetRows, batchTargetBytes(config.BatchTargetBytes, config.MaxMessageSize))
+	flightServer.SetMultiStatementTransactions(config.MultiStatementTransactions)
+	if config.TransactionTimeout > 0 {
+		flightServer.SetTransactionTimeout(config.TransactionTimeout)
+	}
+	flightServer.SetCompression(config.Compression)
+	flightServer.SetTicketSigner(config.TicketSigner)
+	flightServer.SetTicketStore(config.TicketStore)
+	flightServer.SetAuthorizer(config.Authorizer)
+	flightServer.SetTracerProvider(config.TracerProvider)
+	flightServer.SetMetrics(config.Metrics)
+	flightServer.SetAuditSink(config.AuditSink)
 
 	// Register Flight service
 	flight.RegisterFlightServer(grpcServer, flightServer)
@@ -82,6 +97,9 @@ func NewServer(grpcServer *grpc.Server, config ServerConfig) error {
 		"has_auth", config.Auth != nil,
 		"has_tx_manager", config.TransactionManager != nil,
 		"max_message_size", config.MaxMessageSize,
+		"compression", string(config.Compression),
+		"signed_tickets", config.TicketSigner != nil,
+		"has_authorizer", config.Authorizer != nil,
 	)
 
 	return nil
@@ -92,10 +110,34 @@ func validateConfig(config ServerConfig) error {
 	if config.Catalog == nil {
 		return fmt.Errorf("catalog is required")
 	}
+	if config.BatchTargetRows < 0 || config.BatchTargetBytes < 0 {
+		return fmt.Errorf("batch targets must not be negative")
+	}
+	if config.TransactionTimeout < 0 {
+		return fmt.Errorf("transaction timeout must not be negative")
+	}
+	if err := config.Compression.Validate(); err != nil {
+		return err
+	}
+	if config.RateLimits != nil {
+		if err := config.RateLimits.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// ServerOptions returns gRPC server options with authentication interceptors.
+// batchTargetBytes returns the byte target of DoGet record batches. Without an
+// explicit target, batches are sliced to half of a set MaxMessageSize, which
+// leaves room for IPC framing so large table batches fit in one message.
+func batchTargetBytes(target int64, maxMessageSize int) int64 {
+	if target == 0 && maxMessageSize > 0 {
+		return int64(maxMessageSize) / 2
+	}
+	return target
+}
+
+// ServerOptions returns gRPC server options with authentication and rate limit interceptors.
 // Use this when creating a gRPC server if you want authentication enabled.
 //
 // Example:
@@ -116,6 +158,15 @@ func ServerOptions(config ServerConfig) []grpc.ServerOption {
 		grpc.StreamInterceptor(flight.StreamServerInterceptor(config.Auth)),
 	)
 
+	// Add rate limit interceptors after auth, so limits apply to the identity
+	if config.RateLimits != nil {
+		limiter := rateLimiter(config.RateLimits)
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(flight.RateLimitUnaryInterceptor(limiter)),
+			grpc.ChainStreamInterceptor(flight.RateLimitStreamInterceptor(limiter)),
+		)
+	}
+
 	// Add max message size if specified
 	if config.MaxMessageSize > 0 {
 		opts = append(opts,
@@ -126,3 +177,30 @@ func ServerOptions(config ServerConfig) []grpc.ServerOption {
 
 	return opts
 }
+
+var (
+	rateLimitersMu sync.Mutex
+	rateLimiters   = make(map[weak.Pointer[RateLimits]]*flight.RateLimiter)
+)
+
+// rateLimiter returns the limiter enforcing limits. It is created on first use
+// and shared by every ServerOptions and MultiCatalogServerOptions call with the
+// same RateLimits, so that calling them more than once does not multiply the
+// limits. The limiter is dropped when limits is garbage collected.
+func rateLimiter(limits *RateLimits) *flight.RateLimiter {
+	key := weak.Make(limits)
+
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+	if limiter, ok := rateLimiters[key]; ok {
+		return limiter
+	}
+	limiter := flight.NewRateLimiter(*limits)
+	rateLimiters[key] = limiter
+	runtime.AddCleanup(limits, func(key weak.Pointer[RateLimits]) {
+		rateLimitersMu.Lock()
+		defer rateLimitersMu.Unlock()
+		delete(rateLimiters, key)
+	}, key)
+	return limiter
+}
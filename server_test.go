@@ -0,0 +1,150 @@
+package airport
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	arrowflight "github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/flight"
+)
+
+// largeBatchRows is the row count of the single batch returned by
+// largeBatchCatalog, 1.6MB of int64 values.
+const largeBatchRows = 200_000
+
+// largeBatchCatalog returns a catalog with table main.numbers, which returns
+// all its rows in one batch.
+func largeBatchCatalog(t *testing.T) catalog.Catalog {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	cat, err := NewCatalogBuilder().
+		Schema("main").
+		SimpleTable(SimpleTableDef{
+			Name:   "numbers",
+			Schema: schema,
+			ScanFunc: func(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+				builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+				defer builder.Release()
+				ids := builder.Field(0).(*array.Int64Builder)
+				for i := range largeBatchRows {
+					ids.Append(int64(i))
+				}
+				record := builder.NewRecordBatch()
+				defer record.Release()
+				return array.NewRecordReader(schema, []arrow.RecordBatch{record})
+			},
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	return cat
+}
+
+// scanRows serves a gRPC server with opts, registered by register, and
+// returns the number of rows DoGet streams of main.numbers.
+func scanRows(t *testing.T, opts []grpc.ServerOption, register func(*grpc.Server) error) int64 {
+	t.Helper()
+	lis := bufconn.Listen(4 << 20)
+	grpcServer := grpc.NewServer(opts...)
+	if err := register(grpcServer); err != nil {
+		t.Fatalf("registering server failed: %v", err)
+	}
+	go func() { _ = grpcServer.Serve(lis) }()
+	defer grpcServer.Stop()
+
+	client, err := arrowflight.NewClientWithMiddleware("passthrough:///bufnet", nil, nil,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("creating client failed: %v", err)
+	}
+	defer client.Close()
+
+	ticket, _ := flight.EncodeTableTicket("", "main", "numbers")
+	stream, err := client.DoGet(context.Background(), &arrowflight.Ticket{Ticket: ticket})
+	if err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+	reader, err := arrowflight.NewRecordReader(stream)
+	if err != nil {
+		t.Fatalf("reading DoGet stream failed: %v", err)
+	}
+	defer reader.Release()
+	var rows int64
+	for reader.Next() {
+		rows += reader.RecordBatch().NumRows()
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("reading DoGet stream failed: %v", err)
+	}
+	return rows
+}
+
+func TestBatchTargetBytes(t *testing.T) {
+	tests := []struct {
+		target         int64
+		maxMessageSize int
+		want           int64
+	}{
+		{0, 0, 0},
+		{0, 16 << 20, 8 << 20},
+		{1 << 20, 16 << 20, 1 << 20},
+		{1 << 20, 0, 1 << 20},
+	}
+	for _, tt := range tests {
+		if got := batchTargetBytes(tt.target, tt.maxMessageSize); got != tt.want {
+			t.Errorf("batchTargetBytes(%d, %d) = %d, want %d", tt.target, tt.maxMessageSize, got, tt.want)
+		}
+	}
+}
+
+func TestMaxMessageSizeBatchTarget(t *testing.T) {
+	const maxMessageSize = 1 << 20
+
+	t.Run("Server", func(t *testing.T) {
+		config := ServerConfig{Catalog: largeBatchCatalog(t), MaxMessageSize: maxMessageSize}
+		rows := scanRows(t, ServerOptions(config), func(s *grpc.Server) error {
+			return NewServer(s, config)
+		})
+		if rows != largeBatchRows {
+			t.Errorf("got %d rows, want %d", rows, largeBatchRows)
+		}
+	})
+
+	t.Run("MultiCatalogServer", func(t *testing.T) {
+		config := MultiCatalogServerConfig{Catalogs: []catalog.Catalog{largeBatchCatalog(t)}, MaxMessageSize: maxMessageSize}
+		rows := scanRows(t, MultiCatalogServerOptions(config), func(s *grpc.Server) error {
+			_, err := NewMultiCatalogServer(s, config)
+			return err
+		})
+		if rows != largeBatchRows {
+			t.Errorf("got %d rows, want %d", rows, largeBatchRows)
+		}
+	})
+}
+
+func TestRateLimiterShared(t *testing.T) {
+	limits := &RateLimits{Default: RateLimit{RequestsPerSecond: 1}}
+	other := &RateLimits{Default: RateLimit{RequestsPerSecond: 1}}
+
+	// Every options call with the same limits must enforce them with one
+	// limiter, or each call would add its own budget.
+	first := rateLimiter(limits)
+	if rateLimiter(limits) != first {
+		t.Error("limiter of the same RateLimits was created twice")
+	}
+	if rateLimiter(other) == first {
+		t.Error("limiter shared by different RateLimits")
+	}
+}
@@ -3,17 +3,24 @@ package airport_test
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net"
 	"sync"
 	"testing"
 
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	airport "github.com/hugr-lab/airport-go"
 	"github.com/hugr-lab/airport-go/catalog"
+	"github.com/hugr-lab/airport-go/catalog/txmanager"
 	"github.com/hugr-lab/airport-go/internal/msgpack"
 )
 
@@ -82,18 +89,23 @@ func setupTransactionTestEnv(t *testing.T) *transactionTestEnv {
 	txManager := newMockTransactionManager()
 
 	// Create catalog with a simple table for testing transactions
-	cat := txTestCatalog(t)
+	env := startTransactionTestEnv(t, airport.ServerConfig{
+		Catalog:            txTestCatalog(t),
+		TransactionManager: txManager,
+		Address:            "localhost:0",
+	})
+	env.txManager = txManager
+	return env
+}
+
+// startTransactionTestEnv serves an Airport server with config and connects a client.
+func startTransactionTestEnv(t *testing.T, config airport.ServerConfig) *transactionTestEnv {
+	t.Helper()
 
 	// Create gRPC server
 	server := grpc.NewServer()
 
-	// Create and register Airport server with transaction manager
-	config := airport.ServerConfig{
-		Catalog:            cat,
-		TransactionManager: txManager,
-		Address:            "localhost:0",
-	}
-
+	// Create and register Airport server
 	if err := airport.NewServer(server, config); err != nil {
 		t.Fatalf("failed to create server: %v", err)
 	}
@@ -116,14 +128,11 @@ func setupTransactionTestEnv(t *testing.T) *transactionTestEnv {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	client := flight.NewFlightServiceClient(conn)
-
 	return &transactionTestEnv{
-		server:    server,
-		client:    client,
-		conn:      conn,
-		listener:  listener,
-		txManager: txManager,
+		server:   server,
+		client:   flight.NewFlightServiceClient(conn),
+		conn:     conn,
+		listener: listener,
 	}
 }
 
@@ -416,6 +425,227 @@ func TestDMLWithTransaction(t *testing.T) {
 	}
 }
 
+// TestMultiStatementTransactionCommit tests that DML operations sharing a transaction
+// ID are committed together by the commit_transaction action.
+func TestMultiStatementTransactionCommit(t *testing.T) {
+	txm := txmanager.New(txmanager.Options{})
+	defer txm.Close()
+	table := &txItemsTable{txm: txm}
+
+	env := startTransactionTestEnv(t, airport.ServerConfig{
+		Catalog:                    txItemsCatalog(t, table),
+		TransactionManager:         txm.ForCatalog(""),
+		MultiStatementTransactions: true,
+		Address:                    "localhost:0",
+	})
+	defer env.cleanup()
+
+	ctx := context.Background()
+	txID := createTransaction(t, env.client)
+
+	// Each statement is applied only when the transaction commits.
+	insertItems(t, env.client, txID, 1, 2)
+	insertItems(t, env.client, txID, 3)
+	if got := table.committedRows(); got != 0 {
+		t.Fatalf("committed rows before commit = %d, want 0", got)
+	}
+	if state, _, _ := txm.GetTransactionStatus(ctx, txID); state != catalog.TransactionActive {
+		t.Fatalf("state before commit = %q, want active", state)
+	}
+
+	if got := endTransaction(t, env.client, "commit_transaction", txID); got != string(catalog.TransactionCommitted) {
+		t.Fatalf("commit_transaction status = %q, want committed", got)
+	}
+	if got := table.committedRows(); got != 3 {
+		t.Errorf("committed rows after commit = %d, want 3", got)
+	}
+
+	// A rolled back transaction discards all its statements.
+	txID = createTransaction(t, env.client)
+	insertItems(t, env.client, txID, 4)
+	insertItems(t, env.client, txID, 5)
+	if got := endTransaction(t, env.client, "rollback_transaction", txID); got != string(catalog.TransactionAborted) {
+		t.Fatalf("rollback_transaction status = %q, want aborted", got)
+	}
+	if got := table.committedRows(); got != 3 {
+		t.Errorf("committed rows after rollback = %d, want 3", got)
+	}
+}
+
+// TestAutoCommitTransaction tests that without MultiStatementTransactions every DML
+// operation commits its transaction, as DuckDB Airport clients expect.
+func TestAutoCommitTransaction(t *testing.T) {
+	txm := txmanager.New(txmanager.Options{})
+	defer txm.Close()
+	table := &txItemsTable{txm: txm}
+
+	env := startTransactionTestEnv(t, airport.ServerConfig{
+		Catalog:            txItemsCatalog(t, table),
+		TransactionManager: txm.ForCatalog(""),
+		Address:            "localhost:0",
+	})
+	defer env.cleanup()
+
+	txID := createTransaction(t, env.client)
+	insertItems(t, env.client, txID, 1, 2)
+	if got := table.committedRows(); got != 2 {
+		t.Errorf("committed rows after insert = %d, want 2", got)
+	}
+	if state, _, _ := txm.GetTransactionStatus(context.Background(), txID); state != catalog.TransactionCommitted {
+		t.Errorf("state after insert = %q, want committed", state)
+	}
+}
+
+// createTransaction runs the create_transaction action and returns the transaction ID.
+func createTransaction(t *testing.T, client flight.FlightServiceClient) string {
+	t.Helper()
+
+	body, _ := msgpack.Encode(map[string]any{"catalog_name": ""})
+	stream, err := client.DoAction(context.Background(), &flight.Action{Type: "create_transaction", Body: body})
+	if err != nil {
+		t.Fatalf("create_transaction failed: %v", err)
+	}
+	result, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive result: %v", err)
+	}
+	var response struct {
+		Identifier string `msgpack:"identifier"`
+	}
+	if err := msgpack.Decode(result.Body, &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Identifier == "" {
+		t.Fatal("expected non-empty transaction identifier")
+	}
+	return response.Identifier
+}
+
+// endTransaction runs the commit_transaction or rollback_transaction action and
+// returns the resulting status.
+func endTransaction(t *testing.T, client flight.FlightServiceClient, actionType, txID string) string {
+	t.Helper()
+
+	body, _ := msgpack.Encode(map[string]any{"transaction_id": txID})
+	stream, err := client.DoAction(context.Background(), &flight.Action{Type: actionType, Body: body})
+	if err != nil {
+		t.Fatalf("%s failed: %v", actionType, err)
+	}
+	result, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("%s failed: %v", actionType, err)
+	}
+	var response struct {
+		Status string `msgpack:"status"`
+	}
+	if err := msgpack.Decode(result.Body, &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return response.Status
+}
+
+// insertItems inserts ids into test_schema.items in transaction txID through
+// DoExchange, as the Airport extension does for INSERT statements.
+func insertItems(t *testing.T, client flight.FlightServiceClient, txID string, ids ...int64) {
+	t.Helper()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"airport-operation", "insert",
+		"airport-flight-path", "test_schema/items",
+		"airport-transaction-id", txID,
+	)
+	stream, err := client.DoExchange(ctx)
+	if err != nil {
+		t.Fatalf("DoExchange failed: %v", err)
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, txItemsSchema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+	record := builder.NewRecordBatch()
+	defer record.Release()
+
+	writer := flight.NewRecordWriter(stream, ipc.WithSchema(txItemsSchema))
+	if err := writer.Write(record); err != nil {
+		t.Fatalf("failed to write rows: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close writer: %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend failed: %v", err)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			return
+		} else if err != nil {
+			t.Fatalf("INSERT failed: %v", err)
+		}
+	}
+}
+
+var txItemsSchema = arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+// txItemsTable is an insertable table that applies inserts when their
+// transaction commits.
+type txItemsTable struct {
+	txm *txmanager.Manager
+
+	mu        sync.Mutex
+	committed int64
+}
+
+func (t *txItemsTable) Name() string    { return "items" }
+func (t *txItemsTable) Comment() string { return "Table applying inserts on commit" }
+func (t *txItemsTable) ArrowSchema(columns []string) *arrow.Schema {
+	return catalog.ProjectSchema(txItemsSchema, columns)
+}
+
+func (t *txItemsTable) Scan(ctx context.Context, opts *catalog.ScanOptions) (array.RecordReader, error) {
+	return array.NewRecordReader(txItemsSchema, nil)
+}
+
+func (t *txItemsTable) Insert(ctx context.Context, rows array.RecordReader, opts *catalog.DMLOptions) (*catalog.DMLResult, error) {
+	var n int64
+	for rows.Next() {
+		n += rows.RecordBatch().NumRows()
+	}
+	apply := func(context.Context) error {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.committed += n
+		return nil
+	}
+	if txID, ok := catalog.TransactionIDFromContext(ctx); ok {
+		if err := t.txm.OnCommit(txID, apply); err != nil {
+			return nil, err
+		}
+	} else if err := apply(ctx); err != nil {
+		return nil, err
+	}
+	return &catalog.DMLResult{AffectedRows: n}, nil
+}
+
+func (t *txItemsTable) committedRows() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.committed
+}
+
+// txItemsCatalog creates a catalog with table test_schema.items.
+func txItemsCatalog(t *testing.T, table catalog.Table) catalog.Catalog {
+	t.Helper()
+
+	cat, err := airport.NewCatalogBuilder().
+		Schema("test_schema").
+		Table(table).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build catalog: %v", err)
+	}
+	return cat
+}
+
 // Ensure json import is used (for potential future use)
 var _ = json.Marshal
 